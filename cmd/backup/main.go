@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/backup"
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/notifier"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+	"github.com/vedsatt/pr-review-assignment-service/internal/storage"
+)
+
+func main() {
+	if !run() {
+		os.Exit(1)
+	}
+}
+
+// run returns false when the command fails, so that deferred cleanup happens before the exit.
+func run() bool {
+	var cmd string
+	var path string
+
+	flag.StringVar(&cmd, "command", "export", "Backup command: export or restore")
+	flag.StringVar(&path, "file", "-", "Backup file, - for stdout on export and stdin on restore")
+	flag.Parse()
+
+	if cmd != "export" && cmd != "restore" {
+		log.Printf("unknown command: %s", cmd)
+		return false
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Printf("failed to parse config: %v", err)
+		return false
+	}
+
+	repository, err := storage.NewRepository(cfg.StorageDriver, cfg.PostgresCfg, cfg.SQLiteCfg)
+	if err != nil {
+		log.Printf("failed to create repository: %v", err)
+		return false
+	}
+	defer repository.CloseConnection()
+
+	notifier, err := notifier.NewNotifier(cfg.NotifierCfg)
+	if err != nil {
+		log.Printf("failed to create notifier: %v", err)
+		return false
+	}
+	defer notifier.Close()
+
+	service := service.NewService(repository, notifier, metrics.New())
+
+	if cmd == "export" {
+		return export(service, path)
+	}
+
+	return restore(service, path)
+}
+
+func export(service *service.Service, path string) bool {
+	var out io.Writer = os.Stdout
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			log.Printf("failed to create file: %v", err)
+			return false
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer := bufio.NewWriter(out)
+	encoder := backup.NewEncoder(writer)
+	if errDetails := service.ExportBackup(context.Background(), encoder.Write); errDetails != nil {
+		log.Printf("export failed: %s", errDetails.Message)
+		return false
+	}
+
+	if err := writer.Flush(); err != nil {
+		log.Printf("failed to write backup: %v", err)
+		return false
+	}
+
+	log.Println("export completed successfully")
+	return true
+}
+
+func restore(service *service.Service, path string) bool {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Printf("failed to open file: %v", err)
+			return false
+		}
+		defer file.Close()
+		in = file
+	}
+
+	counts, errDetails := service.RestoreBackup(context.Background(), backup.NewDecoder(in))
+	if errDetails != nil {
+		printJSON(models.ErrorResponse{Error: *errDetails})
+		return false
+	}
+
+	printJSON(models.RestoreBackupResponse{Restored: *counts})
+	return true
+}
+
+func printJSON(value any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		log.Printf("failed to encode result: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/importer"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/notifier"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+	"github.com/vedsatt/pr-review-assignment-service/internal/storage"
+)
+
+func main() {
+	if !run() {
+		os.Exit(1)
+	}
+}
+
+// run returns false when the import is rejected, so that deferred cleanup happens before the exit.
+func run() bool {
+	var path string
+	var format string
+	var dryRun bool
+
+	flag.StringVar(&path, "file", "", "Path to the org chart file")
+	flag.StringVar(&format, "format", "", "File format: csv, json or yaml, detected by the extension by default")
+	flag.BoolVar(&dryRun, "dry-run", false, "Report the changes without applying them")
+	flag.Parse()
+
+	if path == "" {
+		log.Fatal("-file is required")
+	}
+
+	if format == "" {
+		var err error
+		format, err = importer.FormatFromFilename(path)
+		if err != nil {
+			log.Fatalf("failed to detect format of %s, set -format", path)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := importer.Parse(format, file)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", format, err)
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("failed to parse config: %v", err)
+	}
+
+	repository, err := storage.NewRepository(cfg.StorageDriver, cfg.PostgresCfg, cfg.SQLiteCfg)
+	if err != nil {
+		log.Fatalf("failed to create repository: %v", err)
+	}
+	defer repository.CloseConnection()
+
+	notifier, err := notifier.NewNotifier(cfg.NotifierCfg)
+	if err != nil {
+		log.Fatalf("failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	service := service.NewService(repository, notifier, metrics.New())
+
+	result, errDetails := service.ImportTeams(context.Background(), models.ImportRequest{Rows: rows, DryRun: dryRun})
+	if errDetails != nil {
+		printJSON(models.ErrorResponse{Error: *errDetails})
+		return false
+	}
+
+	printJSON(result)
+	return true
+}
+
+func printJSON(value any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		log.Printf("failed to encode result: %v", err)
+	}
+}
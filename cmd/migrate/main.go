@@ -9,15 +9,17 @@ import (
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/storage"
 )
 
 func main() {
 	var migrationsPath string
 	var cmd string
 
-	flag.StringVar(&migrationsPath, "path", "./migrations", "Path to migrations files")
+	flag.StringVar(&migrationsPath, "path", "", "Path to migrations files, by default the set of the storage driver")
 	flag.StringVar(&cmd, "command", "up", "Migrations command")
 	flag.Parse()
 
@@ -26,13 +28,27 @@ func main() {
 		log.Fatalf("failed to parse config: %v", err)
 	}
 
-	addr := net.JoinHostPort(cfg.PostgresCfg.Host, cfg.PostgresCfg.Port)
-	dsn := fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable",
-		cfg.PostgresCfg.User,
-		cfg.PostgresCfg.Password,
-		addr,
-		cfg.PostgresCfg.DBName,
-	)
+	var dsn string
+	switch cfg.StorageDriver {
+	case storage.DriverPostgres:
+		addr := net.JoinHostPort(cfg.PostgresCfg.Host, cfg.PostgresCfg.Port)
+		dsn = fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable",
+			cfg.PostgresCfg.User,
+			cfg.PostgresCfg.Password,
+			addr,
+			cfg.PostgresCfg.DBName,
+		)
+		if migrationsPath == "" {
+			migrationsPath = "./migrations"
+		}
+	case storage.DriverSQLite:
+		dsn = fmt.Sprintf("sqlite://%s", cfg.SQLiteCfg.Path)
+		if migrationsPath == "" {
+			migrationsPath = "./migrations/sqlite"
+		}
+	default:
+		log.Fatalf("storage driver %q has no migrations", cfg.StorageDriver)
+	}
 
 	m, err := migrate.New(
 		fmt.Sprintf("file://%s", migrationsPath),
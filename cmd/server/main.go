@@ -2,22 +2,34 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vedsatt/pr-review-assignment-service/internal/config"
-	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/digest"
+	"github.com/vedsatt/pr-review-assignment-service/internal/grpcapi"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/notifier"
 	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+	"github.com/vedsatt/pr-review-assignment-service/internal/storage"
 	"github.com/vedsatt/pr-review-assignment-service/internal/transport"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 type App struct {
 	Server     *http.Server
-	Repository *repository.Repository
+	GRPCServer *grpc.Server
+	Repository storage.Repository
+	Notifier   *notifier.Notifier
+	stopJobs   context.CancelFunc
+	jobs       sync.WaitGroup
 }
 
 func main() {
@@ -32,18 +44,62 @@ func main() {
 		zap.L().Fatal("failed to get config: %v", zap.Error(err))
 	}
 
-	repository, err := repository.NewRepository(cfg.PostgresCfg)
+	repository, err := storage.NewRepository(cfg.StorageDriver, cfg.PostgresCfg, cfg.SQLiteCfg)
 	if err != nil {
 		zap.L().Fatal("failed to create repository", zap.Error(err))
 	}
 	app.Repository = repository
 
-	service := service.NewService(repository)
+	notifier, err := notifier.NewNotifier(cfg.NotifierCfg)
+	if err != nil {
+		zap.L().Fatal("failed to create notifier", zap.Error(err))
+	}
+	app.Notifier = notifier
+
+	metrics := metrics.New()
+	if pool, ok := repository.(interface{ PoolStat() *pgxpool.Stat }); ok {
+		metrics.RegisterPool(pool.PoolStat)
+	}
+
+	service := service.NewService(repository, notifier, metrics)
+	metrics.RegisterOpenReviews(service.OpenReviewsByTeam)
+
+	if cfg.AuthCfg.AdminToken != "" {
+		if errDetails := service.EnsureAdminToken(context.Background(), cfg.AuthCfg.AdminToken); errDetails != nil {
+			zap.L().Fatal("failed to register admin token", zap.String("code", errDetails.Code))
+		}
+	}
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	app.stopJobs = stopJobs
+	app.jobs.Go(func() {
+		runReviewSLAWatcher(jobsCtx, service, cfg.ReviewSLA, cfg.SLACheckInterval)
+	})
+	app.jobs.Go(func() {
+		runIdempotencyKeyPurge(jobsCtx, service, cfg.IdempotencyPurgeInterval)
+	})
+
+	if cfg.DigestCfg.Enabled {
+		scheduler, err := newDigestScheduler(cfg, service, notifier)
+		if err != nil {
+			zap.L().Fatal("failed to create digest scheduler", zap.Error(err))
+		}
+		app.jobs.Go(func() {
+			scheduler.Run(jobsCtx)
+		})
+	}
 
 	zap.L().Info("starting server...", zap.String("port", cfg.HTTPPort))
-	server := transport.StartServer(cfg, service)
+	server := transport.StartServer(cfg, service, metrics)
 	app.Server = server
 
+	zap.L().Info("starting grpc server...", zap.String("port", cfg.GRPCPort))
+	grpcServer, err := grpcapi.StartServer(cfg, service)
+	if err != nil {
+		zap.L().Fatal("failed to start grpc server", zap.Error(err))
+	}
+	app.GRPCServer = grpcServer
+
 	app.gracefulShutdown()
 }
 
@@ -63,8 +119,76 @@ func (app *App) gracefulShutdown() {
 		zap.L().Error("failed to shutdown HTTP server", zap.Error(err))
 	}
 
+	zap.L().Info("shutting down gRPC server...")
+	app.GRPCServer.GracefulStop()
+
+	zap.L().Info("stopping background jobs...")
+	app.stopJobs()
+	app.jobs.Wait()
+
+	zap.L().Info("flushing notifications...")
+	app.Notifier.Close()
+
 	zap.L().Info("closing database connection...")
 	app.Repository.CloseConnection()
 
 	zap.L().Info("app shotdown completed")
 }
+
+func newDigestScheduler(
+	cfg *config.Config, service *service.Service, notifier *notifier.Notifier,
+) (*digest.Scheduler, error) {
+	var senders []digest.Sender
+	for _, output := range cfg.DigestCfg.Outputs {
+		switch output {
+		case digest.OutputChat:
+			senders = append(senders, digest.NewChatSender(notifier))
+		case digest.OutputEmail:
+			senders = append(senders, digest.NewEmailSender(digest.NewSMTPMailer(cfg.SMTPCfg)))
+		default:
+			return nil, fmt.Errorf("unknown digest output %q", output)
+		}
+	}
+
+	return digest.NewScheduler(cfg.DigestCfg, service, senders...)
+}
+
+func runReviewSLAWatcher(ctx context.Context, service *service.Service, sla, interval time.Duration) {
+	if sla <= 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if errDetails := service.CheckReviewSLA(ctx, sla); errDetails != nil {
+				zap.L().Error("failed to check review SLA", zap.String("code", errDetails.Code))
+			}
+		}
+	}
+}
+
+func runIdempotencyKeyPurge(ctx context.Context, service *service.Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if errDetails := service.PurgeIdempotencyKeys(ctx); errDetails != nil {
+				zap.L().Error("failed to purge idempotency keys", zap.String("code", errDetails.Code))
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+const tokenPrefix = "prs_"
+
+type AuthCfg struct {
+	Enabled    bool   `env:"AUTH_ENABLED"     env-default:"false"`
+	AdminToken string `env:"AUTH_ADMIN_TOKEN"`
+}
+
+type callerKey struct{}
+
+func WithCaller(ctx context.Context, caller *models.Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller stored by the authentication middleware.
+func CallerFromContext(ctx context.Context) (*models.Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*models.Caller)
+	return caller, ok && caller != nil
+}
+
+func GenerateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer <token>" header value.
+func BearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func IsValidRole(role string) bool {
+	switch role {
+	case models.RoleAdmin, models.RoleTeamLead, models.RoleMember, models.RoleReadOnly:
+		return true
+	default:
+		return false
+	}
+}
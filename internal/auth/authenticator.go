@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+// CallerResolver maps API tokens and verified identities to callers.
+type CallerResolver interface {
+	Authenticate(ctx context.Context, rawToken string) (*models.Caller, *models.ErrDetails)
+	AuthenticateIdentity(ctx context.Context, identity models.Identity) (*models.Caller, *models.ErrDetails)
+}
+
+// Authenticator resolves bearer tokens of any transport, JWTs are checked against the OIDC provider when configured.
+type Authenticator struct {
+	resolver    CallerResolver
+	jwtVerifier *JWTVerifier
+}
+
+func NewAuthenticator(resolver CallerResolver, cfg OIDCCfg, client *http.Client) *Authenticator {
+	authenticator := &Authenticator{resolver: resolver}
+	if cfg.JWKSURL != "" {
+		authenticator.jwtVerifier = NewJWTVerifier(cfg, client)
+	}
+
+	return authenticator
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*models.Caller, *models.ErrDetails) {
+	if a.jwtVerifier == nil || !LooksLikeJWT(token) {
+		return a.resolver.Authenticate(ctx, token)
+	}
+
+	identity, err := a.jwtVerifier.Verify(ctx, token)
+	if err != nil {
+		zap.L().Info("jwt verification failed", zap.Error(err))
+		return nil, &models.ErrDetails{Code: models.UnauthorizedErr, Message: "invalid or expired token"}
+	}
+
+	return a.resolver.AuthenticateIdentity(ctx, identity)
+}
+
+func AdminRoles() []string {
+	return []string{models.RoleAdmin}
+}
+
+func ManagerRoles() []string {
+	return []string{models.RoleAdmin, models.RoleTeamLead}
+}
+
+func WriterRoles() []string {
+	return []string{models.RoleAdmin, models.RoleTeamLead, models.RoleMember}
+}
+
+func ReaderRoles() []string {
+	return []string{models.RoleAdmin, models.RoleTeamLead, models.RoleMember, models.RoleReadOnly}
+}
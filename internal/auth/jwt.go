@@ -0,0 +1,431 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"golang.org/x/sync/singleflight"
+)
+
+const minJWKSRefreshInterval = time.Minute
+
+var (
+	ErrInvalidJWT = errors.New("invalid jwt")
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+type OIDCCfg struct {
+	JWKSURL         string            `env:"OIDC_JWKS_URL"`
+	Issuer          string            `env:"OIDC_ISSUER"`
+	Audience        string            `env:"OIDC_AUDIENCE"`
+	UserClaim       string            `env:"OIDC_USER_CLAIM"       env-default:"sub"`
+	RoleClaim       string            `env:"OIDC_ROLE_CLAIM"       env-default:"role"`
+	RoleMapping     map[string]string `env:"OIDC_ROLE_MAPPING"`
+	DefaultRole     string            `env:"OIDC_DEFAULT_ROLE"     env-default:"member"`
+	RefreshInterval time.Duration     `env:"OIDC_JWKS_REFRESH"     env-default:"1h"`
+	Leeway          time.Duration     `env:"OIDC_CLOCK_LEEWAY"     env-default:"30s"`
+}
+
+// Validate rejects a JWKS without an expected issuer and audience, otherwise tokens the provider
+// issued for any other client would be accepted.
+func (cfg OIDCCfg) Validate() error {
+	if cfg.JWKSURL == "" {
+		return nil
+	}
+
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return errors.New("OIDC_ISSUER and OIDC_AUDIENCE are required with OIDC_JWKS_URL")
+	}
+
+	return nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// JWTVerifier validates tokens issued by an OIDC provider against its JWKS.
+// Keys are cached and refetched periodically or when a token is signed with an unknown key,
+// concurrent refetches share a single request to the provider.
+type JWTVerifier struct {
+	cfg     OIDCCfg
+	client  *http.Client
+	refetch singleflight.Group
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewJWTVerifier(cfg OIDCCfg, client *http.Client) *JWTVerifier {
+	return &JWTVerifier{
+		cfg:    cfg,
+		client: client,
+		keys:   make(map[string]crypto.PublicKey),
+	}
+}
+
+// LooksLikeJWT tells JWTs apart from opaque API tokens.
+func LooksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// Verify checks the signature and registered claims of the token and extracts the caller identity.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (models.Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return models.Identity{}, fmt.Errorf("%w: malformed token", ErrInvalidJWT)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return models.Identity{}, fmt.Errorf("%w: header: %w", ErrInvalidJWT, err)
+	}
+
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%w: signature: %w", ErrInvalidJWT, err)
+	}
+
+	if err = verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return models.Identity{}, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+
+	var claims map[string]any
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return models.Identity{}, fmt.Errorf("%w: claims: %w", ErrInvalidJWT, err)
+	}
+
+	if err = v.validateClaims(claims); err != nil {
+		return models.Identity{}, fmt.Errorf("%w: %w", ErrInvalidJWT, err)
+	}
+
+	return v.identity(claims)
+}
+
+func (v *JWTVerifier) validateClaims(claims map[string]any) error {
+	now := time.Now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("missing exp claim")
+	}
+
+	if now.After(time.Unix(int64(exp), 0).Add(v.cfg.Leeway)) {
+		return errors.New("token expired")
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(v.cfg.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return errors.New("token is not valid yet")
+	}
+
+	if claims["iss"] != v.cfg.Issuer {
+		return errors.New("unexpected issuer")
+	}
+
+	if !hasAudience(claims["aud"], v.cfg.Audience) {
+		return errors.New("unexpected audience")
+	}
+
+	return nil
+}
+
+func hasAudience(claim any, audience string) bool {
+	switch aud := claim.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		return slices.Contains(aud, any(audience))
+	default:
+		return false
+	}
+}
+
+func (v *JWTVerifier) identity(claims map[string]any) (models.Identity, error) {
+	value, _ := claims[v.cfg.UserClaim].(string)
+	if value == "" {
+		return models.Identity{}, fmt.Errorf("%w: missing %s claim", ErrInvalidJWT, v.cfg.UserClaim)
+	}
+
+	identity := models.Identity{Role: v.cfg.DefaultRole}
+	if v.cfg.UserClaim == "email" {
+		// Anyone can put an address they don't own into their profile, only verified ones identify the user.
+		if verified, _ := claims["email_verified"].(bool); !verified {
+			return models.Identity{}, fmt.Errorf("%w: email is not verified", ErrInvalidJWT)
+		}
+		identity.Email = value
+	} else {
+		identity.UserID = value
+	}
+
+	if role, ok := v.mappedRole(claims[v.cfg.RoleClaim]); ok {
+		identity.Role = role
+	}
+
+	return identity, nil
+}
+
+// mappedRole translates the provider roles of the claim through OIDC_ROLE_MAPPING, roles missing
+// from the mapping are ignored. The claim may hold a single role or a list, the most privileged mapped role wins.
+func (v *JWTVerifier) mappedRole(claim any) (string, bool) {
+	var providerRoles []string
+	switch value := claim.(type) {
+	case string:
+		providerRoles = []string{value}
+	case []any:
+		for _, item := range value {
+			if role, ok := item.(string); ok {
+				providerRoles = append(providerRoles, role)
+			}
+		}
+	}
+
+	byPrivilege := []string{models.RoleAdmin, models.RoleTeamLead, models.RoleMember, models.RoleReadOnly}
+
+	best := -1
+	for _, providerRole := range providerRoles {
+		role, ok := v.cfg.RoleMapping[providerRole]
+		if !ok {
+			continue
+		}
+
+		rank := slices.Index(byPrivilege, role)
+		if rank >= 0 && (best < 0 || rank < best) {
+			best = rank
+		}
+	}
+
+	if best < 0 {
+		return "", false
+	}
+
+	return byPrivilege[best], true
+}
+
+func (v *JWTVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.fetchedAt) > v.cfg.RefreshInterval
+	recent := time.Since(v.fetchedAt) < minJWKSRefreshInterval
+	v.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if !ok && recent {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	// The fetch is shared by concurrent callers, so it must not be cancelled together with one of them.
+	_, err, _ := v.refetch.Do("jwks", func() (any, error) {
+		return nil, v.refresh(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+func (v *JWTVerifier) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create jwks request: %w", err)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch jwks: status %d", resp.StatusCode)
+	}
+
+	var set jwks
+	if err = json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// ecCurves binds every ECDSA alg to the only curve it is defined for.
+func ecCurves() map[string]elliptic.Curve {
+	return map[string]elliptic.Curve{
+		"ES256": elliptic.P256(),
+		"ES384": elliptic.P384(),
+		"ES512": elliptic.P521(),
+	}
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("alg %q does not match rsa key", alg)
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		if curve, ok := ecCurves()[alg]; !ok || curve != pub.Curve {
+			return fmt.Errorf("alg %q does not match %s key", alg, pub.Curve.Params().Name)
+		}
+
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature length")
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return errors.New("unsupported key")
+	}
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,466 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testAudience = "pr-review"
+)
+
+// signingKey is a locally generated key published by the test JWKS server under its kid.
+type signingKey struct {
+	kid string
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newRSAKey(t *testing.T, kid string) signingKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	return signingKey{kid: kid, rsa: key}
+}
+
+func newECKey(t *testing.T, kid string) signingKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ec key: %v", err)
+	}
+
+	return signingKey{kid: kid, ec: key}
+}
+
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func (k signingKey) jwk() map[string]string {
+	if k.rsa != nil {
+		return map[string]string{
+			"kty": "RSA", "kid": k.kid, "use": "sig",
+			"n": encodeBigInt(k.rsa.N), "e": encodeBigInt(big.NewInt(int64(k.rsa.E))),
+		}
+	}
+
+	return map[string]string{
+		"kty": "EC", "kid": k.kid, "use": "sig", "crv": "P-256",
+		"x": encodeBigInt(k.ec.X), "y": encodeBigInt(k.ec.Y),
+	}
+}
+
+func (k signingKey) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	if k.ec != nil {
+		return k.signAs(t, "ES256", claims)
+	}
+	return k.signAs(t, "RS256", claims)
+}
+
+// signAs signs the token with the hash of alg, whatever the key is.
+func (k signingKey) signAs(t *testing.T, alg string, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": k.kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatalf("failed to encode header: %v", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to encode claims: %v", err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	hash := map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}[alg[2:]]
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	var signature []byte
+	if k.rsa != nil {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, hash, digest)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+	} else {
+		r, s, err := ecdsa.Sign(rand.Reader, k.ec, digest)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		size := (k.ec.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// jwksServer publishes the current keys and counts how often they are fetched.
+type jwksServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	keys    []signingKey
+	delay   time.Duration
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, keys ...signingKey) *jwksServer {
+	t.Helper()
+
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.fetches.Add(1)
+		time.Sleep(s.delay)
+
+		s.mu.Lock()
+		set := map[string][]map[string]string{"keys": {}}
+		for _, key := range s.keys {
+			set["keys"] = append(set["keys"], key.jwk())
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *jwksServer) rotate(keys ...signingKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func newVerifier(jwksURL string, mapping map[string]string) *auth.JWTVerifier {
+	return auth.NewJWTVerifier(auth.OIDCCfg{
+		JWKSURL:         jwksURL,
+		Issuer:          testIssuer,
+		Audience:        testAudience,
+		UserClaim:       "sub",
+		RoleClaim:       "role",
+		RoleMapping:     mapping,
+		DefaultRole:     models.RoleMember,
+		RefreshInterval: time.Hour,
+		Leeway:          time.Second,
+	}, &http.Client{Timeout: 5 * time.Second})
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub": "u1",
+		"iss": testIssuer,
+		"aud": []string{"other", testAudience},
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"nbf": time.Now().Add(-time.Minute).Unix(),
+	}
+}
+
+func withClaim(claims map[string]any, name string, value any) map[string]any {
+	claims[name] = value
+	return claims
+}
+
+func TestVerifyAcceptsValidTokens(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	jwks := newJWKSServer(t, rsaKey, ecKey)
+	verifier := newVerifier(jwks.URL, nil)
+
+	for _, key := range []signingKey{rsaKey, ecKey} {
+		identity, err := verifier.Verify(t.Context(), key.sign(t, validClaims()))
+		if err != nil {
+			t.Fatalf("Verify(%s) error = %v", key.kid, err)
+		}
+
+		if identity.UserID != "u1" || identity.Role != models.RoleMember {
+			t.Errorf("Verify(%s) identity = %+v, want u1 with the default role", key.kid, identity)
+		}
+	}
+
+	if fetches := jwks.fetches.Load(); fetches != 1 {
+		t.Errorf("jwks fetched %d times, want the keys to be cached after the first fetch", fetches)
+	}
+}
+
+func TestVerifyEmailClaim(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	jwks := newJWKSServer(t, key)
+	verifier := auth.NewJWTVerifier(auth.OIDCCfg{
+		JWKSURL: jwks.URL, Issuer: testIssuer, Audience: testAudience,
+		UserClaim: "email", RoleClaim: "role", DefaultRole: models.RoleReadOnly, RefreshInterval: time.Hour,
+	}, http.DefaultClient)
+
+	claims := withClaim(validClaims(), "email", "alice@example.com")
+	identity, err := verifier.Verify(t.Context(), key.sign(t, withClaim(claims, "email_verified", true)))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if identity.Email != "alice@example.com" || identity.UserID != "" || identity.Role != models.RoleReadOnly {
+		t.Errorf("Verify() identity = %+v, want alice@example.com with read-only role", identity)
+	}
+
+	for _, verified := range []any{false, "true", nil} {
+		claims = withClaim(validClaims(), "email", "alice@example.com")
+		token := key.sign(t, withClaim(claims, "email_verified", verified))
+		if _, err = verifier.Verify(t.Context(), token); !errors.Is(err, auth.ErrInvalidJWT) {
+			t.Errorf("Verify(email_verified = %v) error = %v, want ErrInvalidJWT", verified, err)
+		}
+	}
+}
+
+func TestVerifyBindsAlgToTheKey(t *testing.T) {
+	ecKey := newECKey(t, "ec-1")
+	rsaKey := newRSAKey(t, "rsa-1")
+	jwks := newJWKSServer(t, ecKey, rsaKey)
+	verifier := newVerifier(jwks.URL, nil)
+
+	for _, token := range []string{
+		ecKey.signAs(t, "ES384", validClaims()),
+		ecKey.signAs(t, "ES512", validClaims()),
+		rsaKey.signAs(t, "ES256", validClaims()),
+	} {
+		if _, err := verifier.Verify(t.Context(), token); !errors.Is(err, auth.ErrInvalidJWT) {
+			t.Errorf("Verify() error = %v, want ErrInvalidJWT", err)
+		}
+	}
+
+	if _, err := verifier.Verify(t.Context(), rsaKey.signAs(t, "RS384", validClaims())); err != nil {
+		t.Errorf("Verify(RS384) error = %v", err)
+	}
+}
+
+func TestOIDCCfgRequiresIssuerAndAudience(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     auth.OIDCCfg
+		wantErr bool
+	}{
+		{name: "oidc disabled", cfg: auth.OIDCCfg{}},
+		{name: "complete", cfg: auth.OIDCCfg{JWKSURL: "https://idp", Issuer: testIssuer, Audience: testAudience}},
+		{name: "no issuer", cfg: auth.OIDCCfg{JWKSURL: "https://idp", Audience: testAudience}, wantErr: true},
+		{name: "no audience", cfg: auth.OIDCCfg{JWKSURL: "https://idp", Issuer: testIssuer}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	impostor := newRSAKey(t, "rsa-1")
+	jwks := newJWKSServer(t, key)
+	verifier := newVerifier(jwks.URL, nil)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "expired", token: key.sign(t, withClaim(validClaims(), "exp", time.Now().Add(-time.Minute).Unix()))},
+		{name: "not yet valid", token: key.sign(t, withClaim(validClaims(), "nbf", time.Now().Add(time.Hour).Unix()))},
+		{name: "missing exp", token: key.sign(t, withClaim(validClaims(), "exp", nil))},
+		{name: "wrong issuer", token: key.sign(t, withClaim(validClaims(), "iss", "https://evil.example.com"))},
+		{name: "wrong audience", token: key.sign(t, withClaim(validClaims(), "aud", "other"))},
+		{name: "missing subject", token: key.sign(t, withClaim(validClaims(), "sub", ""))},
+		{name: "foreign signature", token: impostor.sign(t, validClaims())},
+		{name: "malformed", token: "not.a-jwt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(t.Context(), tt.token); !errors.Is(err, auth.ErrInvalidJWT) {
+				t.Errorf("Verify() error = %v, want ErrInvalidJWT", err)
+			}
+		})
+	}
+}
+
+func TestVerifyMapsProviderRoles(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	jwks := newJWKSServer(t, key)
+	verifier := newVerifier(jwks.URL, map[string]string{
+		"pr-admins": models.RoleAdmin,
+		"leads":     models.RoleTeamLead,
+		"auditors":  models.RoleReadOnly,
+		"typo":      "superuser",
+	})
+
+	tests := []struct {
+		name string
+		role any
+		want string
+	}{
+		{name: "mapped role", role: "pr-admins", want: models.RoleAdmin},
+		{name: "service role name is not trusted", role: models.RoleAdmin, want: models.RoleMember},
+		{name: "unmapped role", role: "contractors", want: models.RoleMember},
+		{name: "invalid mapping target", role: "typo", want: models.RoleMember},
+		{name: "most privileged of a list", role: []string{"auditors", "leads", "admin"}, want: models.RoleTeamLead},
+		{name: "no role claim", role: nil, want: models.RoleMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(t.Context(), key.sign(t, withClaim(validClaims(), "role", tt.role)))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			if identity.Role != tt.want {
+				t.Errorf("Verify() role = %q, want %q", identity.Role, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRefetchesRotatedKeys(t *testing.T) {
+	oldKey := newRSAKey(t, "2024")
+	newKey := newRSAKey(t, "2025")
+	jwks := newJWKSServer(t, oldKey)
+	verifier := newVerifier(jwks.URL, nil)
+
+	if _, err := verifier.Verify(t.Context(), oldKey.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify(old key) error = %v", err)
+	}
+
+	jwks.rotate(oldKey, newKey)
+
+	// Right after a fetch an unknown kid is rejected without asking the provider again.
+	if _, err := verifier.Verify(t.Context(), newKey.sign(t, validClaims())); !errors.Is(err, auth.ErrUnknownKey) {
+		t.Fatalf("Verify(new key) error = %v, want ErrUnknownKey", err)
+	}
+	if fetches := jwks.fetches.Load(); fetches != 1 {
+		t.Errorf("jwks fetched %d times, want 1", fetches)
+	}
+
+	staleVerifier := auth.NewJWTVerifier(auth.OIDCCfg{
+		JWKSURL: jwks.URL, Issuer: testIssuer, Audience: testAudience,
+		UserClaim: "sub", RoleClaim: "role", DefaultRole: models.RoleMember,
+	}, http.DefaultClient)
+	if _, err := staleVerifier.Verify(t.Context(), oldKey.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify(old key) error = %v", err)
+	}
+	if _, err := staleVerifier.Verify(t.Context(), newKey.sign(t, validClaims())); err != nil {
+		t.Errorf("Verify(new key) error = %v, want the rotated key to be fetched", err)
+	}
+}
+
+func TestVerifySharesConcurrentJWKSFetches(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	jwks := newJWKSServer(t, key)
+	jwks.delay = 100 * time.Millisecond
+	verifier := newVerifier(jwks.URL, nil)
+
+	token := key.sign(t, validClaims())
+	unknown := newRSAKey(t, "unknown").sign(t, validClaims())
+
+	const callers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := range callers {
+		wg.Go(func() {
+			tok := token
+			if i%2 == 1 {
+				tok = unknown
+			}
+
+			_, err := verifier.Verify(t.Context(), tok)
+			if tok == token && err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Verify() error = %v", err)
+	}
+
+	if fetches := jwks.fetches.Load(); fetches != 1 {
+		t.Errorf("jwks fetched %d times for a burst of %d tokens, want 1", fetches, callers)
+	}
+}
+
+type fakeResolver struct {
+	identity models.Identity
+	rawToken string
+}
+
+func (r *fakeResolver) Authenticate(_ context.Context, rawToken string) (*models.Caller, *models.ErrDetails) {
+	r.rawToken = rawToken
+	return &models.Caller{Role: models.RoleAdmin}, nil
+}
+
+func (r *fakeResolver) AuthenticateIdentity(
+	_ context.Context, identity models.Identity,
+) (*models.Caller, *models.ErrDetails) {
+	r.identity = identity
+	return &models.Caller{UserID: identity.UserID, Role: identity.Role}, nil
+}
+
+func TestAuthenticatorRoutesJWTsToTheVerifier(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	jwks := newJWKSServer(t, key)
+	resolver := &fakeResolver{}
+	authenticator := auth.NewAuthenticator(resolver, auth.OIDCCfg{
+		JWKSURL: jwks.URL, Issuer: testIssuer, Audience: testAudience,
+		UserClaim: "sub", RoleClaim: "role", DefaultRole: models.RoleMember, RefreshInterval: time.Hour,
+	}, http.DefaultClient)
+
+	caller, errDetails := authenticator.Authenticate(t.Context(), key.sign(t, validClaims()))
+	if errDetails != nil {
+		t.Fatalf("Authenticate(jwt) error = %+v", errDetails)
+	}
+	if caller.UserID != "u1" || resolver.identity.UserID != "u1" {
+		t.Errorf("Authenticate(jwt) caller = %+v, want u1", caller)
+	}
+
+	if _, errDetails = authenticator.Authenticate(t.Context(), "opaque-api-token"); errDetails != nil {
+		t.Fatalf("Authenticate(opaque) error = %+v", errDetails)
+	}
+	if resolver.rawToken != "opaque-api-token" {
+		t.Errorf("opaque token was not resolved as an API token")
+	}
+
+	forged := newRSAKey(t, "rsa-1").sign(t, validClaims())
+	if _, errDetails = authenticator.Authenticate(t.Context(), forged); errDetails == nil ||
+		errDetails.Code != models.UnauthorizedErr {
+		t.Errorf("Authenticate(forged) error = %+v, want UNAUTHORIZED", errDetails)
+	}
+}
@@ -0,0 +1,85 @@
+// Package backup reads and writes NDJSON backups, one models.BackupRecord per line.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// ContentType is the media type of a backup.
+const ContentType = "application/x-ndjson"
+
+const maxLineSize = 1 << 20
+
+var ErrEmptyLine = errors.New("empty line")
+
+type Encoder struct {
+	encoder *json.Encoder
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{encoder: json.NewEncoder(w)}
+}
+
+// Write writes the record followed by a newline.
+func (e *Encoder) Write(record models.BackupRecord) error {
+	if err := e.encoder.Encode(record); err != nil {
+		return fmt.Errorf("failed to write backup record: %w", err)
+	}
+
+	return nil
+}
+
+type Decoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &Decoder{scanner: scanner}
+}
+
+// Next reads the next record, it returns io.EOF after the last one.
+// Unknown fields and blank lines are rejected, so that the line number matches the record number.
+func (d *Decoder) Next() (*models.BackupRecord, error) {
+	if !d.scanner.Scan() {
+		if err := d.scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+		return nil, io.EOF
+	}
+	d.line++
+
+	line := bytes.TrimSpace(d.scanner.Bytes())
+	if len(line) == 0 {
+		return nil, ErrEmptyLine
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.DisallowUnknownFields()
+
+	var record models.BackupRecord
+	if err := decoder.Decode(&record); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if decoder.More() {
+		return nil, errors.New("invalid JSON: more than one value on the line")
+	}
+
+	return &record, nil
+}
+
+// Line returns the number of the line read by the last call to Next.
+func (d *Decoder) Line() int {
+	return d.line
+}
@@ -2,16 +2,38 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/digest"
+	"github.com/vedsatt/pr-review-assignment-service/internal/notifier"
 	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/sqlite"
 	"go.uber.org/zap"
 )
 
 type Config struct {
 	repository.PostgresCfg
+	sqlite.SQLiteCfg
+	notifier.NotifierCfg
+	digest.DigestCfg
+	digest.SMTPCfg
+	auth.AuthCfg
+	auth.OIDCCfg
 
-	HTTPPort string `env:"PORT" env-default:"8080"`
+	HTTPPort string `env:"PORT"      env-default:"8080"`
+	GRPCPort string `env:"GRPC_PORT" env-default:"9090"`
+
+	// StorageDriver selects the repository backend: postgres, sqlite or memory for local runs without a database.
+	StorageDriver string `env:"STORAGE_DRIVER" env-default:"postgres"`
+
+	// SCIMDefaultTeam holds users provisioned without a department and users removed from their SCIM group.
+	SCIMDefaultTeam string `env:"SCIM_DEFAULT_TEAM" env-default:"unassigned"`
+
+	// IdempotencyTTL is how long the response to a request with an Idempotency-Key is replayed.
+	IdempotencyTTL           time.Duration `env:"IDEMPOTENCY_TTL"            env-default:"24h"`
+	IdempotencyPurgeInterval time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" env-default:"1h"`
 }
 
 func NewConfig() (*Config, error) {
@@ -26,5 +48,9 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
-	return &cfg, err
+	if err = cfg.OIDCCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid oidc config: %w", err)
+	}
+
+	return &cfg, nil
 }
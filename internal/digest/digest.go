@@ -0,0 +1,139 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	OutputChat  = "chat"
+	OutputEmail = "email"
+)
+
+type DigestCfg struct {
+	Enabled     bool              `env:"DIGEST_ENABLED"      env-default:"false"`
+	DefaultTime string            `env:"DIGEST_DEFAULT_TIME" env-default:"09:00"`
+	TeamTimes   map[string]string `env:"DIGEST_TEAM_TIMES"`
+	Timezone    string            `env:"DIGEST_TIMEZONE"     env-default:"UTC"`
+	Outputs     []string          `env:"DIGEST_OUTPUTS"      env-default:"chat"`
+}
+
+type Service interface {
+	GetTeamNames(ctx context.Context) ([]string, *models.ErrDetails)
+	GetTeamDigest(ctx context.Context, teamName string) (*models.TeamDigest, *models.ErrDetails)
+}
+
+type Sender interface {
+	SendDigest(ctx context.Context, digest *models.TeamDigest) error
+}
+
+type Scheduler struct {
+	cfg      DigestCfg
+	service  Service
+	senders  []Sender
+	location *time.Location
+}
+
+func NewScheduler(cfg DigestCfg, service Service, senders ...Sender) (*Scheduler, error) {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %q: %w", cfg.Timezone, err)
+	}
+
+	for team, at := range cfg.TeamTimes {
+		if _, err = time.Parse("15:04", at); err != nil {
+			return nil, fmt.Errorf("invalid digest time %q for team %s: %w", at, team, err)
+		}
+	}
+
+	if cfg.DefaultTime != "" {
+		if _, err = time.Parse("15:04", cfg.DefaultTime); err != nil {
+			return nil, fmt.Errorf("invalid default digest time %q: %w", cfg.DefaultTime, err)
+		}
+	}
+
+	scheduler := &Scheduler{
+		cfg:      cfg,
+		service:  service,
+		senders:  senders,
+		location: location,
+	}
+	return scheduler, nil
+}
+
+// Run checks the schedule every minute and sends digests of the teams whose time has come.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	lastCheck := time.Now().In(s.location)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			now = now.In(s.location)
+			s.sendDue(ctx, lastCheck, now)
+			lastCheck = now
+		}
+	}
+}
+
+func (s *Scheduler) sendDue(ctx context.Context, from, to time.Time) {
+	teams, errDetails := s.service.GetTeamNames(ctx)
+	if errDetails != nil {
+		zap.L().Error("failed to get teams for digest", zap.String("code", errDetails.Code))
+		return
+	}
+
+	for _, team := range teams {
+		scheduled, ok := s.scheduledAt(team, to)
+		if !ok || !scheduled.After(from) || scheduled.After(to) {
+			continue
+		}
+
+		if err := s.SendTeamDigest(ctx, team); err != nil {
+			zap.L().Error("failed to send digest", zap.Error(err), zap.String("team_name", team))
+		}
+	}
+}
+
+func (s *Scheduler) scheduledAt(team string, day time.Time) (time.Time, bool) {
+	at, ok := s.cfg.TeamTimes[team]
+	if !ok {
+		at = s.cfg.DefaultTime
+	}
+
+	if at == "" {
+		return time.Time{}, false
+	}
+
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	scheduled := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, s.location)
+	return scheduled, true
+}
+
+func (s *Scheduler) SendTeamDigest(ctx context.Context, team string) error {
+	digest, errDetails := s.service.GetTeamDigest(ctx, team)
+	if errDetails != nil {
+		return fmt.Errorf("failed to build digest: %s: %s", errDetails.Code, errDetails.Message)
+	}
+
+	var errs []error
+	for _, sender := range s.senders {
+		if err := sender.SendDigest(ctx, digest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
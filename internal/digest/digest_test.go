@@ -0,0 +1,115 @@
+package digest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/digest"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+type fakeService struct {
+	teams []string
+}
+
+func (s *fakeService) GetTeamNames(_ context.Context) ([]string, *models.ErrDetails) {
+	return s.teams, nil
+}
+
+func (s *fakeService) GetTeamDigest(_ context.Context, teamName string) (*models.TeamDigest, *models.ErrDetails) {
+	if !slices.Contains(s.teams, teamName) {
+		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "team not found"}
+	}
+
+	return &models.TeamDigest{TeamName: teamName}, nil
+}
+
+type recordingSender struct {
+	mu    sync.Mutex
+	teams []string
+	err   error
+}
+
+func (s *recordingSender) SendDigest(_ context.Context, digest *models.TeamDigest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.teams = append(s.teams, digest.TeamName)
+
+	return s.err
+}
+
+func TestNewSchedulerValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  digest.DigestCfg
+	}{
+		{name: "unknown timezone", cfg: digest.DigestCfg{Timezone: "Mars/Olympus"}},
+		{name: "invalid default time", cfg: digest.DigestCfg{Timezone: "UTC", DefaultTime: "9am"}},
+		{name: "invalid team time", cfg: digest.DigestCfg{Timezone: "UTC", TeamTimes: map[string]string{"a": "25:00"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := digest.NewScheduler(tt.cfg, &fakeService{}); err == nil {
+				t.Error("NewScheduler() error = nil, want config error")
+			}
+		})
+	}
+}
+
+func TestSendDueHonoursTeamTimes(t *testing.T) {
+	sender := &recordingSender{}
+	scheduler, err := digest.NewScheduler(digest.DigestCfg{
+		DefaultTime: "09:00",
+		TeamTimes:   map[string]string{"payments": "10:30"},
+		Timezone:    "Europe/Moscow",
+	}, &fakeService{teams: []string{"backend", "payments"}}, sender)
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 10, hour, minute, 0, 0, moscow)
+	}
+
+	scheduler.SendDue(t.Context(), at(8, 59), at(9, 0))
+	scheduler.SendDue(t.Context(), at(9, 0), at(9, 1))
+	scheduler.SendDue(t.Context(), at(10, 29), at(10, 30))
+	// Neither team is due between 11:59 and 12:00.
+	scheduler.SendDue(t.Context(), at(11, 59), at(12, 0))
+
+	if want := []string{"backend", "payments"}; !slices.Equal(sender.teams, want) {
+		t.Errorf("digests sent to %v, want %v", sender.teams, want)
+	}
+}
+
+func TestSendTeamDigestUsesEverySender(t *testing.T) {
+	failing := &recordingSender{err: errors.New("smtp is down")}
+	chat := &recordingSender{}
+	scheduler, err := digest.NewScheduler(digest.DigestCfg{Timezone: "UTC"},
+		&fakeService{teams: []string{"backend"}}, failing, chat)
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	err = scheduler.SendTeamDigest(t.Context(), "backend")
+	if !errors.Is(err, failing.err) {
+		t.Errorf("SendTeamDigest() error = %v, want %v", err, failing.err)
+	}
+
+	if !slices.Equal(chat.teams, []string{"backend"}) {
+		t.Errorf("chat sender got %v, want the digest despite the failing sender", chat.teams)
+	}
+
+	if err = scheduler.SendTeamDigest(t.Context(), "unknown"); err == nil {
+		t.Error("SendTeamDigest() error = nil for an unknown team")
+	}
+}
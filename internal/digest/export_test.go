@@ -0,0 +1,11 @@
+package digest
+
+import (
+	"context"
+	"time"
+)
+
+// SendDue exposes the schedule check of Run, which otherwise only fires from a minute ticker.
+func (s *Scheduler) SendDue(ctx context.Context, from, to time.Time) {
+	s.sendDue(ctx, from, to)
+}
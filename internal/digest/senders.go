@@ -0,0 +1,150 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+const (
+	chatTemplate = `Daily review digest for {{.TeamName}}, {{.GeneratedAt.Format "2006-01-02"}}
+{{range .Users}}{{if or .Reviews .AwaitingReviews}}
+{{handle .User.ID}}: {{len .Reviews}} review(s) to do, {{len .AwaitingReviews}} pull request(s) waiting for review
+{{range .Reviews}}  • review "{{.Name}}" ({{.ID}}) by {{handle .AuthorID}}, assigned {{age (assignedAt .)}} ago
+{{end}}{{range .AwaitingReviews}}  • your "{{.Name}}" ({{.ID}}) is waiting for {{age .CreatedAt}}
+{{end}}{{end}}{{end}}`
+
+	emailTemplate = `Hi {{.User.Username}},
+
+{{if .Reviews}}Pull requests waiting for your review:
+{{range .Reviews}}  - "{{.Name}}" ({{.ID}}) by {{.AuthorID}}, assigned {{age (assignedAt .)}} ago
+{{end}}
+{{end}}{{if .AwaitingReviews}}Your pull requests waiting for review:
+{{range .AwaitingReviews}}  - "{{.Name}}" ({{.ID}}), open for {{age .CreatedAt}}
+{{end}}{{end}}`
+)
+
+// Poster delivers chat messages to the channel of a team, see notifier.Notifier.
+type Poster interface {
+	Post(ctx context.Context, teamName, text string) error
+	Handle(userID string) string
+}
+
+type ChatSender struct {
+	poster   Poster
+	template *template.Template
+}
+
+func NewChatSender(poster Poster) *ChatSender {
+	funcs := template.FuncMap{
+		"handle":     poster.Handle,
+		"age":        age,
+		"assignedAt": assignedAt,
+	}
+
+	return &ChatSender{
+		poster:   poster,
+		template: template.Must(template.New("chat").Funcs(funcs).Parse(chatTemplate)),
+	}
+}
+
+func (s *ChatSender) SendDigest(ctx context.Context, digest *models.TeamDigest) error {
+	if !hasEntries(digest.Users) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := s.template.Execute(&buf, digest); err != nil {
+		return fmt.Errorf("failed to render chat digest: %w", err)
+	}
+
+	if err := s.poster.Post(ctx, digest.TeamName, buf.String()); err != nil {
+		return fmt.Errorf("failed to post chat digest: %w", err)
+	}
+
+	return nil
+}
+
+type Mailer interface {
+	SendMail(ctx context.Context, to []string, subject, body string) error
+}
+
+type EmailSender struct {
+	mailer   Mailer
+	template *template.Template
+}
+
+func NewEmailSender(mailer Mailer) *EmailSender {
+	funcs := template.FuncMap{
+		"age":        age,
+		"assignedAt": assignedAt,
+	}
+
+	return &EmailSender{
+		mailer:   mailer,
+		template: template.Must(template.New("email").Funcs(funcs).Parse(emailTemplate)),
+	}
+}
+
+func (s *EmailSender) SendDigest(ctx context.Context, digest *models.TeamDigest) error {
+	subject := fmt.Sprintf("Review digest for %s", digest.GeneratedAt.Format("2006-01-02"))
+
+	var errs []error
+	for _, user := range digest.Users {
+		if user.User.Email == "" || !hasEntries([]models.UserDigest{user}) {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := s.template.Execute(&buf, user); err != nil {
+			errs = append(errs, fmt.Errorf("failed to render email digest for %s: %w", user.User.ID, err))
+			continue
+		}
+
+		if err := s.mailer.SendMail(ctx, []string{user.User.Email}, subject, buf.String()); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send email digest to %s: %w", user.User.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func hasEntries(users []models.UserDigest) bool {
+	for _, user := range users {
+		if len(user.Reviews) > 0 || len(user.AwaitingReviews) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assignedAt is when the reviewer got the pull request, which is later than its creation after a reassignment.
+func assignedAt(pr *models.PullRequestShort) time.Time {
+	if pr.AssignedAt != nil {
+		return *pr.AssignedAt
+	}
+
+	return pr.CreatedAt
+}
+
+func age(since time.Time) string {
+	elapsed := time.Since(since)
+
+	days := int(elapsed / (24 * time.Hour))
+	hours := int(elapsed % (24 * time.Hour) / time.Hour)
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return "less than an hour"
+	}
+}
@@ -0,0 +1,129 @@
+package digest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/digest"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+type fakePoster struct {
+	team  string
+	texts []string
+}
+
+func (p *fakePoster) Post(_ context.Context, teamName, text string) error {
+	p.team = teamName
+	p.texts = append(p.texts, text)
+	return nil
+}
+
+func (p *fakePoster) Handle(userID string) string {
+	return "@" + userID
+}
+
+type sentMail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+type fakeMailer struct {
+	mails []sentMail
+}
+
+func (m *fakeMailer) SendMail(_ context.Context, to []string, subject, body string) error {
+	m.mails = append(m.mails, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+// reassignedDigest has a review created five days ago and handed to u2 two hours ago.
+func reassignedDigest() *models.TeamDigest {
+	now := time.Now()
+	assignedAt := now.Add(-2*time.Hour - time.Minute)
+
+	return &models.TeamDigest{
+		TeamName:    "backend",
+		GeneratedAt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		Users: []models.UserDigest{
+			{
+				User: models.User{ID: "u1", Username: "alice", Email: "alice@example.com"},
+				AwaitingReviews: []*models.PullRequestShort{
+					{ID: "pr-1", Name: "Add cache", AuthorID: "u1", CreatedAt: now.Add(-5*24*time.Hour - time.Hour)},
+				},
+			},
+			{
+				User: models.User{ID: "u2", Username: "bob", Email: "bob@example.com"},
+				Reviews: []*models.PullRequestShort{
+					{
+						ID: "pr-1", Name: "Add cache", AuthorID: "u1",
+						CreatedAt: now.Add(-5*24*time.Hour - time.Hour), AssignedAt: &assignedAt,
+					},
+				},
+			},
+			{User: models.User{ID: "u3", Username: "carol", Email: "carol@example.com"}},
+		},
+	}
+}
+
+func TestChatSenderReportsAssignmentAge(t *testing.T) {
+	poster := &fakePoster{}
+	if err := digest.NewChatSender(poster).SendDigest(t.Context(), reassignedDigest()); err != nil {
+		t.Fatalf("SendDigest() error = %v", err)
+	}
+
+	if len(poster.texts) != 1 || poster.team != "backend" {
+		t.Fatalf("posted %d messages to %q, want one to backend", len(poster.texts), poster.team)
+	}
+
+	text := poster.texts[0]
+	for _, want := range []string{
+		"Daily review digest for backend, 2025-03-10",
+		`@u2: 1 review(s) to do, 0 pull request(s) waiting for review`,
+		`review "Add cache" (pr-1) by @u1, assigned 2h ago`,
+		`your "Add cache" (pr-1) is waiting for 5d 1h`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("chat digest does not contain %q:\n%s", want, text)
+		}
+	}
+
+	if strings.Contains(text, "@u3") {
+		t.Errorf("chat digest mentions u3 who has nothing to do:\n%s", text)
+	}
+}
+
+func TestChatSenderSkipsEmptyDigest(t *testing.T) {
+	poster := &fakePoster{}
+	empty := &models.TeamDigest{TeamName: "backend", Users: []models.UserDigest{{User: models.User{ID: "u1"}}}}
+
+	if err := digest.NewChatSender(poster).SendDigest(t.Context(), empty); err != nil {
+		t.Fatalf("SendDigest() error = %v", err)
+	}
+
+	if len(poster.texts) != 0 {
+		t.Errorf("posted %q, want nothing for an empty digest", poster.texts)
+	}
+}
+
+func TestEmailSenderMailsUsersWithEntries(t *testing.T) {
+	mailer := &fakeMailer{}
+	if err := digest.NewEmailSender(mailer).SendDigest(t.Context(), reassignedDigest()); err != nil {
+		t.Fatalf("SendDigest() error = %v", err)
+	}
+
+	if len(mailer.mails) != 2 {
+		t.Fatalf("sent %d mails, want 2 (alice and bob)", len(mailer.mails))
+	}
+
+	bob := mailer.mails[1]
+	if bob.to[0] != "bob@example.com" || bob.subject != "Review digest for 2025-03-10" {
+		t.Errorf("mail sent to %v with subject %q", bob.to, bob.subject)
+	}
+	if want := `"Add cache" (pr-1) by u1, assigned 2h ago`; !strings.Contains(bob.body, want) {
+		t.Errorf("mail body does not contain %q:\n%s", want, bob.body)
+	}
+}
@@ -0,0 +1,106 @@
+package digest
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTPCfg struct {
+	Host     string        `env:"SMTP_HOST"`
+	Port     string        `env:"SMTP_PORT"     env-default:"587"`
+	Username string        `env:"SMTP_USERNAME"`
+	Password string        `env:"SMTP_PASSWORD"`
+	From     string        `env:"SMTP_FROM"     env-default:"pr-reviewer@localhost"`
+	Timeout  time.Duration `env:"SMTP_TIMEOUT"  env-default:"10s"`
+}
+
+type SMTPMailer struct {
+	cfg SMTPCfg
+}
+
+func NewSMTPMailer(cfg SMTPCfg) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+func (m *SMTPMailer) SendMail(ctx context.Context, to []string, subject, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.cfg.Host, m.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to set smtp deadline: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if m.cfg.Username != "" {
+		auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err = client.Mail(m.cfg.From); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+
+	for _, recipient := range to {
+		if err = client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+
+	if _, err = writer.Write(buildMessage(m.cfg.From, to, subject, body)); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func buildMessage(from string, to []string, subject, body string) []byte {
+	var msg strings.Builder
+
+	msg.WriteString("From: " + from + "\r\n")
+	msg.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return []byte(msg.String())
+}
@@ -0,0 +1,165 @@
+package digest_test
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/digest"
+)
+
+// smtpStub is a minimal SMTP server accepting a single session without STARTTLS.
+type smtpStub struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	auth     string
+	from     string
+	rcpt     []string
+	data     string
+	finished chan struct{}
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	stub := &smtpStub{listener: listener, finished: make(chan struct{})}
+	go stub.serve(t)
+
+	return stub
+}
+
+func (s *smtpStub) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func (s *smtpStub) serve(t *testing.T) {
+	defer close(s.finished)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		if _, err := conn.Write([]byte(line + "\r\n")); err != nil {
+			t.Errorf("smtp stub failed to reply: %v", err)
+		}
+	}
+
+	reply("220 localhost ESMTP stub")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		s.mu.Lock()
+		switch command {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			s.auth = line
+			reply("235 Authentication succeeded")
+		case "MAIL":
+			s.from = line
+			reply("250 OK")
+		case "RCPT":
+			s.rcpt = append(s.rcpt, line)
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestSMTPMailerSendsMessage(t *testing.T) {
+	stub := newSMTPStub(t)
+	mailer := digest.NewSMTPMailer(digest.SMTPCfg{
+		Host:     "127.0.0.1",
+		Port:     stub.port(),
+		Username: "bot",
+		Password: "secret",
+		From:     "pr-reviewer@example.com",
+		Timeout:  5 * time.Second,
+	})
+
+	err := mailer.SendMail(t.Context(), []string{"alice@example.com", "bob@example.com"},
+		"Review digest for 2025-03-10", "Hi alice,\nnothing to do")
+	if err != nil {
+		t.Fatalf("SendMail() error = %v", err)
+	}
+	<-stub.finished
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	wantAuth := "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00bot\x00secret"))
+	if stub.auth != wantAuth {
+		t.Errorf("auth = %q, want %q", stub.auth, wantAuth)
+	}
+	if stub.from != "MAIL FROM:<pr-reviewer@example.com>" {
+		t.Errorf("sender = %q", stub.from)
+	}
+	if len(stub.rcpt) != 2 || stub.rcpt[1] != "RCPT TO:<bob@example.com>" {
+		t.Errorf("recipients = %q", stub.rcpt)
+	}
+
+	for _, want := range []string{
+		"To: alice@example.com, bob@example.com\r\n",
+		"Subject: Review digest for 2025-03-10\r\n",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\n\r\nHi alice,\r\nnothing to do",
+	} {
+		if !strings.Contains(stub.data, want) {
+			t.Errorf("message does not contain %q:\n%s", want, stub.data)
+		}
+	}
+}
+
+func TestSMTPMailerReportsUnreachableServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	_ = listener.Close()
+
+	mailer := digest.NewSMTPMailer(digest.SMTPCfg{Host: "127.0.0.1", Port: port, Timeout: time.Second})
+	if err = mailer.SendMail(t.Context(), []string{"alice@example.com"}, "subject", "body"); err == nil {
+		t.Error("SendMail() error = nil, want connection error")
+	}
+}
@@ -0,0 +1,177 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoTeam(team *models.Team) *prreviewv1.Team {
+	members := make([]*prreviewv1.TeamMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, &prreviewv1.TeamMember{
+			UserId:   member.ID,
+			Username: member.Username,
+			Email:    member.Email,
+			IsActive: member.IsActive,
+		})
+	}
+
+	return &prreviewv1.Team{TeamName: team.Name, Members: members, Version: team.Version}
+}
+
+func fromProtoMembers(members []*prreviewv1.TeamMember) []models.TeamMember {
+	result := make([]models.TeamMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, models.TeamMember{
+			ID:       member.GetUserId(),
+			Username: member.GetUsername(),
+			Email:    member.GetEmail(),
+			IsActive: member.GetIsActive(),
+		})
+	}
+
+	return result
+}
+
+func toProtoUser(user models.User) *prreviewv1.User {
+	return &prreviewv1.User{
+		UserId:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
+
+func toProtoUserDetails(user *models.UserDetails) *prreviewv1.UserDetails {
+	return &prreviewv1.UserDetails{
+		User:             toProtoUser(user.User),
+		OpenReviews:      int64(user.OpenReviews),
+		OpenPullRequests: int64(user.OpenPullRequests),
+	}
+}
+
+// ifMatch turns the version field of a request into the versions it is conditional on, zero skips the check.
+func ifMatch(version int64) models.Versions {
+	if version == 0 {
+		return nil
+	}
+
+	return models.Versions{version}
+}
+
+func toProtoStatus(status string) prreviewv1.PullRequestStatus {
+	switch status {
+	case "OPEN":
+		return prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case "MERGED":
+		return prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+func fromProtoStatus(status prreviewv1.PullRequestStatus) string {
+	switch status {
+	case prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:
+		return "OPEN"
+	case prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED:
+		return "MERGED"
+	default:
+		return ""
+	}
+}
+
+func fromProtoTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	value := t.AsTime()
+	return &value
+}
+
+func toProtoTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func toProtoPullRequest(pullRequest *models.PullRequest) *prreviewv1.PullRequest {
+	return &prreviewv1.PullRequest{
+		PullRequestId:     pullRequest.ID,
+		PullRequestName:   pullRequest.Name,
+		AuthorId:          pullRequest.AuthorID,
+		Status:            toProtoStatus(pullRequest.Status),
+		AssignedReviewers: pullRequest.AssignedReviewers,
+		MergedAt:          toProtoTime(pullRequest.MergedAt),
+		Version:           pullRequest.Version,
+	}
+}
+
+func toProtoReviewState(state string) prreviewv1.ReviewState {
+	switch state {
+	case models.ReviewStatePending:
+		return prreviewv1.ReviewState_REVIEW_STATE_PENDING
+	case models.ReviewStateCompleted:
+		return prreviewv1.ReviewState_REVIEW_STATE_COMPLETED
+	default:
+		return prreviewv1.ReviewState_REVIEW_STATE_UNSPECIFIED
+	}
+}
+
+func toProtoPullRequestDetails(pullRequest *models.PullRequestDetails) *prreviewv1.PullRequestDetails {
+	reviewers := make([]*prreviewv1.ReviewerAssignment, 0, len(pullRequest.Reviewers))
+	for _, reviewer := range pullRequest.Reviewers {
+		reviewers = append(reviewers, &prreviewv1.ReviewerAssignment{
+			UserId:      reviewer.UserID,
+			Username:    reviewer.Username,
+			IsActive:    reviewer.IsActive,
+			AssignedAt:  toProtoTime(reviewer.AssignedAt),
+			ReviewState: toProtoReviewState(reviewer.ReviewState),
+		})
+	}
+
+	details := &prreviewv1.PullRequestDetails{
+		PullRequestId:     pullRequest.ID,
+		PullRequestName:   pullRequest.Name,
+		AuthorId:          pullRequest.AuthorID,
+		AuthorTeam:        pullRequest.AuthorTeam,
+		Status:            toProtoStatus(pullRequest.Status),
+		AssignedReviewers: pullRequest.AssignedReviewers,
+		Reviewers:         reviewers,
+		CreatedAt:         toProtoTime(pullRequest.CreatedAt),
+		Version:           pullRequest.Version,
+	}
+	if pullRequest.MergedAt != nil {
+		details.MergedAt = toProtoTime(*pullRequest.MergedAt)
+	}
+
+	return details
+}
+
+func toProtoPullRequestsShort(pullRequests []*models.PullRequestShort) []*prreviewv1.PullRequestShort {
+	result := make([]*prreviewv1.PullRequestShort, 0, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		short := &prreviewv1.PullRequestShort{
+			PullRequestId:   pullRequest.ID,
+			PullRequestName: pullRequest.Name,
+			AuthorId:        pullRequest.AuthorID,
+			Status:          toProtoStatus(pullRequest.Status),
+			CreatedAt:       toProtoTime(pullRequest.CreatedAt),
+			ReviewState:     toProtoReviewState(pullRequest.ReviewState),
+		}
+		if pullRequest.AssignedAt != nil {
+			short.AssignedAt = toProtoTime(*pullRequest.AssignedAt)
+		}
+
+		result = append(result, short)
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package grpcapi
+
+import (
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "pr-review-assignment-service"
+
+func mapServiceErrors(err string) codes.Code {
+	switch err {
+	case models.TeamExistsErr, models.UserExistsErr, models.PRExistsErr:
+		return codes.AlreadyExists
+	case models.PRMergedErr, models.NotAssignedErr, models.NoCandidateErr, models.KeyReusedErr:
+		return codes.FailedPrecondition
+	case models.InProgressErr, models.PreconditionErr:
+		return codes.Aborted
+	case models.NotFoundErr:
+		return codes.NotFound
+	case models.InvalidJSONErr, models.InvalidReqErr, models.ValidationErr:
+		return codes.InvalidArgument
+	case models.UnauthorizedErr:
+		return codes.Unauthenticated
+	case models.ForbiddenErr:
+		return codes.PermissionDenied
+	default:
+		return codes.Internal
+	}
+}
+
+// statusError converts service errors to gRPC statuses, the original code is kept in ErrorInfo.Reason.
+func statusError(err *models.ErrDetails) error {
+	st := status.New(mapServiceErrors(err.Code), err.Message)
+
+	info := &errdetails.ErrorInfo{Reason: err.Code, Domain: errorDomain}
+	if len(err.Details) > 0 {
+		info.Metadata = make(map[string]string, len(err.Details))
+		for _, detail := range err.Details {
+			info.Metadata[detail.Field] = detail.Message
+		}
+	}
+
+	withDetails, detailsErr := st.WithDetails(info)
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
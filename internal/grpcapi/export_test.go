@@ -0,0 +1,30 @@
+package grpcapi
+
+import (
+	"github.com/vedsatt/pr-review-assignment-service/internal/transport"
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+	"google.golang.org/grpc"
+)
+
+// MethodRoles exposes the roles of every method.
+func MethodRoles() map[string][]string {
+	return methodRoles()
+}
+
+// NewAuthInterceptor returns the auth interceptor of a server with authentication enabled.
+func NewAuthInterceptor() grpc.UnaryServerInterceptor {
+	s := &server{authEnabled: true}
+	return s.authInterceptor
+}
+
+// Server is the set of services served by the gRPC server.
+type Server interface {
+	prreviewv1.TeamServiceServer
+	prreviewv1.UserServiceServer
+	prreviewv1.PullRequestServiceServer
+}
+
+// NewServer returns the handlers of a server with authentication disabled.
+func NewServer(service transport.PRService) Server {
+	return &server{service: service}
+}
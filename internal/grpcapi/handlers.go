@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+)
+
+func (s *server) AddTeam(ctx context.Context, req *prreviewv1.AddTeamRequest) (*prreviewv1.AddTeamResponse, error) {
+	team, serviceErr := s.service.AddTeam(ctx, models.AddTeamRequest{
+		Name:    req.GetTeamName(),
+		Members: fromProtoMembers(req.GetMembers()),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.AddTeamResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *server) GetTeam(ctx context.Context, req *prreviewv1.GetTeamRequest) (*prreviewv1.GetTeamResponse, error) {
+	team, serviceErr := s.service.GetTeam(ctx, req.GetTeamName())
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.GetTeamResponse{Team: toProtoTeam(team)}, nil
+}
+
+func (s *server) GetUser(ctx context.Context, req *prreviewv1.GetUserRequest) (*prreviewv1.GetUserResponse, error) {
+	user, serviceErr := s.service.GetUser(ctx, req.GetUserId())
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.GetUserResponse{User: toProtoUserDetails(user)}, nil
+}
+
+func (s *server) ListUsers(
+	ctx context.Context, req *prreviewv1.ListUsersRequest,
+) (*prreviewv1.ListUsersResponse, error) {
+	resp, serviceErr := s.service.ListUsers(ctx, models.ListUsersRequest{
+		TeamName:   req.GetTeamName(),
+		IsActive:   req.IsActive,
+		NamePrefix: req.GetNamePrefix(),
+		Limit:      int(req.GetLimit()),
+		Cursor:     req.GetCursor(),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	users := make([]*prreviewv1.UserDetails, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		users = append(users, toProtoUserDetails(user))
+	}
+
+	return &prreviewv1.ListUsersResponse{
+		Users:      users,
+		NextCursor: resp.NextCursor,
+	}, nil
+}
+
+func (s *server) SetIsActive(
+	ctx context.Context, req *prreviewv1.SetIsActiveRequest,
+) (*prreviewv1.SetIsActiveResponse, error) {
+	user, serviceErr := s.service.SetUserStatus(ctx, models.SetUserStatusRequest{
+		ID:       req.GetUserId(),
+		IsActive: req.GetIsActive(),
+		IfMatch:  ifMatch(req.GetVersion()),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.SetIsActiveResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *server) GetReviews(
+	ctx context.Context, req *prreviewv1.GetReviewsRequest,
+) (*prreviewv1.GetReviewsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = models.DefaultPageSize
+	}
+
+	resp, serviceErr := s.service.GetUserReviews(ctx, models.UserReviewsRequest{
+		UserID: req.GetUserId(),
+		Status: fromProtoStatus(req.GetStatus()),
+		Limit:  limit,
+		Cursor: req.GetCursor(),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.GetReviewsResponse{
+		UserId:       resp.UserID,
+		PullRequests: toProtoPullRequestsShort(resp.PullRequests),
+		NextCursor:   resp.NextCursor,
+	}, nil
+}
+
+func (s *server) CreatePullRequest(
+	ctx context.Context, req *prreviewv1.CreatePullRequestRequest,
+) (*prreviewv1.CreatePullRequestResponse, error) {
+	pullRequest, serviceErr := s.service.CreatePullRequest(ctx, models.CreatePRRequest{
+		ID:       req.GetPullRequestId(),
+		Name:     req.GetPullRequestName(),
+		AuthorID: req.GetAuthorId(),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.CreatePullRequestResponse{PullRequest: toProtoPullRequest(pullRequest)}, nil
+}
+
+func (s *server) GetPullRequest(
+	ctx context.Context, req *prreviewv1.GetPullRequestRequest,
+) (*prreviewv1.GetPullRequestResponse, error) {
+	pullRequest, serviceErr := s.service.GetPullRequest(ctx, req.GetPullRequestId())
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.GetPullRequestResponse{PullRequest: toProtoPullRequestDetails(pullRequest)}, nil
+}
+
+func (s *server) ListPullRequests(
+	ctx context.Context, req *prreviewv1.ListPullRequestsRequest,
+) (*prreviewv1.ListPullRequestsResponse, error) {
+	resp, serviceErr := s.service.ListPullRequests(ctx, models.ListPullRequestsRequest{
+		Status:      fromProtoStatus(req.GetStatus()),
+		AuthorID:    req.GetAuthorId(),
+		TeamName:    req.GetTeamName(),
+		ReviewerID:  req.GetReviewerId(),
+		CreatedFrom: fromProtoTime(req.GetCreatedFrom()),
+		CreatedTo:   fromProtoTime(req.GetCreatedTo()),
+		MergedFrom:  fromProtoTime(req.GetMergedFrom()),
+		MergedTo:    fromProtoTime(req.GetMergedTo()),
+		SortBy:      req.GetSortBy(),
+		Order:       req.GetOrder(),
+		Limit:       int(req.GetLimit()),
+		Cursor:      req.GetCursor(),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	pullRequests := make([]*prreviewv1.PullRequestDetails, 0, len(resp.PullRequests))
+	for _, pullRequest := range resp.PullRequests {
+		pullRequests = append(pullRequests, toProtoPullRequestDetails(pullRequest))
+	}
+
+	return &prreviewv1.ListPullRequestsResponse{
+		PullRequests: pullRequests,
+		NextCursor:   resp.NextCursor,
+	}, nil
+}
+
+func (s *server) MergePullRequest(
+	ctx context.Context, req *prreviewv1.MergePullRequestRequest,
+) (*prreviewv1.MergePullRequestResponse, error) {
+	pullRequest, serviceErr := s.service.MergePullRequest(ctx, models.MergePRRequest{
+		ID:      req.GetPullRequestId(),
+		IfMatch: ifMatch(req.GetVersion()),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.MergePullRequestResponse{PullRequest: toProtoPullRequest(&pullRequest)}, nil
+}
+
+func (s *server) ReassignReviewer(
+	ctx context.Context, req *prreviewv1.ReassignReviewerRequest,
+) (*prreviewv1.ReassignReviewerResponse, error) {
+	pullRequest, replacedBy, serviceErr := s.service.ReassignPullRequestReviewer(ctx, models.ReassignPRReviewerRequest{
+		PullRequestID: req.GetPullRequestId(),
+		OldReviewerID: req.GetOldReviewerId(),
+		IfMatch:       ifMatch(req.GetVersion()),
+	})
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.ReassignReviewerResponse{
+		PullRequest: toProtoPullRequest(&pullRequest),
+		ReplacedBy:  replacedBy,
+	}, nil
+}
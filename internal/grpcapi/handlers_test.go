@@ -0,0 +1,126 @@
+package grpcapi_test
+
+import (
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/grpcapi"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/memory"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(...models.PullRequestEvent) {}
+
+// newTestServer serves the gRPC handlers on top of the in-memory repository with one team of four users.
+func newTestServer(t *testing.T) grpcapi.Server {
+	t.Helper()
+
+	s := grpcapi.NewServer(service.NewService(memory.NewRepository(), nopNotifier{}, metrics.New()))
+
+	members := make([]*prreviewv1.TeamMember, 0, 4)
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		members = append(members, &prreviewv1.TeamMember{UserId: id, Username: "user " + id, IsActive: true})
+	}
+
+	_, err := s.AddTeam(t.Context(), &prreviewv1.AddTeamRequest{TeamName: "backend", Members: members})
+	if err != nil {
+		t.Fatalf("AddTeam() error = %v", err)
+	}
+
+	return s
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Errorf("status = %s (%v), want %s", got, err, want)
+	}
+}
+
+func TestPullRequestVersionIsChecked(t *testing.T) {
+	s := newTestServer(t)
+
+	created, err := s.CreatePullRequest(t.Context(), &prreviewv1.CreatePullRequestRequest{
+		PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+
+	version := created.GetPullRequest().GetVersion()
+	if version == 0 {
+		t.Fatal("created pull request has no version")
+	}
+
+	got, err := s.GetPullRequest(t.Context(), &prreviewv1.GetPullRequestRequest{PullRequestId: "pr-1"})
+	if err != nil {
+		t.Fatalf("GetPullRequest() error = %v", err)
+	}
+	if got.GetPullRequest().GetVersion() != version {
+		t.Errorf("GetPullRequest() version = %d, want %d", got.GetPullRequest().GetVersion(), version)
+	}
+
+	oldReviewer := created.GetPullRequest().GetAssignedReviewers()[0]
+	reassigned, err := s.ReassignReviewer(t.Context(), &prreviewv1.ReassignReviewerRequest{
+		PullRequestId: "pr-1", OldReviewerId: oldReviewer, Version: version,
+	})
+	if err != nil {
+		t.Fatalf("ReassignReviewer() with the current version error = %v", err)
+	}
+	if reassigned.GetPullRequest().GetVersion() <= version {
+		t.Errorf("ReassignReviewer() version = %d, want more than %d",
+			reassigned.GetPullRequest().GetVersion(), version)
+	}
+
+	_, err = s.MergePullRequest(t.Context(), &prreviewv1.MergePullRequestRequest{
+		PullRequestId: "pr-1", Version: version,
+	})
+	assertCode(t, err, codes.Aborted)
+
+	_, err = s.ReassignReviewer(t.Context(), &prreviewv1.ReassignReviewerRequest{
+		PullRequestId: "pr-1", OldReviewerId: reassigned.GetReplacedBy(), Version: version,
+	})
+	assertCode(t, err, codes.Aborted)
+
+	_, err = s.MergePullRequest(t.Context(), &prreviewv1.MergePullRequestRequest{
+		PullRequestId: "pr-1", Version: reassigned.GetPullRequest().GetVersion(),
+	})
+	if err != nil {
+		t.Errorf("MergePullRequest() with the current version error = %v", err)
+	}
+}
+
+func TestSetIsActiveChecksTeamVersion(t *testing.T) {
+	s := newTestServer(t)
+
+	team, err := s.GetTeam(t.Context(), &prreviewv1.GetTeamRequest{TeamName: "backend"})
+	if err != nil {
+		t.Fatalf("GetTeam() error = %v", err)
+	}
+
+	version := team.GetTeam().GetVersion()
+	if version == 0 {
+		t.Fatal("team has no version")
+	}
+
+	_, err = s.SetIsActive(t.Context(), &prreviewv1.SetIsActiveRequest{UserId: "u2", Version: version + 1})
+	assertCode(t, err, codes.Aborted)
+
+	if _, err = s.SetIsActive(t.Context(), &prreviewv1.SetIsActiveRequest{UserId: "u2", Version: version}); err != nil {
+		t.Fatalf("SetIsActive() with the current version error = %v", err)
+	}
+
+	_, err = s.SetIsActive(t.Context(), &prreviewv1.SetIsActiveRequest{UserId: "u3", Version: version})
+	assertCode(t, err, codes.Aborted)
+
+	if _, err = s.SetIsActive(t.Context(), &prreviewv1.SetIsActiveRequest{UserId: "u3"}); err != nil {
+		t.Errorf("SetIsActive() without version error = %v", err)
+	}
+}
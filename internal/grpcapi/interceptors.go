@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func logsInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	zap.L().Info("request", zap.String("Method", info.FullMethod))
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	zap.L().Info("response",
+		zap.String("Method", info.FullMethod),
+		zap.String("Code", status.Code(err).String()),
+		zap.Duration("completion time", time.Since(start)),
+	)
+
+	return resp, err
+}
+
+// authInterceptor authenticates the bearer token from the "authorization" metadata like the HTTP middleware does.
+func (s *server) authInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	if !s.authEnabled {
+		return handler(ctx, req)
+	}
+
+	// Methods without roles are denied, so that a new RPC isn't public until it is added to methodRoles.
+	roles, ok := methodRoles()[info.FullMethod]
+	if !ok {
+		zap.L().Error("grpc method has no roles", zap.String("Method", info.FullMethod))
+		return nil, statusError(&models.ErrDetails{Code: models.ForbiddenErr, Message: "method is not allowed"})
+	}
+
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, header := range md.Get("authorization") {
+			if token, ok = auth.BearerToken(header); ok {
+				break
+			}
+		}
+	}
+
+	if token == "" {
+		return nil, statusError(&models.ErrDetails{Code: models.UnauthorizedErr, Message: "missing bearer token"})
+	}
+
+	caller, serviceErr := s.authenticator.Authenticate(ctx, token)
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	if !slices.Contains(roles, caller.Role) {
+		return nil, statusError(&models.ErrDetails{Code: models.ForbiddenErr, Message: "insufficient permissions"})
+	}
+
+	return handler(auth.WithCaller(ctx, caller), req)
+}
@@ -0,0 +1,64 @@
+package grpcapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/grpcapi"
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEveryMethodHasRoles(t *testing.T) {
+	roles := grpcapi.MethodRoles()
+
+	for _, desc := range []grpc.ServiceDesc{
+		prreviewv1.TeamService_ServiceDesc,
+		prreviewv1.UserService_ServiceDesc,
+		prreviewv1.PullRequestService_ServiceDesc,
+		prreviewv1.StatisticsService_ServiceDesc,
+	} {
+		for _, method := range desc.Methods {
+			fullMethod := "/" + desc.ServiceName + "/" + method.MethodName
+			if len(roles[fullMethod]) == 0 {
+				t.Errorf("%s has no roles and is denied to everyone", fullMethod)
+			}
+		}
+	}
+}
+
+func TestAuthInterceptorDeniesUnmappedMethods(t *testing.T) {
+	interceptor := grpcapi.NewAuthInterceptor()
+
+	called := false
+	handler := func(context.Context, any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/prreview.v1.TeamService/DeleteTeam"}
+	_, err := interceptor(t.Context(), nil, info, handler)
+
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("interceptor error = %v, want PermissionDenied", err)
+	}
+	if called {
+		t.Error("handler of an unmapped method was called")
+	}
+}
+
+func TestAuthInterceptorRequiresToken(t *testing.T) {
+	interceptor := grpcapi.NewAuthInterceptor()
+
+	info := &grpc.UnaryServerInfo{FullMethod: prreviewv1.TeamService_GetTeam_FullMethodName}
+	_, err := interceptor(t.Context(), nil, info, func(context.Context, any) (any, error) {
+		t.Error("handler was called without a token")
+		return nil, nil
+	})
+
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("interceptor error = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,79 @@
+package grpcapi
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/transport"
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type server struct {
+	prreviewv1.UnimplementedTeamServiceServer
+	prreviewv1.UnimplementedUserServiceServer
+	prreviewv1.UnimplementedPullRequestServiceServer
+	prreviewv1.UnimplementedStatisticsServiceServer
+
+	service       transport.PRService
+	authEnabled   bool
+	authenticator *auth.Authenticator
+}
+
+// StartServer serves the gRPC API on its own port, it is a thin adapter over the same service as the HTTP API.
+func StartServer(cfg *config.Config, service transport.PRService) (*grpc.Server, error) {
+	listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return nil, err
+	}
+
+	const defaultTimeout = 5 * time.Second
+	server := &server{
+		service:       service,
+		authEnabled:   cfg.AuthCfg.Enabled,
+		authenticator: auth.NewAuthenticator(service, cfg.OIDCCfg, &http.Client{Timeout: defaultTimeout}),
+	}
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(logsInterceptor, server.authInterceptor))
+	prreviewv1.RegisterTeamServiceServer(grpcServer, server)
+	prreviewv1.RegisterUserServiceServer(grpcServer, server)
+	prreviewv1.RegisterPullRequestServiceServer(grpcServer, server)
+	prreviewv1.RegisterStatisticsServiceServer(grpcServer, server)
+	reflection.Register(grpcServer)
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			zap.L().Fatal("failed to start grpc server", zap.Error(err))
+		}
+	}()
+
+	return grpcServer, nil
+}
+
+// methodRoles lists the roles allowed to call each method, mirroring the HTTP routes.
+func methodRoles() map[string][]string {
+	return map[string][]string{
+		prreviewv1.TeamService_AddTeam_FullMethodName: auth.AdminRoles(),
+		prreviewv1.TeamService_GetTeam_FullMethodName: auth.ReaderRoles(),
+
+		prreviewv1.UserService_GetUser_FullMethodName:     auth.ReaderRoles(),
+		prreviewv1.UserService_ListUsers_FullMethodName:   auth.ReaderRoles(),
+		prreviewv1.UserService_SetIsActive_FullMethodName: auth.ManagerRoles(),
+		prreviewv1.UserService_GetReviews_FullMethodName:  auth.ReaderRoles(),
+
+		prreviewv1.PullRequestService_CreatePullRequest_FullMethodName: auth.WriterRoles(),
+		prreviewv1.PullRequestService_GetPullRequest_FullMethodName:    auth.ReaderRoles(),
+		prreviewv1.PullRequestService_ListPullRequests_FullMethodName:  auth.ReaderRoles(),
+		prreviewv1.PullRequestService_MergePullRequest_FullMethodName:  auth.WriterRoles(),
+		prreviewv1.PullRequestService_ReassignReviewer_FullMethodName:  auth.WriterRoles(),
+
+		prreviewv1.StatisticsService_GetUsersStatistics_FullMethodName:        auth.ReaderRoles(),
+		prreviewv1.StatisticsService_GetPullRequestsStatistics_FullMethodName: auth.ReaderRoles(),
+		prreviewv1.StatisticsService_GetReviewersStatistics_FullMethodName:    auth.ReaderRoles(),
+	}
+}
@@ -0,0 +1,69 @@
+package grpcapi
+
+import (
+	"context"
+
+	prreviewv1 "github.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1"
+)
+
+func (s *server) GetUsersStatistics(
+	ctx context.Context, _ *prreviewv1.GetUsersStatisticsRequest,
+) (*prreviewv1.GetUsersStatisticsResponse, error) {
+	stats, serviceErr := s.service.GetUsersStatistics(ctx)
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	usersByTeam := make([]*prreviewv1.TeamUsersCount, 0, len(stats.UsersByTeam))
+	for _, team := range stats.UsersByTeam {
+		usersByTeam = append(usersByTeam, &prreviewv1.TeamUsersCount{
+			TeamName:   team.TeamName,
+			UsersCount: int64(team.Users),
+		})
+	}
+
+	return &prreviewv1.GetUsersStatisticsResponse{
+		TotalUsers:    int64(stats.TotalUsers),
+		ActiveUsers:   int64(stats.ActiveUsers),
+		InactiveUsers: int64(stats.InactiveUsers),
+		UsersByTeam:   usersByTeam,
+	}, nil
+}
+
+func (s *server) GetPullRequestsStatistics(
+	ctx context.Context, _ *prreviewv1.GetPullRequestsStatisticsRequest,
+) (*prreviewv1.GetPullRequestsStatisticsResponse, error) {
+	stats, serviceErr := s.service.GetPullRequestStatistics(ctx)
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	return &prreviewv1.GetPullRequestsStatisticsResponse{
+		TotalPrs:  int64(stats.TotalPRs),
+		OpenPrs:   int64(stats.OpenPRs),
+		MergedPrs: int64(stats.MergedPRs),
+	}, nil
+}
+
+func (s *server) GetReviewersStatistics(
+	ctx context.Context, _ *prreviewv1.GetReviewersStatisticsRequest,
+) (*prreviewv1.GetReviewersStatisticsResponse, error) {
+	stats, serviceErr := s.service.GetReviewersStatistics(ctx)
+	if serviceErr != nil {
+		return nil, statusError(serviceErr)
+	}
+
+	topReviewers := make([]*prreviewv1.ReviewerStats, 0, len(stats.TopReviewers))
+	for _, reviewer := range stats.TopReviewers {
+		topReviewers = append(topReviewers, &prreviewv1.ReviewerStats{
+			UserId:      reviewer.ID,
+			Username:    reviewer.Username,
+			ReviewCount: int64(reviewer.ReviewCount),
+		})
+	}
+
+	return &prreviewv1.GetReviewersStatisticsResponse{
+		TopReviewers:        topReviewers,
+		UsersWithoutReviews: stats.UsersWithoutReview,
+	}, nil
+}
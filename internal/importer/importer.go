@@ -0,0 +1,178 @@
+// Package importer parses org charts for the bulk team and user import.
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+var ErrUnknownFormat = errors.New("unknown import format")
+
+type member struct {
+	UserID   string `json:"user_id"   yaml:"user_id"`
+	Username string `json:"username"  yaml:"username"`
+	Email    string `json:"email"     yaml:"email"`
+	IsActive *bool  `json:"is_active" yaml:"is_active"`
+}
+
+type team struct {
+	TeamName string   `json:"team_name" yaml:"team_name"`
+	Members  []member `json:"members"   yaml:"members"`
+}
+
+type orgChart struct {
+	Teams []team `json:"teams" yaml:"teams"`
+}
+
+// FormatFromContentType maps a Content-Type header to an import format.
+func FormatFromContentType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrUnknownFormat
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, nil
+	case "application/json":
+		return FormatJSON, nil
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return FormatYAML, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// FormatFromFilename detects the import format by the file extension.
+func FormatFromFilename(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Parse reads an org chart. CSV has a header with team_name, user_id, username, email and is_active columns,
+// JSON and YAML documents list teams with their members like /team/add does.
+func Parse(format string, r io.Reader) ([]models.ImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatJSON:
+		var chart orgChart
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&chart); err != nil {
+			return nil, fmt.Errorf("failed to decode json: %w", err)
+		}
+		return chart.rows(), nil
+	case FormatYAML:
+		var chart orgChart
+		decoder := yaml.NewDecoder(r)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&chart); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to decode yaml: %w", err)
+		}
+		return chart.rows(), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func (c orgChart) rows() []models.ImportRow {
+	var rows []models.ImportRow
+	for _, t := range c.Teams {
+		for _, m := range t.Members {
+			rows = append(rows, models.ImportRow{
+				Row:      len(rows) + 1,
+				TeamName: strings.TrimSpace(t.TeamName),
+				UserID:   strings.TrimSpace(m.UserID),
+				Username: strings.TrimSpace(m.Username),
+				Email:    strings.TrimSpace(m.Email),
+				IsActive: m.IsActive == nil || *m.IsActive,
+			})
+		}
+	}
+
+	return rows
+}
+
+func parseCSV(r io.Reader) ([]models.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, required := range []string{"team_name", "user_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header has no %s column", required)
+		}
+	}
+
+	var rows []models.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		isActive := true
+		if value := field("is_active"); value != "" {
+			isActive, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: is_active must be a boolean", len(rows)+1)
+			}
+		}
+
+		rows = append(rows, models.ImportRow{
+			Row:      len(rows) + 1,
+			TeamName: field("team_name"),
+			UserID:   field("user_id"),
+			Username: field("username"),
+			Email:    field("email"),
+			IsActive: isActive,
+		})
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+// RegisterPool exposes the connection stats of the Postgres pool.
+func (m *Metrics) RegisterPool(stat func() *pgxpool.Stat) {
+	m.registry.MustRegister(&poolCollector{
+		stat: stat,
+		acquired: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", "acquired_connections"),
+			"Connections currently in use.", nil, nil),
+		idle: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", "idle_connections"),
+			"Idle connections in the pool.", nil, nil),
+		total: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", "total_connections"),
+			"Connections in the pool, including those being established.", nil, nil),
+		max: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", "max_connections"),
+			"Maximum size of the pool.", nil, nil),
+	})
+}
+
+type poolCollector struct {
+	stat     func() *pgxpool.Stat
+	acquired *prometheus.Desc
+	idle     *prometheus.Desc
+	total    *prometheus.Desc
+	max      *prometheus.Desc
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.total
+	ch <- c.max
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.stat()
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stat.MaxConns()))
+}
+
+// OpenReviewsCounter counts the open reviews of every team.
+type OpenReviewsCounter func(ctx context.Context) ([]models.TeamOpenReviews, *models.ErrDetails)
+
+// RegisterOpenReviews exposes the open reviews per team, they are counted on every scrape.
+func (m *Metrics) RegisterOpenReviews(count OpenReviewsCounter) {
+	m.registry.MustRegister(&openReviewsCollector{
+		count: count,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "open_reviews"),
+			"Reviews of open pull requests assigned to the members of the team.", []string{"team"}, nil),
+	})
+}
+
+type openReviewsCollector struct {
+	count OpenReviewsCounter
+	desc  *prometheus.Desc
+}
+
+func (c *openReviewsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *openReviewsCollector) Collect(ch chan<- prometheus.Metric) {
+	const countTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), countTimeout)
+	defer cancel()
+
+	teams, errDetails := c.count(ctx)
+	if errDetails != nil {
+		zap.L().Error("failed to count open reviews", zap.String("code", errDetails.Code))
+		return
+	}
+
+	for _, team := range teams {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(team.OpenReviews), team.TeamName)
+	}
+}
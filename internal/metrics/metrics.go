@@ -0,0 +1,107 @@
+// Package metrics collects the Prometheus metrics of the service.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+const namespace = "prreview"
+
+// Metrics holds the metrics of the service and the registry they are exposed from.
+type Metrics struct {
+	registry            *prometheus.Registry
+	requests            *prometheus.CounterVec
+	requestDuration     *prometheus.HistogramVec
+	pullRequestsCreated prometheus.Counter
+	pullRequestsMerged  prometheus.Counter
+	reassignments       *prometheus.CounterVec
+	noCandidates        prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route and response status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests by route and response status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		pullRequestsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_created_total",
+			Help:      "Pull requests created.",
+		}),
+		pullRequestsMerged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_merged_total",
+			Help:      "Pull requests merged.",
+		}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Reviewers replaced on pull requests by what triggered the reassignment.",
+		}, []string{"trigger"}),
+		noCandidates: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Reassignments rejected with NO_CANDIDATE.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.pullRequestsCreated,
+		m.pullRequestsMerged,
+		m.reassignments,
+		m.noCandidates,
+	)
+
+	for _, trigger := range []string{models.ReassignTriggerManual, models.ReassignTriggerDeactivation} {
+		m.reassignments.WithLabelValues(trigger)
+	}
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+	m.requests.With(labels).Inc()
+	m.requestDuration.With(labels).Observe(duration.Seconds())
+}
+
+func (m *Metrics) PullRequestCreated() {
+	m.pullRequestsCreated.Inc()
+}
+
+func (m *Metrics) PullRequestMerged() {
+	m.pullRequestsMerged.Inc()
+}
+
+func (m *Metrics) ReviewerReassigned(trigger string) {
+	m.reassignments.WithLabelValues(trigger).Inc()
+}
+
+func (m *Metrics) NoCandidate() {
+	m.noCandidates.Inc()
+}
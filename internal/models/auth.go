@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+const (
+	RoleAdmin    string = "admin"
+	RoleTeamLead string = "team-lead"
+	RoleMember   string = "member"
+	RoleReadOnly string = "read-only"
+)
+
+type APIToken struct {
+	ID       int64
+	Name     string
+	Hash     string
+	Role     string
+	UserID   string
+	TeamName string
+	// UserIsActive is false when the token is bound to an inactive user, tokens without a user are active.
+	UserIsActive bool
+	ExpiresAt    *time.Time
+	RevokedAt    *time.Time
+}
+
+// Caller is the authenticated identity a request is made on behalf of.
+type Caller struct {
+	TokenID  int64
+	UserID   string
+	TeamName string
+	Role     string
+}
+
+// Identity is a caller identified by an external identity provider.
+type Identity struct {
+	UserID string
+	Email  string
+	Role   string
+}
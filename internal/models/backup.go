@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+// BackupVersion is the version of the NDJSON backup format written by the export.
+// Version 1 backups have no team and pull request versions, restore starts them at 1.
+const BackupVersion = 2
+
+// Types of backup records. Records go in this order: header, teams, users, pull requests, reviewers, events, footer.
+const (
+	BackupHeaderRecord      string = "header"
+	BackupTeamRecord        string = "team"
+	BackupUserRecord        string = "user"
+	BackupPullRequestRecord string = "pull_request"
+	BackupReviewerRecord    string = "reviewer"
+	BackupEventRecord       string = "event"
+	BackupFooterRecord      string = "footer"
+)
+
+// BackupRecord is a single NDJSON line of a backup, only the field matching Type is set.
+type BackupRecord struct {
+	Type        string             `json:"type"`
+	Header      *BackupHeader      `json:"header,omitempty"`
+	Team        *BackupTeam        `json:"team,omitempty"`
+	User        *BackupUser        `json:"user,omitempty"`
+	PullRequest *BackupPullRequest `json:"pull_request,omitempty"`
+	Reviewer    *BackupReviewer    `json:"reviewer,omitempty"`
+	Event       *BackupEvent       `json:"event,omitempty"`
+	Footer      *BackupCounts      `json:"footer,omitempty"`
+}
+
+type BackupHeader struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+type BackupTeam struct {
+	Name    string `json:"team_name"`
+	Version int64  `json:"version"`
+}
+
+type BackupUser struct {
+	ID       string  `json:"user_id"`
+	Username *string `json:"username"`
+	Email    *string `json:"email"`
+	TeamName string  `json:"team_name"`
+	IsActive *bool   `json:"is_active"`
+}
+
+type BackupPullRequest struct {
+	ID        string     `json:"pull_request_id"`
+	Name      string     `json:"pull_request_name"`
+	AuthorID  string     `json:"author_id"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+	Version   int64      `json:"version"`
+}
+
+type BackupReviewer struct {
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	AssignedAt    time.Time `json:"assigned_at"`
+}
+
+type BackupEvent struct {
+	ID            int64     `json:"id"`
+	PullRequestID string    `json:"pull_request_id"`
+	Type          string    `json:"event_type"`
+	ReviewerID    *string   `json:"reviewer_id"`
+	OldReviewerID *string   `json:"old_reviewer_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BackupCounts is the number of records of each type, the footer of a backup and the result of a restore.
+type BackupCounts struct {
+	Teams        int64 `json:"teams"`
+	Users        int64 `json:"users"`
+	PullRequests int64 `json:"pull_requests"`
+	Reviewers    int64 `json:"reviewers"`
+	Events       int64 `json:"events"`
+}
+
+// BackupReader yields backup records one by one, Next returns io.EOF after the last one
+// and Line the number of the line the last record was read from.
+type BackupReader interface {
+	Next() (*BackupRecord, error)
+	Line() int
+}
+
+type RestoreBackupResponse struct {
+	Restored BackupCounts `json:"restored"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type UserDigest struct {
+	User            User
+	Reviews         []*PullRequestShort
+	AwaitingReviews []*PullRequestShort
+}
+
+type TeamDigest struct {
+	TeamName    string
+	GeneratedAt time.Time
+	Users       []UserDigest
+}
@@ -5,21 +5,32 @@ import "time"
 type TeamMember struct {
 	ID       string `json:"user_id"`
 	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
 	IsActive bool   `json:"is_active"`
 }
 
+// Team and pull request versions grow with every change and are sent as the ETag.
 type Team struct {
 	Name    string       `json:"team_name"`
 	Members []TeamMember `json:"members"`
+	Version int64        `json:"-"`
 }
 
 type User struct {
 	ID       string `json:"user_id"`
 	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
 }
 
+// UserDetails is a user with the current review load.
+type UserDetails struct {
+	User
+	OpenReviews      int `json:"open_reviews"`
+	OpenPullRequests int `json:"open_pull_requests"`
+}
+
 type PullRequest struct {
 	ID                string    `json:"pull_request_id"`
 	Name              string    `json:"pull_request_name"`
@@ -28,11 +39,65 @@ type PullRequest struct {
 	AssignedReviewers []string  `json:"assigned_reviewers"`
 	MergedAt          time.Time `json:"merged_at,omitempty"`
 	CreatedAt         string    `json:"created_at,omitempty"`
+	Version           int64     `json:"-"`
+}
+
+const (
+	ReviewStatePending   string = "PENDING"
+	ReviewStateCompleted string = "COMPLETED"
+)
+
+// PullRequestDetails is a pull request with its reviewers, timestamps and the author's team.
+type PullRequestDetails struct {
+	ID                string               `json:"pull_request_id"`
+	Name              string               `json:"pull_request_name"`
+	AuthorID          string               `json:"author_id"`
+	AuthorTeam        string               `json:"author_team"`
+	Status            string               `json:"status"`
+	AssignedReviewers []string             `json:"assigned_reviewers"`
+	Reviewers         []ReviewerAssignment `json:"reviewers"`
+	CreatedAt         time.Time            `json:"created_at"`
+	MergedAt          *time.Time           `json:"merged_at,omitempty"`
+	Version           int64                `json:"-"`
+}
+
+// ReviewerAssignment is a reviewer of a pull request, the review is completed once the pull request is merged.
+type ReviewerAssignment struct {
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	IsActive    bool      `json:"is_active"`
+	AssignedAt  time.Time `json:"assigned_at"`
+	ReviewState string    `json:"review_state"`
 }
 
 type PullRequestShort struct {
-	ID       string `json:"pull_request_id"`
-	Name     string `json:"pull_request_name"`
-	AuthorID string `json:"author_id"`
-	Status   string `json:"status"`
+	ID          string     `json:"pull_request_id"`
+	Name        string     `json:"pull_request_name"`
+	AuthorID    string     `json:"author_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	AssignedAt  *time.Time `json:"assigned_at,omitempty"`
+	ReviewState string     `json:"review_state,omitempty"`
+}
+
+// DirectoryUserFilter selects users for directory sync, empty fields match any user.
+type DirectoryUserFilter struct {
+	ID       string
+	Username string
+	Email    string
+	Offset   int
+	Limit    int
+}
+
+// Operations of a TeamMembersChange.
+const (
+	MembersAdd     string = "add"
+	MembersRemove  string = "remove"
+	MembersReplace string = "replace"
+)
+
+// TeamMembersChange adds, removes or replaces the members of a directory team.
+type TeamMembersChange struct {
+	Op        string
+	MemberIDs []string
 }
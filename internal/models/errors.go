@@ -1,7 +1,14 @@
 package models
 
 type ErrDetails struct {
-	Code    string `json:"code"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
 }
 
@@ -10,13 +17,20 @@ type ErrorResponse struct {
 }
 
 const (
-	TeamExistsErr  string = "TEAM_EXISTS"
-	UserExistsErr  string = "USER_EXISTS"
-	PRExistsErr    string = "PR_EXISTS"
-	PRMergedErr    string = "PR_MERGED"
-	NotAssignedErr string = "NOT_ASSIGNED"
-	NoCandidateErr string = "NO_CANDIDATE"
-	NotFoundErr    string = "NOT_FOUND"
-	InvalidJSONErr string = "INVALID_JSON"
-	InternalErr    string = "NTERNAL_ERROR"
+	TeamExistsErr   string = "TEAM_EXISTS"
+	UserExistsErr   string = "USER_EXISTS"
+	PRExistsErr     string = "PR_EXISTS"
+	PRMergedErr     string = "PR_MERGED"
+	NotAssignedErr  string = "NOT_ASSIGNED"
+	NoCandidateErr  string = "NO_CANDIDATE"
+	NotFoundErr     string = "NOT_FOUND"
+	InvalidJSONErr  string = "INVALID_JSON"
+	InvalidReqErr   string = "INVALID_REQUEST"
+	ValidationErr   string = "VALIDATION_FAILED"
+	UnauthorizedErr string = "UNAUTHORIZED"
+	ForbiddenErr    string = "FORBIDDEN"
+	KeyReusedErr    string = "IDEMPOTENCY_KEY_REUSED"
+	InProgressErr   string = "REQUEST_IN_PROGRESS"
+	PreconditionErr string = "PRECONDITION_FAILED"
+	InternalErr     string = "NTERNAL_ERROR"
 )
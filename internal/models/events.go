@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	EventReviewerAssigned   string = "REVIEWER_ASSIGNED"
+	EventReviewerReassigned string = "REVIEWER_REASSIGNED"
+	EventReviewSLABreached  string = "REVIEW_SLA_BREACHED"
+	EventPullRequestMerged  string = "PR_MERGED"
+)
+
+const (
+	ReassignTriggerManual       = "manual"
+	ReassignTriggerDeactivation = "deactivation"
+)
+
+type PullRequestEvent struct {
+	Type            string
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	TeamName        string
+	ReviewerID      string
+	OldReviewerID   string
+	Reviewers       []string
+	AssignedAt      time.Time
+	CreatedAt       time.Time
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// MaxIdempotencyKeyLength is the length limit of the Idempotency-Key header.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord is the stored outcome of a request sent with an Idempotency-Key header.
+// StatusCode is zero while the first request with the key is still being handled.
+// Headers are the response headers set by the handler, e.g. the ETag.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Headers     map[string][]string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,38 @@
+package models
+
+const (
+	ImportActionCreated   string = "created"
+	ImportActionUpdated   string = "updated"
+	ImportActionUnchanged string = "unchanged"
+)
+
+// ImportRow is a single user of an org chart, Row is its 1-based position in the source.
+type ImportRow struct {
+	Row      int
+	TeamName string
+	UserID   string
+	Username string
+	Email    string
+	IsActive bool
+}
+
+type ImportRequest struct {
+	Rows   []ImportRow
+	DryRun bool
+}
+
+type ImportRowResult struct {
+	Row      int    `json:"row"`
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Action   string `json:"action"`
+}
+
+type ImportResult struct {
+	DryRun         bool              `json:"dry_run"`
+	TeamsCreated   int               `json:"teams_created"`
+	UsersCreated   int               `json:"users_created"`
+	UsersUpdated   int               `json:"users_updated"`
+	UsersUnchanged int               `json:"users_unchanged"`
+	Rows           []ImportRowResult `json:"rows"`
+}
@@ -1,13 +1,28 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
+// Versions lists the versions a change is conditional on, the ETags of If-Match. The change applies
+// when the current version is any of them, an empty list skips the check.
+type Versions []int64
+
+func (v Versions) Match(current int64) bool {
+	return len(v) == 0 || slices.Contains(v, current)
+}
+
 type AddTeamRequest struct {
 	Name    string       `json:"team_name"`
 	Members []TeamMember `json:"members"`
 }
 
+// SetUserStatusRequest carries the versions of the user's team from If-Match, since the change bumps it.
 type SetUserStatusRequest struct {
-	ID       string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	ID       string   `json:"user_id"`
+	IsActive bool     `json:"is_active"`
+	IfMatch  Versions `json:"-"`
 }
 
 type CreatePRRequest struct {
@@ -16,11 +31,74 @@ type CreatePRRequest struct {
 	AuthorID string `json:"author_id"`
 }
 
+type UpdateUserRequest struct {
+	IsActive *bool `json:"is_active"`
+}
+
+const (
+	SortByCreatedAt = "created_at"
+	SortByMergedAt  = "merged_at"
+	SortByName      = "pull_request_name"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// UserReviewsRequest selects the reviews of a user, Limit 0 returns all of them.
+type UserReviewsRequest struct {
+	UserID string
+	Status string
+	Limit  int
+	Cursor string
+}
+
+// ListUsersRequest holds the filters of the user directory, empty fields don't filter.
+type ListUsersRequest struct {
+	TeamName   string
+	IsActive   *bool
+	NamePrefix string
+	Limit      int
+	Cursor     string
+}
+
+// ListPullRequestsRequest holds the filters of the pull request listing, empty fields don't filter.
+type ListPullRequestsRequest struct {
+	Status      string
+	AuthorID    string
+	TeamName    string
+	ReviewerID  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	SortBy      string
+	Order       string
+	Limit       int
+	Cursor      string
+}
+
+// MergePRRequest and ReassignPRReviewerRequest carry the versions of the pull request from If-Match.
 type MergePRRequest struct {
-	ID string `json:"pull_request_id"`
+	ID      string   `json:"pull_request_id"`
+	IfMatch Versions `json:"-"`
 }
 
 type ReassignPRReviewerRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldReviewerID string `json:"old_reviewer_id"`
+	PullRequestID string   `json:"pull_request_id"`
+	OldReviewerID string   `json:"old_reviewer_id"`
+	IfMatch       Versions `json:"-"`
+}
+
+type CreateAPITokenRequest struct {
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	UserID    string     `json:"user_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type RevokeAPITokenRequest struct {
+	ID int64 `json:"token_id"`
 }
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type AddTeamResponse struct {
 	Team Team `json:"team"`
 }
@@ -8,15 +10,34 @@ type SetUserStatusResponse struct {
 	User User `json:"user"`
 }
 
+type GetUserResponse struct {
+	User UserDetails `json:"user"`
+}
+
+type ListUsersResponse struct {
+	Users      []*UserDetails `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
 type GetUserReviewsResponse struct {
 	UserID       string              `json:"user_id"`
 	PullRequests []*PullRequestShort `json:"pull_requests"`
+	NextCursor   string              `json:"next_cursor,omitempty"`
 }
 
 type CreatePRResponse struct {
 	PullRequest PullRequest `json:"pr"`
 }
 
+type GetPullRequestResponse struct {
+	PullRequest PullRequestDetails `json:"pr"`
+}
+
+type ListPullRequestsResponse struct {
+	PullRequests []*PullRequestDetails `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
 type MergePullRequestResponse struct {
 	PullRequest PullRequest `json:"pr"`
 }
@@ -25,3 +46,12 @@ type ReassignPullRequestReviewerResponse struct {
 	PullRequest PullRequest `json:"pr"`
 	ReplacedBy  string      `json:"replaced_by"`
 }
+
+type CreateAPITokenResponse struct {
+	ID        int64      `json:"token_id"`
+	Token     string     `json:"token"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	UserID    string     `json:"user_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import "encoding/json"
+
+// SCIM 2.0 schema URNs, see RFC 7643 and RFC 7644.
+const (
+	SCIMUserSchema           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMEnterpriseUserSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SCIMGroupSchema          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMListResponseSchema   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMPatchOpSchema        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMErrorSchema          = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIMUser maps to a user: id and externalId to user_id, userName and displayName to username,
+// the primary email to email, active to is_active and the enterprise department to team_name.
+type SCIMUser struct {
+	Schemas     []string            `json:"schemas"`
+	ID          string              `json:"id,omitempty"`
+	ExternalID  string              `json:"externalId,omitempty"`
+	UserName    string              `json:"userName"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Active      *bool               `json:"active,omitempty"`
+	Emails      []SCIMEmail         `json:"emails,omitempty"`
+	Groups      []SCIMMember        `json:"groups,omitempty"`
+	Enterprise  *SCIMEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *SCIMMeta           `json:"meta,omitempty"`
+}
+
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SCIMEnterpriseUser struct {
+	Department string `json:"department,omitempty"`
+}
+
+// SCIMGroup maps to a team, the team name is both the id and the displayName.
+type SCIMGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []SCIMMember `json:"members,omitempty"`
+	Meta        *SCIMMeta    `json:"meta,omitempty"`
+}
+
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+type SCIMListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
@@ -12,6 +12,11 @@ type TeamUsersCount struct {
 	Users    int    `json:"users_count"`
 }
 
+type TeamOpenReviews struct {
+	TeamName    string
+	OpenReviews int
+}
+
 type PullRequestsStatsResponse struct {
 	TotalPRs  int `json:"total_prs"`
 	OpenPRs   int `json:"open_prs"`
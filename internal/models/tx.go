@@ -0,0 +1,11 @@
+package models
+
+import "context"
+
+// Tx is a storage transaction. Begin starts a nested transaction
+// that can be rolled back without aborting the outer one.
+type Tx interface {
+	Begin(ctx context.Context) (Tx, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
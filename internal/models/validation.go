@@ -0,0 +1,228 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits of the database columns.
+const (
+	MaxUserIDLength        = 10
+	MaxPullRequestIDLength = 100
+	MaxNameLength          = 255
+	MaxEmailLength         = 255
+)
+
+// validation collects the field errors of a request.
+type validation struct {
+	errs []FieldError
+}
+
+func (v *validation) fail(field, rule, message string) {
+	v.errs = append(v.errs, FieldError{Field: field, Rule: rule, Message: message})
+}
+
+// required checks that the value is not blank.
+func (v *validation) required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.fail(field, "required", "field is required")
+		return false
+	}
+
+	return true
+}
+
+func (v *validation) maxLength(field, value string, maxLength int) {
+	if utf8.RuneCountInString(value) > maxLength {
+		v.fail(field, "maxLength", fmt.Sprintf("must be at most %d characters long", maxLength))
+	}
+}
+
+// text checks a required string column.
+func (v *validation) text(field, value string, maxLength int) {
+	if v.required(field, value) {
+		v.maxLength(field, value, maxLength)
+	}
+}
+
+func (v *validation) email(field, value string) {
+	if value == "" {
+		return
+	}
+
+	v.maxLength(field, value, MaxEmailLength)
+	if _, err := mail.ParseAddress(value); err != nil {
+		v.fail(field, "format", "must be an email address")
+	}
+}
+
+func (v *validation) oneOf(field, value string, allowed ...string) {
+	if value != "" && !slices.Contains(allowed, value) {
+		v.fail(field, "enum", "must be one of "+strings.Join(allowed, ", "))
+	}
+}
+
+// limit checks a page size, zero asks for the default one.
+func (v *validation) limit(field string, value int) {
+	if value < 0 || value > MaxPageSize {
+		v.fail(field, "range", fmt.Sprintf("must be between 0 and %d", MaxPageSize))
+	}
+}
+
+// ValidateText checks a required string parameter against the length of its column.
+func ValidateText(field, value string, maxLength int) []FieldError {
+	var v validation
+	v.text(field, value, maxLength)
+
+	return v.errs
+}
+
+// Validate returns the violated rules of the request, nothing when the request is valid.
+func (r AddTeamRequest) Validate() []FieldError {
+	var v validation
+	v.text("team_name", r.Name, MaxNameLength)
+
+	if len(r.Members) == 0 {
+		v.fail("members", "minItems", "must contain at least 1 items")
+	}
+
+	seen := make(map[string]int, len(r.Members))
+	for i, member := range r.Members {
+		field := fmt.Sprintf("members[%d].", i)
+		v.text(field+"user_id", member.ID, MaxUserIDLength)
+		v.text(field+"username", member.Username, MaxNameLength)
+		v.email(field+"email", member.Email)
+
+		if first, ok := seen[member.ID]; ok && member.ID != "" {
+			v.fail(field+"user_id", "unique", fmt.Sprintf("duplicates members[%d]", first))
+			continue
+		}
+		seen[member.ID] = i
+	}
+
+	return v.errs
+}
+
+// Validate checks a user synced from an identity provider.
+func (u User) Validate() []FieldError {
+	var v validation
+	v.text("user_id", u.ID, MaxUserIDLength)
+	v.text("username", u.Username, MaxNameLength)
+	v.email("email", u.Email)
+	v.text("team_name", u.TeamName, MaxNameLength)
+
+	return v.errs
+}
+
+func (r SetUserStatusRequest) Validate() []FieldError {
+	var v validation
+	v.text("user_id", r.ID, MaxUserIDLength)
+
+	return v.errs
+}
+
+func (r CreatePRRequest) Validate() []FieldError {
+	var v validation
+	v.text("pull_request_id", r.ID, MaxPullRequestIDLength)
+	v.text("pull_request_name", r.Name, MaxNameLength)
+	v.text("author_id", r.AuthorID, MaxUserIDLength)
+
+	return v.errs
+}
+
+func (r MergePRRequest) Validate() []FieldError {
+	var v validation
+	v.text("pull_request_id", r.ID, MaxPullRequestIDLength)
+
+	return v.errs
+}
+
+func (r ReassignPRReviewerRequest) Validate() []FieldError {
+	var v validation
+	v.text("pull_request_id", r.PullRequestID, MaxPullRequestIDLength)
+	v.text("old_reviewer_id", r.OldReviewerID, MaxUserIDLength)
+
+	return v.errs
+}
+
+func (r UserReviewsRequest) Validate() []FieldError {
+	var v validation
+	v.text("user_id", r.UserID, MaxUserIDLength)
+	v.oneOf("status", r.Status, "OPEN", "MERGED")
+	v.limit("limit", r.Limit)
+
+	return v.errs
+}
+
+func (r ListUsersRequest) Validate() []FieldError {
+	var v validation
+	v.maxLength("team_name", r.TeamName, MaxNameLength)
+	v.maxLength("name_prefix", r.NamePrefix, MaxNameLength)
+	v.limit("limit", r.Limit)
+
+	return v.errs
+}
+
+func (r ListPullRequestsRequest) Validate() []FieldError {
+	var v validation
+	v.oneOf("status", r.Status, "OPEN", "MERGED")
+	v.maxLength("author_id", r.AuthorID, MaxUserIDLength)
+	v.maxLength("team_name", r.TeamName, MaxNameLength)
+	v.maxLength("reviewer_id", r.ReviewerID, MaxUserIDLength)
+	v.oneOf("sort_by", r.SortBy, SortByCreatedAt, SortByMergedAt, SortByName)
+	v.oneOf("order", r.Order, OrderAsc, OrderDesc)
+	v.limit("limit", r.Limit)
+
+	if r.CreatedFrom != nil && r.CreatedTo != nil && !r.CreatedFrom.Before(*r.CreatedTo) {
+		v.fail("created_from", "range", "must be before created_to")
+	}
+
+	if r.MergedFrom != nil && r.MergedTo != nil && !r.MergedFrom.Before(*r.MergedTo) {
+		v.fail("merged_from", "range", "must be before merged_to")
+	}
+
+	return v.errs
+}
+
+// Validate reports the errors of each row under its position in the source, like rows[3].email.
+func (r ImportRequest) Validate() []FieldError {
+	var v validation
+	if len(r.Rows) == 0 {
+		v.fail("rows", "minItems", "must contain at least 1 items")
+	}
+
+	seen := make(map[string]int, len(r.Rows))
+	for _, row := range r.Rows {
+		field := fmt.Sprintf("rows[%d].", row.Row)
+		v.text(field+"team_name", row.TeamName, MaxNameLength)
+		v.text(field+"user_id", row.UserID, MaxUserIDLength)
+		v.text(field+"username", row.Username, MaxNameLength)
+		v.email(field+"email", row.Email)
+
+		if first, ok := seen[row.UserID]; ok && row.UserID != "" {
+			v.fail(field+"user_id", "unique", fmt.Sprintf("duplicates row %d", first))
+			continue
+		}
+		seen[row.UserID] = row.Row
+	}
+
+	return v.errs
+}
+
+func (r CreateAPITokenRequest) Validate() []FieldError {
+	var v validation
+	v.text("name", r.Name, MaxNameLength)
+	if v.required("role", r.Role) {
+		v.oneOf("role", r.Role, RoleAdmin, RoleTeamLead, RoleMember, RoleReadOnly)
+	}
+	v.maxLength("user_id", r.UserID, MaxUserIDLength)
+
+	if r.Role == RoleTeamLead && r.UserID == "" {
+		v.fail("user_id", "required", "team-lead token requires user_id")
+	}
+
+	return v.errs
+}
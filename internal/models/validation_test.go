@@ -0,0 +1,131 @@
+package models_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+type validator interface {
+	Validate() []models.FieldError
+}
+
+// rules lists the violations as "field:rule".
+func rules(errs []models.FieldError) []string {
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Field+":"+err.Rule)
+	}
+
+	return got
+}
+
+func TestValidate(t *testing.T) {
+	longID := strings.Repeat("u", models.MaxUserIDLength+1)
+	longName := strings.Repeat("я", models.MaxNameLength+1)
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		request validator
+		want    []string
+	}{
+		{"valid team", models.AddTeamRequest{Name: "backend", Members: []models.TeamMember{
+			{ID: "u1", Username: "Alice", Email: "alice@example.com"},
+		}}, nil},
+		{"team without members", models.AddTeamRequest{Name: " "}, []string{"team_name:required", "members:minItems"}},
+		{"team with invalid members", models.AddTeamRequest{Name: longName, Members: []models.TeamMember{
+			{ID: "u1", Username: "Alice", Email: "alice"},
+			{ID: longID, Username: ""},
+			{ID: "u1", Username: "Alice again"},
+		}}, []string{"team_name:maxLength", "members[0].email:format", "members[1].user_id:maxLength",
+			"members[1].username:required", "members[2].user_id:unique"}},
+
+		{"valid user", models.User{ID: "u1", Username: "Alice", TeamName: "backend"}, nil},
+		{"user without fields", models.User{}, []string{"user_id:required", "username:required",
+			"team_name:required"}},
+		{"user with long fields", models.User{ID: longID, Username: longName, Email: "a@" + longName,
+			TeamName: longName}, []string{"user_id:maxLength", "username:maxLength", "email:maxLength",
+			"team_name:maxLength"}},
+
+		{"valid status change", models.SetUserStatusRequest{ID: "u1"}, nil},
+		{"status change without user", models.SetUserStatusRequest{}, []string{"user_id:required"}},
+
+		{"valid pull request", models.CreatePRRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1"}, nil},
+		{"pull request without fields", models.CreatePRRequest{}, []string{"pull_request_id:required",
+			"pull_request_name:required", "author_id:required"}},
+		{"pull request with long author", models.CreatePRRequest{ID: "pr-1", Name: "Add search", AuthorID: longID},
+			[]string{"author_id:maxLength"}},
+		{"merge without id", models.MergePRRequest{}, []string{"pull_request_id:required"}},
+		{"reassign without fields", models.ReassignPRReviewerRequest{}, []string{"pull_request_id:required",
+			"old_reviewer_id:required"}},
+
+		{"valid reviews", models.UserReviewsRequest{UserID: "u1", Status: "OPEN", Limit: models.MaxPageSize}, nil},
+		{"reviews with the default limit", models.UserReviewsRequest{UserID: "u1"}, nil},
+		{"reviews with invalid filters", models.UserReviewsRequest{Status: "open", Limit: models.MaxPageSize + 1},
+			[]string{"user_id:required", "status:enum", "limit:range"}},
+
+		{"valid users", models.ListUsersRequest{TeamName: "backend", NamePrefix: "al", Limit: 1}, nil},
+		{"users with invalid filters", models.ListUsersRequest{TeamName: longName, NamePrefix: longName, Limit: -1},
+			[]string{"team_name:maxLength", "name_prefix:maxLength", "limit:range"}},
+
+		{"valid pull requests", models.ListPullRequestsRequest{Status: "MERGED", SortBy: models.SortByMergedAt,
+			Order: models.OrderDesc, MergedFrom: &earlier, MergedTo: &now}, nil},
+		{"pull requests with invalid filters", models.ListPullRequestsRequest{
+			Status: "CLOSED", AuthorID: longID, TeamName: longName, ReviewerID: longID, SortBy: "id", Order: "up",
+			Limit: models.MaxPageSize + 1, CreatedFrom: &now, CreatedTo: &earlier, MergedFrom: &now, MergedTo: &now,
+		}, []string{"status:enum", "author_id:maxLength", "team_name:maxLength", "reviewer_id:maxLength",
+			"sort_by:enum", "order:enum", "limit:range", "created_from:range", "merged_from:range"}},
+
+		{"valid import", models.ImportRequest{Rows: []models.ImportRow{
+			{Row: 2, TeamName: "backend", UserID: "u1", Username: "Alice", Email: "alice@example.com"},
+		}}, nil},
+		{"empty import", models.ImportRequest{}, []string{"rows:minItems"}},
+		{"import with invalid rows", models.ImportRequest{Rows: []models.ImportRow{
+			{Row: 2, TeamName: "backend", UserID: "u1", Username: "Alice", Email: "alice"},
+			{Row: 3, UserID: "u1", Username: "Alice"},
+		}}, []string{"rows[2].email:format", "rows[3].team_name:required", "rows[3].user_id:unique"}},
+
+		{"valid token", models.CreateAPITokenRequest{Name: "ci", Role: models.RoleTeamLead, UserID: "u1"}, nil},
+		{"token without fields", models.CreateAPITokenRequest{}, []string{"name:required", "role:required"}},
+		{"token with unknown role", models.CreateAPITokenRequest{Name: "ci", Role: "root"}, []string{"role:enum"}},
+		{"team-lead token without user", models.CreateAPITokenRequest{Name: "ci", Role: models.RoleTeamLead},
+			[]string{"user_id:required"}},
+	}
+
+	for _, tt := range tests {
+		if got := rules(tt.request.Validate()); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Validate() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"backend", nil},
+		{"", []string{"team_name:required"}},
+		{"  ", []string{"team_name:required"}},
+		{strings.Repeat("я", models.MaxNameLength), nil},
+		{strings.Repeat("я", models.MaxNameLength+1), []string{"team_name:maxLength"}},
+	}
+
+	for _, tt := range tests {
+		if got := rules(models.ValidateText("team_name", tt.value, models.MaxNameLength)); !slices.Equal(got, tt.want) {
+			t.Errorf("ValidateText(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLimitMessage(t *testing.T) {
+	errs := models.UserReviewsRequest{UserID: "u1", Limit: -1}.Validate()
+	if len(errs) != 1 || errs[0].Message != "must be between 0 and 100" {
+		t.Errorf("Validate(limit -1) = %+v, want a range that allows the default 0", errs)
+	}
+}
@@ -0,0 +1,246 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAssignedTemplate = `{{handle .ReviewerID}}, you have been assigned to review ` +
+		`"{{.PullRequestName}}" ({{.PullRequestID}}) by {{handle .AuthorID}}`
+	defaultReassignedTemplate = `{{handle .ReviewerID}}, you have been assigned to review ` +
+		`"{{.PullRequestName}}" ({{.PullRequestID}}) instead of {{handle .OldReviewerID}}`
+	defaultSLABreachedTemplate = `{{handle .ReviewerID}}, review of "{{.PullRequestName}}" ({{.PullRequestID}}) ` +
+		`by {{handle .AuthorID}} is waiting since {{.AssignedAt.Format "2006-01-02 15:04 MST"}}`
+	defaultMergedTemplate = `"{{.PullRequestName}}" ({{.PullRequestID}}) by {{handle .AuthorID}} has been merged` +
+		`{{if .Reviewers}}, thanks {{handles .Reviewers}}{{end}}`
+)
+
+type NotifierCfg struct {
+	WebhookURL       string            `env:"NOTIFIER_WEBHOOK_URL"`
+	Username         string            `env:"NOTIFIER_USERNAME"            env-default:"pr-reviewer-bot"`
+	TeamChannels     map[string]string `env:"NOTIFIER_TEAM_CHANNELS"`
+	TeamWebhooks     map[string]string `env:"NOTIFIER_TEAM_WEBHOOKS"`
+	UserHandles      map[string]string `env:"NOTIFIER_USER_HANDLES"`
+	Timeout          time.Duration     `env:"NOTIFIER_TIMEOUT"             env-default:"5s"`
+	QueueSize        int               `env:"NOTIFIER_QUEUE_SIZE"          env-default:"100"`
+	ReviewSLA        time.Duration     `env:"REVIEW_SLA"                   env-default:"24h"`
+	SLACheckInterval time.Duration     `env:"REVIEW_SLA_CHECK_INTERVAL"    env-default:"10m"`
+	Templates        TemplatesCfg
+}
+
+type TemplatesCfg struct {
+	Assigned    string `env:"NOTIFIER_TEMPLATE_ASSIGNED"`
+	Reassigned  string `env:"NOTIFIER_TEMPLATE_REASSIGNED"`
+	SLABreached string `env:"NOTIFIER_TEMPLATE_SLA_BREACHED"`
+	Merged      string `env:"NOTIFIER_TEMPLATE_MERGED"`
+}
+
+// Message is an incoming-webhook payload understood by both Slack and Mattermost.
+type Message struct {
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+	Text     string `json:"text"`
+}
+
+type Notifier struct {
+	cfg       NotifierCfg
+	client    *http.Client
+	templates map[string]*template.Template
+	queue     chan models.PullRequestEvent
+	done      chan struct{}
+
+	// mu guards closed, so that handlers still running after Close don't send to the closed queue.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewNotifier(cfg NotifierCfg) (*Notifier, error) {
+	n := &Notifier{
+		cfg:       cfg,
+		client:    &http.Client{Timeout: cfg.Timeout},
+		templates: make(map[string]*template.Template),
+		queue:     make(chan models.PullRequestEvent, cfg.QueueSize),
+		done:      make(chan struct{}),
+	}
+
+	templates := map[string]string{
+		models.EventReviewerAssigned:   withDefault(cfg.Templates.Assigned, defaultAssignedTemplate),
+		models.EventReviewerReassigned: withDefault(cfg.Templates.Reassigned, defaultReassignedTemplate),
+		models.EventReviewSLABreached:  withDefault(cfg.Templates.SLABreached, defaultSLABreachedTemplate),
+		models.EventPullRequestMerged:  withDefault(cfg.Templates.Merged, defaultMergedTemplate),
+	}
+
+	funcs := template.FuncMap{
+		"handle":  n.Handle,
+		"handles": n.handles,
+	}
+
+	for eventType, text := range templates {
+		tmpl, err := template.New(eventType).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", eventType, err)
+		}
+		n.templates[eventType] = tmpl
+	}
+
+	go n.run()
+
+	return n, nil
+}
+
+func withDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
+
+// Handle returns the chat handle configured for the user or the user id itself.
+func (n *Notifier) Handle(userID string) string {
+	if handle, ok := n.cfg.UserHandles[userID]; ok && handle != "" {
+		return handle
+	}
+
+	return userID
+}
+
+func (n *Notifier) handles(userIDs []string) string {
+	mentions := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		mentions = append(mentions, n.Handle(userID))
+	}
+
+	return strings.Join(mentions, ", ")
+}
+
+func (n *Notifier) webhookURL(teamName string) string {
+	if url, ok := n.cfg.TeamWebhooks[teamName]; ok && url != "" {
+		return url
+	}
+
+	return n.cfg.WebhookURL
+}
+
+// Notify queues events for delivery without blocking the caller, events are dropped after Close.
+func (n *Notifier) Notify(events ...models.PullRequestEvent) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, event := range events {
+		if n.webhookURL(event.TeamName) == "" {
+			continue
+		}
+
+		if n.closed {
+			zap.L().Warn("notifier is closed, dropping event",
+				zap.String("event", event.Type),
+				zap.String("pull_request_id", event.PullRequestID))
+			continue
+		}
+
+		select {
+		case n.queue <- event:
+		default:
+			zap.L().Warn("notification queue is full, dropping event",
+				zap.String("event", event.Type),
+				zap.String("pull_request_id", event.PullRequestID))
+		}
+	}
+}
+
+func (n *Notifier) run() {
+	defer close(n.done)
+
+	for event := range n.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), n.cfg.Timeout)
+		if err := n.Send(ctx, event); err != nil {
+			zap.L().Error("failed to send notification",
+				zap.Error(err),
+				zap.String("event", event.Type),
+				zap.String("pull_request_id", event.PullRequestID))
+		}
+		cancel()
+	}
+}
+
+// Close stops accepting events and waits until the queued ones are delivered.
+func (n *Notifier) Close() {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+
+	<-n.done
+}
+
+func (n *Notifier) Render(event models.PullRequestEvent) (string, error) {
+	tmpl, ok := n.templates[event.Type]
+	if !ok {
+		return "", fmt.Errorf("unknown event type %q", event.Type)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", event.Type, err)
+	}
+
+	return buf.String(), nil
+}
+
+func (n *Notifier) Send(ctx context.Context, event models.PullRequestEvent) error {
+	text, err := n.Render(event)
+	if err != nil {
+		return err
+	}
+
+	return n.Post(ctx, event.TeamName, text)
+}
+
+// Post delivers a text message to the webhook and channel configured for the team.
+func (n *Notifier) Post(ctx context.Context, teamName, text string) error {
+	url := n.webhookURL(teamName)
+	if url == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(Message{
+		Channel:  n.cfg.TeamChannels[teamName],
+		Username: n.cfg.Username,
+		Text:     text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
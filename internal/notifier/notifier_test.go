@@ -0,0 +1,234 @@
+package notifier_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/notifier"
+)
+
+// webhook stands in for a Slack/Mattermost incoming webhook and records the received messages.
+type webhook struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []notifier.Message
+	status   int
+}
+
+func newWebhook(t *testing.T) *webhook {
+	t.Helper()
+
+	w := &webhook{status: http.StatusOK}
+	w.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("webhook got %s request, want POST", r.Method)
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("webhook got Content-Type %q, want application/json", contentType)
+		}
+
+		var msg notifier.Message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("webhook got malformed payload: %v", err)
+		}
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.messages = append(w.messages, msg)
+		rw.WriteHeader(w.status)
+	}))
+	t.Cleanup(w.Close)
+
+	return w
+}
+
+func (w *webhook) received() []notifier.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]notifier.Message(nil), w.messages...)
+}
+
+func newTestNotifier(t *testing.T, cfg notifier.NotifierCfg) *notifier.Notifier {
+	t.Helper()
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = time.Second
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = 10
+	}
+
+	n, err := notifier.NewNotifier(cfg)
+	if err != nil {
+		t.Fatalf("notifier.NewNotifier() error = %v", err)
+	}
+
+	return n
+}
+
+func TestNotifyDeliversEveryEventType(t *testing.T) {
+	hook := newWebhook(t)
+	n := newTestNotifier(t, notifier.NotifierCfg{
+		WebhookURL:   hook.URL,
+		Username:     "bot",
+		TeamChannels: map[string]string{"backend": "#backend-review"},
+		UserHandles:  map[string]string{"u1": "@alice", "u2": "<@U02>", "u3": "@carol"},
+	})
+
+	assignedAt := time.Date(2025, 1, 2, 15, 4, 0, 0, time.UTC)
+	n.Notify(
+		models.PullRequestEvent{
+			Type: models.EventReviewerAssigned, TeamName: "backend",
+			PullRequestID: "pr-1", PullRequestName: "Add cache", AuthorID: "u1", ReviewerID: "u2",
+		},
+		models.PullRequestEvent{
+			Type: models.EventReviewerReassigned, TeamName: "backend",
+			PullRequestID: "pr-1", PullRequestName: "Add cache", AuthorID: "u1", ReviewerID: "u3", OldReviewerID: "u2",
+		},
+		models.PullRequestEvent{
+			Type: models.EventReviewSLABreached, TeamName: "backend",
+			PullRequestID: "pr-1", PullRequestName: "Add cache", AuthorID: "u1", ReviewerID: "u3", AssignedAt: assignedAt,
+		},
+		models.PullRequestEvent{
+			Type: models.EventPullRequestMerged, TeamName: "backend",
+			PullRequestID: "pr-1", PullRequestName: "Add cache", AuthorID: "u1", Reviewers: []string{"u3", "u4"},
+		},
+	)
+	n.Close()
+
+	want := []string{
+		`<@U02>, you have been assigned to review "Add cache" (pr-1) by @alice`,
+		`@carol, you have been assigned to review "Add cache" (pr-1) instead of <@U02>`,
+		`@carol, review of "Add cache" (pr-1) by @alice is waiting since 2025-01-02 15:04 UTC`,
+		`"Add cache" (pr-1) by @alice has been merged, thanks @carol, u4`,
+	}
+
+	messages := hook.received()
+	if len(messages) != len(want) {
+		t.Fatalf("webhook got %d messages, want %d: %+v", len(messages), len(want), messages)
+	}
+
+	for i, msg := range messages {
+		if msg.Text != want[i] {
+			t.Errorf("message %d text = %q, want %q", i, msg.Text, want[i])
+		}
+		if msg.Channel != "#backend-review" {
+			t.Errorf("message %d channel = %q, want #backend-review", i, msg.Channel)
+		}
+		if msg.Username != "bot" {
+			t.Errorf("message %d username = %q, want bot", i, msg.Username)
+		}
+	}
+}
+
+func TestNotifyRoutesTeamsToTheirWebhooks(t *testing.T) {
+	defaultHook := newWebhook(t)
+	paymentsHook := newWebhook(t)
+	n := newTestNotifier(t, notifier.NotifierCfg{
+		WebhookURL:   defaultHook.URL,
+		TeamWebhooks: map[string]string{"payments": paymentsHook.URL},
+	})
+
+	n.Notify(
+		models.PullRequestEvent{Type: models.EventReviewerAssigned, TeamName: "payments", PullRequestID: "pr-1"},
+		models.PullRequestEvent{Type: models.EventReviewerAssigned, TeamName: "backend", PullRequestID: "pr-2"},
+	)
+	n.Close()
+
+	if got := paymentsHook.received(); len(got) != 1 || !strings.Contains(got[0].Text, "pr-1") {
+		t.Errorf("payments webhook got %+v, want only pr-1", got)
+	}
+	if got := defaultHook.received(); len(got) != 1 || !strings.Contains(got[0].Text, "pr-2") {
+		t.Errorf("default webhook got %+v, want only pr-2", got)
+	}
+}
+
+func TestNotifySkipsTeamsWithoutWebhook(t *testing.T) {
+	paymentsHook := newWebhook(t)
+	n := newTestNotifier(t, notifier.NotifierCfg{
+		TeamWebhooks: map[string]string{"payments": paymentsHook.URL},
+	})
+
+	n.Notify(models.PullRequestEvent{Type: models.EventReviewerAssigned, TeamName: "backend", PullRequestID: "pr-1"})
+	n.Close()
+
+	if got := paymentsHook.received(); len(got) != 0 {
+		t.Errorf("webhook got %+v, want no messages", got)
+	}
+}
+
+func TestNotifyAfterCloseDropsEvents(t *testing.T) {
+	hook := newWebhook(t)
+	n := newTestNotifier(t, notifier.NotifierCfg{WebhookURL: hook.URL})
+
+	n.Notify(models.PullRequestEvent{Type: models.EventReviewerAssigned, PullRequestID: "pr-1"})
+	n.Close()
+
+	// A handler that outlived the shutdown timeout must not panic on the closed queue.
+	n.Notify(models.PullRequestEvent{Type: models.EventReviewerAssigned, PullRequestID: "pr-2"})
+	n.Close()
+
+	got := hook.received()
+	if len(got) != 1 || !strings.Contains(got[0].Text, "pr-1") {
+		t.Errorf("webhook got %+v, want only pr-1", got)
+	}
+}
+
+func TestCustomTemplates(t *testing.T) {
+	hook := newWebhook(t)
+	n := newTestNotifier(t, notifier.NotifierCfg{
+		WebhookURL:  hook.URL,
+		UserHandles: map[string]string{"u1": "@alice"},
+		Templates: notifier.TemplatesCfg{
+			Merged: `merged {{.PullRequestID}}: {{handles .Reviewers}}`,
+		},
+	})
+	defer n.Close()
+
+	event := models.PullRequestEvent{
+		Type: models.EventPullRequestMerged, PullRequestID: "pr-1", Reviewers: []string{"u1", "u2"},
+	}
+	if err := n.Send(t.Context(), event); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got := hook.received(); len(got) != 1 || got[0].Text != "merged pr-1: @alice, u2" {
+		t.Errorf("webhook got %+v, want the custom template", got)
+	}
+}
+
+func TestNewNotifierRejectsInvalidTemplate(t *testing.T) {
+	_, err := notifier.NewNotifier(notifier.NotifierCfg{Templates: notifier.TemplatesCfg{Assigned: "{{.Missing"}})
+	if err == nil {
+		t.Fatal("notifier.NewNotifier() error = nil, want a template parse error")
+	}
+}
+
+func TestSendReportsWebhookFailure(t *testing.T) {
+	hook := newWebhook(t)
+	hook.status = http.StatusInternalServerError
+	n := newTestNotifier(t, notifier.NotifierCfg{WebhookURL: hook.URL})
+	defer n.Close()
+
+	err := n.Send(t.Context(), models.PullRequestEvent{Type: models.EventReviewerAssigned})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Send() error = %v, want webhook status 500", err)
+	}
+}
+
+func TestRenderUnknownEventType(t *testing.T) {
+	n := newTestNotifier(t, notifier.NotifierCfg{})
+	defer n.Close()
+
+	if _, err := n.Render(models.PullRequestEvent{Type: "UNKNOWN"}); err == nil {
+		t.Error("Render() error = nil, want unknown event type")
+	}
+}
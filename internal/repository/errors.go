@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by every repository implementation, match them with errors.Is and errors.As.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrDB       = errors.New("database")
+)
+
+// Entities a unique constraint belongs to.
+const (
+	EntityTeam        = "team"
+	EntityUser        = "user"
+	EntityEmail       = "email"
+	EntityPullRequest = "pr"
+)
+
+// ErrConflict reports that a row with the same unique key as the entity already exists.
+type ErrConflict struct {
+	Entity string
+}
+
+func (e *ErrConflict) Error() string {
+	return e.Entity + " unique violation"
+}
+
+// NotFound reports a missing entity, the error matches ErrNotFound.
+func NotFound(entity string) error {
+	return fmt.Errorf("%s %w", entity, ErrNotFound)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// InsertPullRequestSequentially sends the statements of InsertPullRequest one by one,
+// as the create path did before they were batched.
+func (r *Repository) InsertPullRequestSequentially(
+	ctx context.Context,
+	tx models.Tx,
+	pullRequest models.CreatePRRequest,
+	reviewers []string,
+	events []models.PullRequestEvent,
+) error {
+	batch, _, err := r.insertPullRequestBatch(pullRequest, reviewers, events)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range batch.QueuedQueries {
+		if _, err = pgxTx(tx).Exec(ctx, query.SQL, query.Arguments...); err != nil {
+			return wrapDBError(err, "InsertPullRequestSequentially")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// InsertIdempotencyKey claims the key for a new request, an expired key is claimed again.
+// It reports false when the key is held by another request.
+func (r *Repository) InsertIdempotencyKey(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	query, args, err := r.builder.
+		Insert("idempotency_keys").
+		Columns("idempotency_key", "expires_at").
+		Values(key, expiresAt).
+		Suffix(`ON CONFLICT (idempotency_key) DO UPDATE
+			SET request_hash = NULL, status_code = NULL, response_headers = NULL, response_body = NULL,
+				created_at = NOW(), expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= NOW()`).
+		ToSql()
+
+	if err != nil {
+		return false, wrapDBError(err, "InsertIdempotencyKey: build query")
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, wrapDBError(err, "InsertIdempotencyKey: execute query")
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// SelectIdempotencyKey returns nil when the key is unknown or expired.
+func (r *Repository) SelectIdempotencyKey(ctx context.Context, key string) (*models.IdempotencyRecord, error) {
+	query, args, err := r.builder.
+		Select("idempotency_key", "COALESCE(request_hash, '')", "COALESCE(status_code, 0)",
+			"response_headers", "response_body", "expires_at").
+		From("idempotency_keys").
+		Where(squirrel.Eq{"idempotency_key": key}).
+		Where(squirrel.Expr("expires_at > NOW()")).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectIdempotencyKey: build query")
+	}
+
+	var record models.IdempotencyRecord
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&record.Key, &record.RequestHash, &record.StatusCode, &record.Headers, &record.Body, &record.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectIdempotencyKey: query row")
+	}
+
+	return &record, nil
+}
+
+func (r *Repository) UpdateIdempotencyKey(ctx context.Context, record models.IdempotencyRecord) error {
+	query, args, err := r.builder.
+		Update("idempotency_keys").
+		Set("request_hash", record.RequestHash).
+		Set("status_code", record.StatusCode).
+		Set("response_headers", record.Headers).
+		Set("response_body", record.Body).
+		Set("expires_at", record.ExpiresAt).
+		Where(squirrel.Eq{"idempotency_key": record.Key}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "UpdateIdempotencyKey: build query")
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "UpdateIdempotencyKey: execute query")
+	}
+
+	if result.RowsAffected() == 0 {
+		return NotFound("idempotency key")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	query, args, err := r.builder.
+		Delete("idempotency_keys").
+		Where(squirrel.Eq{"idempotency_key": key}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "DeleteIdempotencyKey: build query")
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		return wrapDBError(err, "DeleteIdempotencyKey: execute query")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	query, args, err := r.builder.
+		Delete("idempotency_keys").
+		Where(squirrel.Expr("expires_at <= NOW()")).
+		ToSql()
+
+	if err != nil {
+		return 0, wrapDBError(err, "DeleteExpiredIdempotencyKeys: build query")
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, wrapDBError(err, "DeleteExpiredIdempotencyKeys: execute query")
+	}
+
+	return result.RowsAffected(), nil
+}
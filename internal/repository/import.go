@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// UpsertTeam creates the team unless it exists and reports whether it was created.
+func (r *Repository) UpsertTeam(ctx context.Context, tx models.Tx, teamName string) (bool, error) {
+	query, args, err := r.builder.
+		Insert("teams").
+		Columns("team_name").
+		Values(teamName).
+		Suffix("ON CONFLICT (team_name) DO NOTHING RETURNING team_name").
+		ToSql()
+
+	if err != nil {
+		return false, wrapDBError(err, "UpsertTeam: build query")
+	}
+
+	var inserted string
+	err = pgxTx(tx).QueryRow(ctx, query, args...).Scan(&inserted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, wrapDBError(err, "UpsertTeam: query row")
+	}
+
+	return true, nil
+}
+
+// UpsertTeamMember creates or updates the user. It returns the import action
+// and whether the user was active before, so that deactivation can reassign reviews.
+func (r *Repository) UpsertTeamMember(
+	ctx context.Context, tx models.Tx, member models.TeamMember, teamName string,
+) (string, bool, error) {
+	query, args, err := r.builder.
+		Insert("users").
+		Prefix("WITH previous AS (SELECT is_active, team_name FROM users WHERE id = ?)", member.ID).
+		Columns("id", "user_name", "email", "is_active", "team_name").
+		Values(member.ID, member.Username, nullableString(member.Email), member.IsActive, teamName).
+		Suffix(`ON CONFLICT (id) DO UPDATE SET
+			user_name = EXCLUDED.user_name,
+			email = EXCLUDED.email,
+			is_active = EXCLUDED.is_active,
+			team_name = EXCLUDED.team_name
+		WHERE (users.user_name, users.email, users.is_active, users.team_name)
+			IS DISTINCT FROM (EXCLUDED.user_name, EXCLUDED.email, EXCLUDED.is_active, EXCLUDED.team_name)
+		RETURNING (xmax = 0), COALESCE((SELECT is_active FROM previous), false),
+			COALESCE((SELECT team_name FROM previous), '')`).
+		ToSql()
+
+	if err != nil {
+		return "", false, wrapDBError(err, "UpsertTeamMember: build query")
+	}
+
+	var inserted, wasActive bool
+	var previousTeam string
+	err = pgxTx(tx).QueryRow(ctx, query, args...).Scan(&inserted, &wasActive, &previousTeam)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ImportActionUnchanged, member.IsActive, nil
+	}
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return "", false, &ErrConflict{Entity: EntityEmail}
+		}
+		return "", false, wrapDBError(err, "UpsertTeamMember: query row")
+	}
+
+	err = r.bumpTeamVersions(ctx, tx, squirrel.Eq{"team_name": []string{teamName, previousTeam}})
+	if err != nil {
+		return "", false, err
+	}
+
+	if inserted {
+		return models.ImportActionCreated, false, nil
+	}
+
+	return models.ImportActionUpdated, wasActive, nil
+}
@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+var errUnknownBackupRecord = errors.New("unknown backup record type")
+
+// ExportBackup passes every row of the data tables to write, parents before children.
+// The rows are read from the last committed state, so the export is a consistent snapshot.
+// Errors returned by write are passed through as is.
+func (r *Repository) ExportBackup(
+	_ context.Context, write func(models.BackupRecord) error,
+) (*models.BackupCounts, error) {
+	s := r.committed()
+
+	var records []models.BackupRecord
+	for _, teamName := range slices.Sorted(maps.Keys(s.teams)) {
+		records = append(records, models.BackupRecord{
+			Type: models.BackupTeamRecord,
+			Team: &models.BackupTeam{Name: teamName, Version: s.teams[teamName]},
+		})
+	}
+
+	for _, u := range sortedUsers(s) {
+		records = append(records, models.BackupRecord{
+			Type: models.BackupUserRecord,
+			User: &models.BackupUser{
+				ID:       u.ID,
+				Username: &u.Username,
+				Email:    nullableString(u.Email),
+				TeamName: u.TeamName,
+				IsActive: &u.IsActive,
+			},
+		})
+	}
+
+	pullRequests := slices.SortedFunc(maps.Values(s.pullRequests), func(a, b pullRequest) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	for _, pr := range pullRequests {
+		records = append(records, models.BackupRecord{
+			Type: models.BackupPullRequestRecord,
+			PullRequest: &models.BackupPullRequest{
+				ID:        pr.ID,
+				Name:      pr.Name,
+				AuthorID:  pr.AuthorID,
+				Status:    pr.Status,
+				CreatedAt: pr.CreatedAt,
+				MergedAt:  pr.MergedAt,
+				Version:   pr.Version,
+			},
+		})
+	}
+
+	reviewers := slices.Clone(s.reviewers)
+	slices.SortFunc(reviewers, func(a, b reviewer) int {
+		return compareKeys(cmp.Compare(a.PullRequestID, b.PullRequestID), a.ReviewerID, b.ReviewerID)
+	})
+	for _, rev := range reviewers {
+		records = append(records, models.BackupRecord{
+			Type: models.BackupReviewerRecord,
+			Reviewer: &models.BackupReviewer{
+				PullRequestID: rev.PullRequestID,
+				ReviewerID:    rev.ReviewerID,
+				AssignedAt:    rev.AssignedAt,
+			},
+		})
+	}
+
+	for _, e := range s.events {
+		records = append(records, models.BackupRecord{
+			Type: models.BackupEventRecord,
+			Event: &models.BackupEvent{
+				ID:            e.ID,
+				PullRequestID: e.PullRequestID,
+				Type:          e.Type,
+				ReviewerID:    nullableString(e.ReviewerID),
+				OldReviewerID: nullableString(e.OldReviewerID),
+				CreatedAt:     e.CreatedAt,
+			},
+		})
+	}
+
+	for _, record := range records {
+		if err := write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.counts(), nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func (s *state) counts() *models.BackupCounts {
+	return &models.BackupCounts{
+		Teams:        int64(len(s.teams)),
+		Users:        int64(len(s.users)),
+		PullRequests: int64(len(s.pullRequests)),
+		Reviewers:    int64(len(s.reviewers)),
+		Events:       int64(len(s.events)),
+	}
+}
+
+// LockBackupTables does nothing, writes already wait for the running transaction.
+func (r *Repository) LockBackupTables(_ context.Context, _ models.Tx) error {
+	return nil
+}
+
+// CountBackupRows returns the number of rows in the data tables.
+func (r *Repository) CountBackupRows(_ context.Context, tx models.Tx) (*models.BackupCounts, error) {
+	return txState(tx).counts(), nil
+}
+
+// CopyBackupRecords inserts records of a single type, the records are expected to be validated.
+func (r *Repository) CopyBackupRecords(ctx context.Context, tx models.Tx, records []models.BackupRecord) error {
+	return r.write(ctx, tx, func(s *state) error {
+		for _, record := range records {
+			if err := s.restore(record); err != nil {
+				return wrapDBError(err, "CopyBackupRecords")
+			}
+		}
+		return nil
+	})
+}
+
+func (s *state) restore(record models.BackupRecord) error {
+	switch record.Type {
+	case models.BackupTeamRecord:
+		s.teams[record.Team.Name] = record.Team.Version
+	case models.BackupUserRecord:
+		u := user{ID: record.User.ID, TeamName: record.User.TeamName, IsActive: true}
+		if record.User.Username != nil {
+			u.Username = *record.User.Username
+		}
+		if record.User.Email != nil {
+			u.Email = *record.User.Email
+		}
+		if record.User.IsActive != nil {
+			u.IsActive = *record.User.IsActive
+		}
+		s.users[u.ID] = u
+	case models.BackupPullRequestRecord:
+		pr := record.PullRequest
+		s.pullRequests[pr.ID] = pullRequest{
+			ID:        pr.ID,
+			Name:      pr.Name,
+			AuthorID:  pr.AuthorID,
+			Status:    pr.Status,
+			CreatedAt: pr.CreatedAt,
+			MergedAt:  pr.MergedAt,
+			Version:   pr.Version,
+		}
+	case models.BackupReviewerRecord:
+		s.reviewers = append(s.reviewers, reviewer{
+			PullRequestID: record.Reviewer.PullRequestID,
+			ReviewerID:    record.Reviewer.ReviewerID,
+			AssignedAt:    record.Reviewer.AssignedAt,
+		})
+	case models.BackupEventRecord:
+		e := event{
+			ID:            record.Event.ID,
+			PullRequestID: record.Event.PullRequestID,
+			Type:          record.Event.Type,
+			CreatedAt:     record.Event.CreatedAt,
+		}
+		if record.Event.ReviewerID != nil {
+			e.ReviewerID = *record.Event.ReviewerID
+		}
+		if record.Event.OldReviewerID != nil {
+			e.OldReviewerID = *record.Event.OldReviewerID
+		}
+		s.events = append(s.events, e)
+	default:
+		return errUnknownBackupRecord
+	}
+
+	return nil
+}
+
+// ResetEventSequence moves the event ids past the restored ones.
+func (r *Repository) ResetEventSequence(ctx context.Context, tx models.Tx) error {
+	return r.write(ctx, tx, func(s *state) error {
+		for _, e := range s.events {
+			s.lastEventID = max(s.lastEventID, e.ID)
+		}
+		return nil
+	})
+}
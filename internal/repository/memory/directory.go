@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+// SelectDirectoryUsers returns a page of users matching all the set filter fields ordered by id,
+// along with the total number of matching users.
+func (r *Repository) SelectDirectoryUsers(
+	_ context.Context, filter models.DirectoryUserFilter,
+) ([]models.User, int, error) {
+	users := make([]models.User, 0)
+	for _, u := range sortedUsers(r.committed()) {
+		switch {
+		case filter.ID != "" && u.ID != filter.ID,
+			filter.Username != "" && u.Username != filter.Username,
+			filter.Email != "" && !strings.EqualFold(u.Email, filter.Email):
+			continue
+		}
+
+		users = append(users, u.model())
+	}
+
+	total := len(users)
+	users = users[min(filter.Offset, total):]
+	if filter.Limit > 0 && len(users) > filter.Limit {
+		users = users[:filter.Limit]
+	}
+
+	return users, total, nil
+}
+
+func (r *Repository) TeamExists(_ context.Context, teamName string) (bool, error) {
+	return r.committed().teamExists(teamName), nil
+}
+
+// SelectTeamMemberIDs returns the ids of the team members in tx ordered by id.
+func (r *Repository) SelectTeamMemberIDs(_ context.Context, tx models.Tx, teamName string) ([]string, error) {
+	var ids []string
+	for _, u := range sortedUsers(r.read(tx)) {
+		if u.TeamName == teamName {
+			ids = append(ids, u.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// MoveUsersToTeam changes the team of the given users, with fromTeam set only its members are moved.
+// It returns the number of moved users.
+func (r *Repository) MoveUsersToTeam(
+	ctx context.Context, tx models.Tx, userIDs []string, fromTeam, teamName string,
+) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	var moved int64
+	err := r.write(ctx, tx, func(s *state) error {
+		moved = s.moveUsers(teamName, func(u user) bool {
+			return slices.Contains(userIDs, u.ID) && (fromTeam == "" || u.TeamName == fromTeam)
+		})
+
+		if moved > 0 && !s.teamExists(teamName) {
+			return wrapDBError(errForeignKey, "MoveUsersToTeam")
+		}
+		return nil
+	})
+
+	return moved, err
+}
+
+// MoveTeamMembers moves every member of a team to another one.
+func (r *Repository) MoveTeamMembers(ctx context.Context, tx models.Tx, fromTeam, teamName string) error {
+	return r.write(ctx, tx, func(s *state) error {
+		moved := s.moveUsers(teamName, func(u user) bool {
+			return u.TeamName == fromTeam
+		})
+
+		if moved > 0 && !s.teamExists(teamName) {
+			return wrapDBError(errForeignKey, "MoveTeamMembers")
+		}
+		return nil
+	})
+}
+
+func (s *state) moveUsers(teamName string, match func(user) bool) int64 {
+	var moved int64
+	for id, u := range s.users {
+		if match(u) {
+			s.bumpTeamVersions(u.TeamName, teamName)
+			u.TeamName = teamName
+			s.users[id] = u
+			moved++
+		}
+	}
+
+	return moved
+}
+
+func (r *Repository) DeleteTeam(ctx context.Context, tx models.Tx, teamName string) error {
+	return r.write(ctx, tx, func(s *state) error {
+		if !s.teamExists(teamName) {
+			return repository.NotFound("team")
+		}
+
+		for _, u := range s.users {
+			if u.TeamName == teamName {
+				return wrapDBError(errForeignKey, "DeleteTeam")
+			}
+		}
+
+		delete(s.teams, teamName)
+		return nil
+	})
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (r *Repository) InsertPullRequestEvents(
+	ctx context.Context, tx models.Tx, events []models.PullRequestEvent,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.write(ctx, tx, func(s *state) error {
+		for _, e := range events {
+			_, prExists := s.pullRequests[e.PullRequestID]
+			if !prExists || !s.optionalUserExists(e.ReviewerID) || !s.optionalUserExists(e.OldReviewerID) {
+				return wrapDBError(errForeignKey, "InsertPullRequestEvents")
+			}
+		}
+
+		now := time.Now()
+		for _, e := range events {
+			s.lastEventID++
+			s.events = append(s.events, event{
+				ID:            s.lastEventID,
+				PullRequestID: e.PullRequestID,
+				Type:          e.Type,
+				ReviewerID:    e.ReviewerID,
+				OldReviewerID: e.OldReviewerID,
+				CreatedAt:     now,
+			})
+		}
+		return nil
+	})
+}
+
+func (s *state) optionalUserExists(userID string) bool {
+	if userID == "" {
+		return true
+	}
+
+	_, ok := s.users[userID]
+	return ok
+}
+
+func (r *Repository) SelectBreachedReviews(
+	_ context.Context, assignedBefore time.Time,
+) ([]models.PullRequestEvent, error) {
+	s := r.committed()
+
+	var events []models.PullRequestEvent
+	for _, rev := range s.reviewers {
+		pr := s.pullRequests[rev.PullRequestID]
+		if pr.Status != "OPEN" || !rev.AssignedAt.Before(assignedBefore) || s.breachReported(rev) {
+			continue
+		}
+
+		events = append(events, models.PullRequestEvent{
+			Type:            models.EventReviewSLABreached,
+			PullRequestID:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorID:        pr.AuthorID,
+			TeamName:        s.users[pr.AuthorID].TeamName,
+			ReviewerID:      rev.ReviewerID,
+			AssignedAt:      rev.AssignedAt,
+		})
+	}
+
+	slices.SortStableFunc(events, func(a, b models.PullRequestEvent) int {
+		return a.AssignedAt.Compare(b.AssignedAt)
+	})
+
+	return events, nil
+}
+
+// breachReported reports whether the SLA breach of the review was recorded since it was assigned.
+func (s *state) breachReported(rev reviewer) bool {
+	for _, e := range s.events {
+		if e.PullRequestID == rev.PullRequestID && e.ReviewerID == rev.ReviewerID &&
+			e.Type == models.EventReviewSLABreached && !e.CreatedAt.Before(rev.AssignedAt) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+func (r *Repository) InsertIdempotencyKey(_ context.Context, key string, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.idempotencyKeys[key]; ok && record.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+
+	r.idempotencyKeys[key] = models.IdempotencyRecord{Key: key, ExpiresAt: expiresAt}
+	return true, nil
+}
+
+func (r *Repository) SelectIdempotencyKey(_ context.Context, key string) (*models.IdempotencyRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.idempotencyKeys[key]
+	if !ok || !record.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+
+	record.Headers = maps.Clone(record.Headers)
+	record.Body = slices.Clone(record.Body)
+	return &record, nil
+}
+
+func (r *Repository) UpdateIdempotencyKey(_ context.Context, record models.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.idempotencyKeys[record.Key]
+	if !ok {
+		return repository.NotFound("idempotency key")
+	}
+
+	stored.RequestHash = record.RequestHash
+	stored.StatusCode = record.StatusCode
+	stored.Headers = maps.Clone(record.Headers)
+	stored.Body = slices.Clone(record.Body)
+	stored.ExpiresAt = record.ExpiresAt
+	r.idempotencyKeys[record.Key] = stored
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotencyKey(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.idempotencyKeys, key)
+	return nil
+}
+
+func (r *Repository) DeleteExpiredIdempotencyKeys(_ context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	now := time.Now()
+	for key, record := range r.idempotencyKeys {
+		if !record.ExpiresAt.After(now) {
+			delete(r.idempotencyKeys, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+// UpsertTeam creates the team unless it exists and reports whether it was created.
+func (r *Repository) UpsertTeam(ctx context.Context, tx models.Tx, teamName string) (bool, error) {
+	var created bool
+	err := r.write(ctx, tx, func(s *state) error {
+		if !s.teamExists(teamName) {
+			s.teams[teamName] = 1
+			created = true
+		}
+		return nil
+	})
+
+	return created, err
+}
+
+// UpsertTeamMember creates or updates the user. It returns the import action
+// and whether the user was active before, so that deactivation can reassign reviews.
+func (r *Repository) UpsertTeamMember(
+	ctx context.Context, tx models.Tx, member models.TeamMember, teamName string,
+) (string, bool, error) {
+	action, wasActive := models.ImportActionUnchanged, member.IsActive
+	err := r.write(ctx, tx, func(s *state) error {
+		updated := user{
+			ID:       member.ID,
+			Username: member.Username,
+			Email:    member.Email,
+			TeamName: teamName,
+			IsActive: member.IsActive,
+		}
+
+		previous, exists := s.users[member.ID]
+		if exists && previous == updated {
+			return nil
+		}
+
+		if ownerID, ok := s.emailOwner(member.Email); ok && ownerID != member.ID {
+			return &repository.ErrConflict{Entity: repository.EntityEmail}
+		}
+
+		if !s.teamExists(teamName) {
+			return wrapDBError(errForeignKey, "UpsertTeamMember")
+		}
+
+		s.users[member.ID] = updated
+		s.bumpTeamVersions(teamName, previous.TeamName)
+		if exists {
+			action, wasActive = models.ImportActionUpdated, previous.IsActive
+		} else {
+			action, wasActive = models.ImportActionCreated, false
+		}
+		return nil
+	})
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return action, wasActive, nil
+}
@@ -0,0 +1,257 @@
+// Package memory implements the service repository in process memory for local runs.
+// Nothing is persisted, the data is lost when the process exits.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+var (
+	errTxClosed   = errors.New("tx is closed")
+	errForeignKey = errors.New("foreign key violation")
+)
+
+type user struct {
+	ID       string
+	Username string
+	Email    string
+	TeamName string
+	IsActive bool
+}
+
+type pullRequest struct {
+	ID        string
+	Name      string
+	AuthorID  string
+	Status    string
+	CreatedAt time.Time
+	MergedAt  *time.Time
+	Version   int64
+}
+
+type reviewer struct {
+	PullRequestID string
+	ReviewerID    string
+	AssignedAt    time.Time
+}
+
+type event struct {
+	ID            int64
+	PullRequestID string
+	Type          string
+	ReviewerID    string
+	OldReviewerID string
+	CreatedAt     time.Time
+}
+
+// state is a version of the data tables. A committed state is never modified,
+// transactions work on their own copy and replace the committed one on commit.
+// Teams map to their version.
+type state struct {
+	teams        map[string]int64
+	users        map[string]user
+	pullRequests map[string]pullRequest
+	reviewers    []reviewer
+	events       []event
+	lastEventID  int64
+}
+
+func newState() *state {
+	return &state{
+		teams:        make(map[string]int64),
+		users:        make(map[string]user),
+		pullRequests: make(map[string]pullRequest),
+	}
+}
+
+func (s *state) clone() *state {
+	return &state{
+		teams:        maps.Clone(s.teams),
+		users:        maps.Clone(s.users),
+		pullRequests: maps.Clone(s.pullRequests),
+		reviewers:    slices.Clone(s.reviewers),
+		events:       slices.Clone(s.events),
+		lastEventID:  s.lastEventID,
+	}
+}
+
+func (s *state) teamExists(teamName string) bool {
+	_, ok := s.teams[teamName]
+	return ok
+}
+
+// bumpTeamVersions increments the version of the teams whose members changed.
+func (s *state) bumpTeamVersions(teamNames ...string) {
+	for _, teamName := range slices.Compact(slices.Sorted(slices.Values(teamNames))) {
+		if version, ok := s.teams[teamName]; ok {
+			s.teams[teamName] = version + 1
+		}
+	}
+}
+
+func (s *state) bumpPullRequestVersion(prID string) {
+	if pr, ok := s.pullRequests[prID]; ok {
+		pr.Version++
+		s.pullRequests[prID] = pr
+	}
+}
+
+func (s *state) emailOwner(email string) (string, bool) {
+	if email == "" {
+		return "", false
+	}
+
+	for _, u := range s.users {
+		if u.Email == email {
+			return u.ID, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *state) reviewerIndex(prID, reviewerID string) int {
+	return slices.IndexFunc(s.reviewers, func(r reviewer) bool {
+		return r.PullRequestID == prID && r.ReviewerID == reviewerID
+	})
+}
+
+// Repository keeps the data in memory. Transactions are serialized and see their own writes,
+// reads outside of a transaction see the last committed state, as with read committed in Postgres.
+type Repository struct {
+	mu   sync.RWMutex
+	data *state
+
+	// API tokens are written outside of transactions, so they are kept apart from the state.
+	tokens      []models.APIToken
+	lastTokenID int64
+
+	// idempotencyKeys are written outside of transactions as well.
+	idempotencyKeys map[string]models.IdempotencyRecord
+
+	// txLock is held by the running transaction.
+	txLock chan struct{}
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		data:            newState(),
+		idempotencyKeys: make(map[string]models.IdempotencyRecord),
+		txLock:          make(chan struct{}, 1),
+	}
+}
+
+func wrapDBError(err error, context string) error {
+	return fmt.Errorf("%w: %s: %w", repository.ErrDB, context, err)
+}
+
+// CloseConnection is a no-op, it lets the repository replace the Postgres one.
+func (r *Repository) CloseConnection() {}
+
+func (r *Repository) committed() *state {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.data
+}
+
+// transaction holds a private copy of the state, nested transactions copy the state of their parent.
+type transaction struct {
+	repo   *Repository
+	parent *transaction
+	data   *state
+	closed bool
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (models.Tx, error) {
+	select {
+	case r.txLock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, wrapDBError(ctx.Err(), "BeginTx")
+	}
+
+	return &transaction{repo: r, data: r.committed().clone()}, nil
+}
+
+func (t *transaction) Begin(_ context.Context) (models.Tx, error) {
+	if t.closed {
+		return nil, wrapDBError(errTxClosed, "Begin: create savepoint")
+	}
+
+	return &transaction{repo: t.repo, parent: t, data: t.data.clone()}, nil
+}
+
+func (t *transaction) Commit(_ context.Context) error {
+	if t.closed {
+		return wrapDBError(errTxClosed, "Commit")
+	}
+	t.closed = true
+
+	if t.parent != nil {
+		t.parent.data = t.data
+		return nil
+	}
+
+	t.repo.mu.Lock()
+	t.repo.data = t.data
+	t.repo.mu.Unlock()
+
+	<-t.repo.txLock
+	return nil
+}
+
+// Rollback of a finished transaction does nothing, so that it can always be deferred.
+func (t *transaction) Rollback(_ context.Context) error {
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+
+	if t.parent == nil {
+		<-t.repo.txLock
+	}
+
+	return nil
+}
+
+// txState returns the state a transaction started by this repository works on.
+func txState(tx models.Tx) *state {
+	return tx.(*transaction).data
+}
+
+// read returns the state seen by a statement run in tx or outside of a transaction when tx is nil.
+func (r *Repository) read(tx models.Tx) *state {
+	if tx == nil {
+		return r.committed()
+	}
+
+	return txState(tx)
+}
+
+// write runs a statement in tx or in its own transaction when tx is nil.
+func (r *Repository) write(ctx context.Context, tx models.Tx, statement func(*state) error) error {
+	if tx != nil {
+		return statement(txState(tx))
+	}
+
+	tx, err := r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = statement(txState(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+)
+
+// SelectPullRequests returns a page of pull requests matching the filter and the cursor of the next page.
+func (r *Repository) SelectPullRequests(
+	_ context.Context, filter models.ListPullRequestsRequest,
+) ([]*models.PullRequestDetails, string, error) {
+	sort := filter.SortBy + ":" + filter.Order
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var afterTime time.Time
+	if after != nil && filter.SortBy != models.SortByName {
+		afterTime, err = time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return nil, "", pagination.ErrInvalidCursor
+		}
+	}
+
+	compare := func(a, b *models.PullRequestDetails) int {
+		switch filter.SortBy {
+		case models.SortByName:
+			return compareKeys(cmp.Compare(a.Name, b.Name), a.ID, b.ID)
+		case models.SortByMergedAt:
+			return compareKeys(a.MergedAt.Compare(*b.MergedAt), a.ID, b.ID)
+		default:
+			return compareKeys(a.CreatedAt.Compare(b.CreatedAt), a.ID, b.ID)
+		}
+	}
+
+	if filter.Order == models.OrderDesc {
+		ascending := compare
+		compare = func(a, b *models.PullRequestDetails) int {
+			return ascending(b, a)
+		}
+	}
+
+	// The keyset row is built from the cursor values, the pull request it was issued for may have changed since.
+	var afterRow *models.PullRequestDetails
+	if after != nil {
+		afterRow = &models.PullRequestDetails{
+			ID:        after.ID,
+			Name:      after.Value,
+			CreatedAt: afterTime,
+			MergedAt:  &afterTime,
+		}
+	}
+
+	s := r.committed()
+
+	pullRequests := make([]*models.PullRequestDetails, 0, filter.Limit)
+	for _, pr := range s.pullRequests {
+		details := pr.details(s)
+		if !matchPullRequest(s, details, filter) {
+			continue
+		}
+
+		if afterRow != nil && compare(details, afterRow) <= 0 {
+			continue
+		}
+
+		pullRequests = append(pullRequests, details)
+	}
+
+	slices.SortFunc(pullRequests, compare)
+
+	var next string
+	if len(pullRequests) > filter.Limit {
+		pullRequests = pullRequests[:filter.Limit]
+		next = pagination.Encode(pagination.PullRequestCursor(pullRequests[len(pullRequests)-1], filter.SortBy, sort))
+	}
+
+	fillReviewerAssignments(s, pullRequests)
+
+	return pullRequests, next, nil
+}
+
+func matchPullRequest(s *state, pr *models.PullRequestDetails, filter models.ListPullRequestsRequest) bool {
+	switch {
+	case filter.Status != "" && pr.Status != filter.Status,
+		filter.AuthorID != "" && pr.AuthorID != filter.AuthorID,
+		filter.TeamName != "" && pr.AuthorTeam != filter.TeamName,
+		filter.ReviewerID != "" && s.reviewerIndex(pr.ID, filter.ReviewerID) < 0,
+		filter.CreatedFrom != nil && pr.CreatedAt.Before(*filter.CreatedFrom),
+		filter.CreatedTo != nil && !pr.CreatedAt.Before(*filter.CreatedTo):
+		return false
+	}
+
+	// Unmerged pull requests have no merge time to sort by or filter on.
+	if pr.MergedAt == nil {
+		return filter.MergedFrom == nil && filter.MergedTo == nil && filter.SortBy != models.SortByMergedAt
+	}
+
+	return (filter.MergedFrom == nil || !pr.MergedAt.Before(*filter.MergedFrom)) &&
+		(filter.MergedTo == nil || pr.MergedAt.Before(*filter.MergedTo))
+}
+
+// fillReviewerAssignments sets the reviewers of the pull requests in the order they were assigned.
+func fillReviewerAssignments(s *state, pullRequests []*models.PullRequestDetails) {
+	byID := make(map[string]*models.PullRequestDetails, len(pullRequests))
+	for _, pr := range pullRequests {
+		pr.AssignedReviewers = make([]string, 0)
+		pr.Reviewers = make([]models.ReviewerAssignment, 0)
+		byID[pr.ID] = pr
+	}
+
+	reviewers := slices.Clone(s.reviewers)
+	slices.SortFunc(reviewers, func(a, b reviewer) int {
+		return compareKeys(a.AssignedAt.Compare(b.AssignedAt), a.ReviewerID, b.ReviewerID)
+	})
+
+	for _, rev := range reviewers {
+		pr, ok := byID[rev.PullRequestID]
+		if !ok {
+			continue
+		}
+
+		u := s.users[rev.ReviewerID]
+		assignment := models.ReviewerAssignment{
+			UserID:      u.ID,
+			Username:    u.Username,
+			IsActive:    u.IsActive,
+			AssignedAt:  rev.AssignedAt,
+			ReviewState: models.ReviewStatePending,
+		}
+		if pr.Status == "MERGED" {
+			assignment.ReviewState = models.ReviewStateCompleted
+		}
+
+		pr.AssignedReviewers = append(pr.AssignedReviewers, assignment.UserID)
+		pr.Reviewers = append(pr.Reviewers, assignment)
+	}
+}
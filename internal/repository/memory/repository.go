@@ -0,0 +1,549 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+)
+
+var errUniqueViolation = errors.New("unique violation")
+
+func (r *Repository) InsertTeam(ctx context.Context, tx models.Tx, team models.AddTeamRequest) error {
+	return r.write(ctx, tx, func(s *state) error {
+		if s.teamExists(team.Name) {
+			return &repository.ErrConflict{Entity: repository.EntityTeam}
+		}
+
+		s.teams[team.Name] = 1
+		return nil
+	})
+}
+
+func (r *Repository) InsertTeamMember(
+	ctx context.Context, tx models.Tx, member models.TeamMember, teamName string,
+) error {
+	return r.write(ctx, tx, func(s *state) error {
+		if _, ok := s.users[member.ID]; ok {
+			return &repository.ErrConflict{Entity: repository.EntityUser}
+		}
+
+		if _, ok := s.emailOwner(member.Email); ok {
+			return &repository.ErrConflict{Entity: repository.EntityUser}
+		}
+
+		if !s.teamExists(teamName) {
+			return repository.ErrNotFound
+		}
+
+		s.users[member.ID] = user{
+			ID:       member.ID,
+			Username: member.Username,
+			Email:    member.Email,
+			TeamName: teamName,
+			IsActive: member.IsActive,
+		}
+		s.bumpTeamVersions(teamName)
+		return nil
+	})
+}
+
+func (r *Repository) SelectTeam(_ context.Context, teamName string) (*models.Team, error) {
+	s := r.committed()
+
+	team := &models.Team{
+		Name:    teamName,
+		Members: make([]models.TeamMember, 0),
+		Version: s.teams[teamName],
+	}
+
+	for _, u := range sortedUsers(s) {
+		if u.TeamName == teamName {
+			team.Members = append(team.Members, models.TeamMember{
+				ID:       u.ID,
+				Username: u.Username,
+				Email:    u.Email,
+				IsActive: u.IsActive,
+			})
+		}
+	}
+
+	if len(team.Members) == 0 {
+		return nil, nil
+	}
+
+	return team, nil
+}
+
+func (r *Repository) UpdateUserStatus(ctx context.Context, tx models.Tx, request models.SetUserStatusRequest) error {
+	return r.write(ctx, tx, func(s *state) error {
+		u, ok := s.users[request.ID]
+		if !ok {
+			return repository.NotFound("user")
+		}
+
+		u.IsActive = request.IsActive
+		s.users[u.ID] = u
+		s.bumpTeamVersions(u.TeamName)
+		return nil
+	})
+}
+
+func (r *Repository) SelectUser(_ context.Context, userID string) (models.User, error) {
+	u, ok := r.committed().users[userID]
+	if !ok {
+		return models.User{}, repository.NotFound("user")
+	}
+
+	return u.model(), nil
+}
+
+func (r *Repository) SelectUserByEmail(_ context.Context, email string) (models.User, error) {
+	s := r.committed()
+
+	id, ok := s.emailOwner(email)
+	if !ok {
+		return models.User{}, repository.NotFound("user")
+	}
+
+	return s.users[id].model(), nil
+}
+
+// SelectUserReviews returns the pull requests the user reviews, most recently assigned first.
+func (r *Repository) SelectUserReviews(
+	_ context.Context, filter models.UserReviewsRequest,
+) ([]*models.PullRequestShort, string, error) {
+	const sort = "assigned_at:desc"
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var afterAssignedAt time.Time
+	if after != nil {
+		afterAssignedAt, err = time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return nil, "", pagination.ErrInvalidCursor
+		}
+	}
+
+	s := r.committed()
+
+	var pullRequests []*models.PullRequestShort
+	for _, rev := range s.reviewers {
+		pr := s.pullRequests[rev.PullRequestID]
+		if rev.ReviewerID != filter.UserID || (filter.Status != "" && pr.Status != filter.Status) {
+			continue
+		}
+
+		if after != nil && compareKeys(rev.AssignedAt.Compare(afterAssignedAt), pr.ID, after.ID) >= 0 {
+			continue
+		}
+
+		assignedAt := rev.AssignedAt
+		short := pr.short()
+		short.AssignedAt = &assignedAt
+		short.ReviewState = models.ReviewStatePending
+		if pr.Status == "MERGED" {
+			short.ReviewState = models.ReviewStateCompleted
+		}
+
+		pullRequests = append(pullRequests, short)
+	}
+
+	slices.SortFunc(pullRequests, func(a, b *models.PullRequestShort) int {
+		return compareKeys(b.AssignedAt.Compare(*a.AssignedAt), b.ID, a.ID)
+	})
+
+	var next string
+	if filter.Limit > 0 && len(pullRequests) > filter.Limit {
+		pullRequests = pullRequests[:filter.Limit]
+		last := pullRequests[len(pullRequests)-1]
+		next = pagination.Encode(pagination.Cursor{
+			Sort:  sort,
+			Value: last.AssignedAt.UTC().Format(time.RFC3339Nano),
+			ID:    last.ID,
+		})
+	}
+
+	return pullRequests, next, nil
+}
+
+func (r *Repository) SelectAuthoredPullRequests(
+	_ context.Context, authorID, status string,
+) ([]*models.PullRequestShort, error) {
+	s := r.committed()
+
+	var pullRequests []*models.PullRequestShort
+	for _, pr := range s.pullRequests {
+		if pr.AuthorID == authorID && pr.Status == status {
+			pullRequests = append(pullRequests, pr.short())
+		}
+	}
+
+	slices.SortFunc(pullRequests, func(a, b *models.PullRequestShort) int {
+		return compareKeys(a.CreatedAt.Compare(b.CreatedAt), a.ID, b.ID)
+	})
+
+	return pullRequests, nil
+}
+
+func (r *Repository) SelectTeamNames(_ context.Context) ([]string, error) {
+	s := r.committed()
+	if len(s.teams) == 0 {
+		return nil, nil
+	}
+
+	return slices.Sorted(maps.Keys(s.teams)), nil
+}
+
+func (r *Repository) DeletePullRequestReviewer(ctx context.Context, tx models.Tx, prID, reviewerID string) error {
+	return r.write(ctx, tx, func(s *state) error {
+		if i := s.reviewerIndex(prID, reviewerID); i >= 0 {
+			s.reviewers = slices.Delete(s.reviewers, i, i+1)
+		}
+		s.bumpPullRequestVersion(prID)
+		return nil
+	})
+}
+
+func (r *Repository) FindAvailableReviewers(_ context.Context, tx models.Tx, author models.User) ([]string, error) {
+	return r.read(tx).availableReviewers(author), nil
+}
+
+// availableReviewers returns up to 10 random active teammates of the author.
+func (s *state) availableReviewers(author models.User) []string {
+	const defaultLimit = 10
+
+	var reviewers []string
+	for _, u := range s.users {
+		if u.TeamName == author.TeamName && u.IsActive && u.ID != author.ID {
+			reviewers = append(reviewers, u.ID)
+		}
+	}
+
+	rand.Shuffle(len(reviewers), func(i, j int) {
+		reviewers[i], reviewers[j] = reviewers[j], reviewers[i]
+	})
+
+	if len(reviewers) > defaultLimit {
+		reviewers = reviewers[:defaultLimit]
+	}
+
+	return reviewers
+}
+
+// InsertPullRequest creates the pull request with its reviewers and events.
+func (r *Repository) InsertPullRequest(
+	ctx context.Context,
+	tx models.Tx,
+	request models.CreatePRRequest,
+	reviewers []string,
+	events []models.PullRequestEvent,
+) (*models.PullRequest, error) {
+	err := r.write(ctx, tx, func(s *state) error {
+		if _, ok := s.pullRequests[request.ID]; ok {
+			return &repository.ErrConflict{Entity: repository.EntityPullRequest}
+		}
+
+		if _, ok := s.users[request.AuthorID]; !ok {
+			return repository.ErrNotFound
+		}
+
+		s.pullRequests[request.ID] = pullRequest{
+			ID:        request.ID,
+			Name:      request.Name,
+			AuthorID:  request.AuthorID,
+			Status:    "OPEN",
+			CreatedAt: time.Now(),
+			Version:   1,
+		}
+
+		for _, reviewerID := range reviewers {
+			if err := s.insertReviewer(request.ID, reviewerID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.InsertPullRequestEvents(ctx, tx, events); err != nil {
+		return nil, err
+	}
+
+	return &models.PullRequest{
+		ID:                request.ID,
+		Name:              request.Name,
+		AuthorID:          request.AuthorID,
+		Status:            "OPEN",
+		AssignedReviewers: reviewers,
+		Version:           1,
+	}, nil
+}
+
+func (r *Repository) SelectPullRequest(
+	_ context.Context, pullRequestID string,
+) (*models.PullRequest, time.Time, error) {
+	s := r.committed()
+
+	pr, ok := s.pullRequests[pullRequestID]
+	if !ok {
+		return nil, time.Time{}, nil
+	}
+
+	result := &models.PullRequest{
+		ID:       pr.ID,
+		Name:     pr.Name,
+		AuthorID: pr.AuthorID,
+		Status:   pr.Status,
+		Version:  pr.Version,
+	}
+
+	for _, rev := range s.reviewers {
+		if rev.PullRequestID == pr.ID {
+			result.AssignedReviewers = append(result.AssignedReviewers, rev.ReviewerID)
+		}
+	}
+
+	if pr.MergedAt == nil {
+		return result, time.Time{}, nil
+	}
+
+	return result, *pr.MergedAt, nil
+}
+
+func (r *Repository) SelectPullRequestDetails(
+	_ context.Context, pullRequestID string,
+) (*models.PullRequestDetails, error) {
+	s := r.committed()
+
+	pr, ok := s.pullRequests[pullRequestID]
+	if !ok {
+		return nil, nil
+	}
+
+	details := pr.details(s)
+	fillReviewerAssignments(s, []*models.PullRequestDetails{details})
+
+	return details, nil
+}
+
+func (r *Repository) UpdatePullRequestStatus(ctx context.Context, tx models.Tx, pullRequestID string) (bool, error) {
+	var updated bool
+	err := r.write(ctx, tx, func(s *state) error {
+		pr, ok := s.pullRequests[pullRequestID]
+		if !ok || pr.Status != "OPEN" {
+			return nil
+		}
+
+		mergedAt := time.Now()
+		pr.Status = "MERGED"
+		pr.MergedAt = &mergedAt
+		pr.Version++
+		s.pullRequests[pr.ID] = pr
+		updated = true
+		return nil
+	})
+
+	return updated, err
+}
+
+func (s *state) insertReviewer(prID, reviewerID string) error {
+	_, prExists := s.pullRequests[prID]
+	_, userExists := s.users[reviewerID]
+	if !prExists || !userExists {
+		return repository.ErrNotFound
+	}
+
+	if s.reviewerIndex(prID, reviewerID) >= 0 {
+		return wrapDBError(errUniqueViolation, "insert reviewer")
+	}
+
+	s.reviewers = append(s.reviewers, reviewer{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		AssignedAt:    time.Now(),
+	})
+	return nil
+}
+
+func (r *Repository) SelectPullRequestReviewers(
+	_ context.Context, tx models.Tx, pullRequestID string,
+) (map[string]bool, error) {
+	return r.read(tx).pullRequestReviewers(pullRequestID), nil
+}
+
+func (s *state) pullRequestReviewers(prID string) map[string]bool {
+	reviewers := make(map[string]bool)
+	for _, rev := range s.reviewers {
+		if rev.PullRequestID == prID {
+			reviewers[rev.ReviewerID] = true
+		}
+	}
+
+	return reviewers
+}
+
+func (r *Repository) ReassignPullRequestReviewer(
+	ctx context.Context, tx models.Tx, prID, oldReviewerID, authorID, teamName string,
+) (string, error) {
+	var newReviewerID string
+	err := r.write(ctx, tx, func(s *state) error {
+		author := models.User{
+			ID:       authorID,
+			TeamName: teamName,
+		}
+		currentReviewers := s.pullRequestReviewers(prID)
+		for _, candidateID := range s.availableReviewers(author) {
+			if candidateID != oldReviewerID && !currentReviewers[candidateID] {
+				newReviewerID = candidateID
+				break
+			}
+		}
+
+		if newReviewerID == "" {
+			return nil
+		}
+
+		if i := s.reviewerIndex(prID, oldReviewerID); i >= 0 {
+			s.reviewers = slices.Delete(s.reviewers, i, i+1)
+		}
+		s.bumpPullRequestVersion(prID)
+
+		return s.insertReviewer(prID, newReviewerID)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return newReviewerID, nil
+}
+
+// LockReviewerAssignment does nothing, writes already wait for the running transaction.
+func (r *Repository) LockReviewerAssignment(_ context.Context, _ models.Tx, _ string) error {
+	return nil
+}
+
+// LockPullRequest returns the pull request with its reviewers, nil when it doesn't exist.
+// Transactions are serialized, so the pull request needs no lock of its own.
+func (r *Repository) LockPullRequest(
+	_ context.Context, tx models.Tx, pullRequestID string,
+) (*models.PullRequest, error) {
+	s := r.read(tx)
+
+	pr, ok := s.pullRequests[pullRequestID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &models.PullRequest{
+		ID:                pr.ID,
+		Name:              pr.Name,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: slices.Sorted(maps.Keys(s.pullRequestReviewers(pr.ID))),
+		Version:           pr.Version,
+	}, nil
+}
+
+// LockUser returns the user as seen by tx, see LockPullRequest.
+func (r *Repository) LockUser(_ context.Context, tx models.Tx, userID string) (models.User, error) {
+	u, ok := r.read(tx).users[userID]
+	if !ok {
+		return models.User{}, repository.NotFound("user")
+	}
+
+	return u.model(), nil
+}
+
+// LockOpenReviews returns the open pull requests the user reviews ordered by id, see LockPullRequest.
+func (r *Repository) LockOpenReviews(
+	_ context.Context, tx models.Tx, reviewerID string,
+) ([]*models.PullRequestShort, error) {
+	s := r.read(tx)
+
+	var pullRequests []*models.PullRequestShort
+	for _, rev := range s.reviewers {
+		if pr := s.pullRequests[rev.PullRequestID]; rev.ReviewerID == reviewerID && pr.Status == "OPEN" {
+			pullRequests = append(pullRequests, pr.short())
+		}
+	}
+
+	slices.SortFunc(pullRequests, func(a, b *models.PullRequestShort) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return pullRequests, nil
+}
+
+// LockTeam returns the version of the team, see LockPullRequest.
+func (r *Repository) LockTeam(_ context.Context, tx models.Tx, teamName string) (int64, error) {
+	version, ok := r.read(tx).teams[teamName]
+	if !ok {
+		return 0, repository.NotFound(repository.EntityTeam)
+	}
+
+	return version, nil
+}
+
+func (u user) model() models.User {
+	return models.User{
+		ID:       u.ID,
+		Username: u.Username,
+		Email:    u.Email,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+	}
+}
+
+func (pr pullRequest) short() *models.PullRequestShort {
+	return &models.PullRequestShort{
+		ID:        pr.ID,
+		Name:      pr.Name,
+		AuthorID:  pr.AuthorID,
+		Status:    pr.Status,
+		CreatedAt: pr.CreatedAt,
+	}
+}
+
+func (pr pullRequest) details(s *state) *models.PullRequestDetails {
+	return &models.PullRequestDetails{
+		ID:         pr.ID,
+		Name:       pr.Name,
+		AuthorID:   pr.AuthorID,
+		AuthorTeam: s.users[pr.AuthorID].TeamName,
+		Status:     pr.Status,
+		CreatedAt:  pr.CreatedAt,
+		MergedAt:   pr.MergedAt,
+		Version:    pr.Version,
+	}
+}
+
+func sortedUsers(s *state) []user {
+	users := slices.Collect(maps.Values(s.users))
+	slices.SortFunc(users, func(a, b user) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return users
+}
+
+// compareKeys orders rows by a sort value and then by id, as the (value, id) keys of the Postgres queries.
+func compareKeys(byValue int, id, otherID string) int {
+	if byValue != 0 {
+		return byValue
+	}
+
+	return cmp.Compare(id, otherID)
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (r *Repository) SelectUserStats(_ context.Context) (*models.UserStatsResponse, error) {
+	s := r.committed()
+	stats := &models.UserStatsResponse{TotalUsers: len(s.users)}
+
+	byTeam := make(map[string]int)
+	for _, u := range s.users {
+		if u.IsActive {
+			stats.ActiveUsers++
+		} else {
+			stats.InactiveUsers++
+		}
+		byTeam[u.TeamName]++
+	}
+
+	for _, teamName := range slices.Sorted(maps.Keys(byTeam)) {
+		stats.UsersByTeam = append(stats.UsersByTeam, models.TeamUsersCount{
+			TeamName: teamName,
+			Users:    byTeam[teamName],
+		})
+	}
+
+	return stats, nil
+}
+
+func (r *Repository) SelectPullRequestStats(_ context.Context) (*models.PullRequestsStatsResponse, error) {
+	s := r.committed()
+	stats := &models.PullRequestsStatsResponse{TotalPRs: len(s.pullRequests)}
+
+	for _, pr := range s.pullRequests {
+		switch pr.Status {
+		case "OPEN":
+			stats.OpenPRs++
+		case "MERGED":
+			stats.MergedPRs++
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *Repository) SelectReviewerStats(_ context.Context) (*models.ReviewersStatsResponse, error) {
+	const defaultLimit = 10
+	s := r.committed()
+
+	reviewCounts := make(map[string]int)
+	for _, rev := range s.reviewers {
+		reviewCounts[rev.ReviewerID]++
+	}
+
+	stats := &models.ReviewersStatsResponse{}
+	var reviewers []models.Reviewers
+	for _, u := range sortedUsers(s) {
+		if !u.IsActive {
+			continue
+		}
+
+		if reviewCounts[u.ID] == 0 {
+			stats.UsersWithoutReview = append(stats.UsersWithoutReview, u.ID)
+		}
+
+		reviewers = append(reviewers, models.Reviewers{ID: u.ID, Username: u.Username, ReviewCount: reviewCounts[u.ID]})
+	}
+
+	slices.SortStableFunc(reviewers, func(a, b models.Reviewers) int {
+		return cmp.Compare(b.ReviewCount, a.ReviewCount)
+	})
+
+	if len(reviewers) > defaultLimit {
+		reviewers = reviewers[:defaultLimit]
+	}
+
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewCount > 0 {
+			stats.TopReviewers = append(stats.TopReviewers, reviewer)
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *Repository) SelectOpenReviewsByTeam(_ context.Context) ([]models.TeamOpenReviews, error) {
+	s := r.committed()
+
+	byTeam := make(map[string]int, len(s.teams))
+	for teamName := range s.teams {
+		byTeam[teamName] = 0
+	}
+
+	for _, rev := range s.reviewers {
+		pr, ok := s.pullRequests[rev.PullRequestID]
+		if !ok || pr.Status != "OPEN" {
+			continue
+		}
+
+		if u, ok := s.users[rev.ReviewerID]; ok {
+			byTeam[u.TeamName]++
+		}
+	}
+
+	teams := make([]models.TeamOpenReviews, 0, len(byTeam))
+	for _, teamName := range slices.Sorted(maps.Keys(byTeam)) {
+		teams = append(teams, models.TeamOpenReviews{TeamName: teamName, OpenReviews: byTeam[teamName]})
+	}
+
+	return teams, nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+func (r *Repository) InsertAPIToken(_ context.Context, token models.APIToken) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token.UserID != "" {
+		if _, ok := r.data.users[token.UserID]; !ok {
+			return 0, repository.NotFound("user")
+		}
+	}
+
+	for _, existing := range r.tokens {
+		if existing.Hash == token.Hash {
+			return existing.ID, nil
+		}
+	}
+
+	r.lastTokenID++
+	token.ID = r.lastTokenID
+	token.TeamName = ""
+	token.RevokedAt = nil
+	r.tokens = append(r.tokens, token)
+
+	return token.ID, nil
+}
+
+func (r *Repository) SelectAPIToken(_ context.Context, hash string) (*models.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.Hash == hash {
+			user, ok := r.data.users[token.UserID]
+			token.TeamName = user.TeamName
+			token.UserIsActive = token.UserID == "" || ok && user.IsActive
+			return &token, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Repository) RevokeAPIToken(_ context.Context, tokenID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, token := range r.tokens {
+		if token.ID == tokenID && token.RevokedAt == nil {
+			revokedAt := time.Now()
+			r.tokens[i].RevokedAt = &revokedAt
+			return nil
+		}
+	}
+
+	return repository.NotFound("token")
+}
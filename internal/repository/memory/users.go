@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+)
+
+func (s *state) userDetails(u user) *models.UserDetails {
+	details := &models.UserDetails{User: u.model()}
+
+	for _, rev := range s.reviewers {
+		if rev.ReviewerID == u.ID && s.pullRequests[rev.PullRequestID].Status == "OPEN" {
+			details.OpenReviews++
+		}
+	}
+
+	for _, pr := range s.pullRequests {
+		if pr.AuthorID == u.ID && pr.Status == "OPEN" {
+			details.OpenPullRequests++
+		}
+	}
+
+	return details
+}
+
+func (r *Repository) SelectUserDetails(_ context.Context, userID string) (*models.UserDetails, error) {
+	s := r.committed()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, repository.NotFound("user")
+	}
+
+	return s.userDetails(u), nil
+}
+
+// SelectUsers returns a page of users ordered by id and the cursor of the next page.
+func (r *Repository) SelectUsers(
+	_ context.Context, filter models.ListUsersRequest,
+) ([]*models.UserDetails, string, error) {
+	const sort = "user_id:asc"
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s := r.committed()
+	prefix := strings.ToLower(filter.NamePrefix)
+
+	users := make([]*models.UserDetails, 0, filter.Limit)
+	for _, u := range sortedUsers(s) {
+		switch {
+		case filter.TeamName != "" && u.TeamName != filter.TeamName,
+			filter.IsActive != nil && u.IsActive != *filter.IsActive,
+			!strings.HasPrefix(strings.ToLower(u.Username), prefix),
+			after != nil && u.ID <= after.ID:
+			continue
+		}
+
+		users = append(users, s.userDetails(u))
+		if len(users) > filter.Limit {
+			break
+		}
+	}
+
+	var next string
+	if len(users) > filter.Limit {
+		users = users[:filter.Limit]
+		next = pagination.Encode(pagination.Cursor{Sort: sort, ID: users[len(users)-1].ID})
+	}
+
+	return users, next, nil
+}
@@ -0,0 +1,60 @@
+// Package pagination implements the keyset pagination cursors shared by the storage backends.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points right after the last row of a page in keyset pagination.
+// Sort binds it to the ordering it was issued for.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func Encode(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func Decode(value, sort string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err = json.Unmarshal(data, &c); err != nil || c.Sort != sort || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// PullRequestCursor returns the cursor pointing right after the pull request in the sortBy ordering.
+func PullRequestCursor(pr *models.PullRequestDetails, sortBy, sort string) Cursor {
+	c := Cursor{Sort: sort, ID: pr.ID}
+
+	switch sortBy {
+	case models.SortByName:
+		c.Value = pr.Name
+	case models.SortByMergedAt:
+		c.Value = pr.MergedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		c.Value = pr.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return c
+}
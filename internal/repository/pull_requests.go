@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+)
+
+// SelectPullRequests returns a page of pull requests matching the filter and the cursor of the next page.
+func (r *Repository) SelectPullRequests(
+	ctx context.Context, filter models.ListPullRequestsRequest,
+) ([]*models.PullRequestDetails, string, error) {
+	sortColumn := map[string]string{
+		models.SortByCreatedAt: "pr.created_at",
+		models.SortByMergedAt:  "pr.merged_at",
+		models.SortByName:      "pr.pr_name",
+	}[filter.SortBy]
+
+	sort := filter.SortBy + ":" + filter.Order
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "u.team_name", "pr.pr_status", "pr.created_at", "pr.merged_at").
+		From("pull_requests pr").
+		Join("users u ON u.id = pr.author_id").
+		OrderBy(sortColumn+" "+filter.Order, "pr.id "+filter.Order).
+		Limit(uint64(filter.Limit) + 1)
+
+	builder = applyPullRequestFilter(builder, filter)
+
+	if after != nil {
+		operator := ">"
+		if filter.Order == models.OrderDesc {
+			operator = "<"
+		}
+
+		value := any(after.Value)
+		if filter.SortBy != models.SortByName {
+			timestamp, err := time.Parse(time.RFC3339Nano, after.Value)
+			if err != nil {
+				return nil, "", pagination.ErrInvalidCursor
+			}
+			value = timestamp
+		}
+
+		keyset := fmt.Sprintf("(%s, pr.id) %s (?, ?)", sortColumn, operator)
+		builder = builder.Where(keyset, value, after.ID)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectPullRequests: build query")
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectPullRequests: execute query")
+	}
+	defer rows.Close()
+
+	pullRequests := make([]*models.PullRequestDetails, 0, filter.Limit)
+	for rows.Next() {
+		var pr models.PullRequestDetails
+
+		err = rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.AuthorTeam, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
+		if err != nil {
+			return nil, "", wrapDBError(err, "SelectPullRequests: scan pull request")
+		}
+
+		pullRequests = append(pullRequests, &pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", wrapDBError(err, "SelectPullRequests: iterate pull requests")
+	}
+
+	var next string
+	if len(pullRequests) > filter.Limit {
+		pullRequests = pullRequests[:filter.Limit]
+		next = pagination.Encode(pagination.PullRequestCursor(pullRequests[len(pullRequests)-1], filter.SortBy, sort))
+	}
+
+	if err = r.fillReviewerAssignments(ctx, pullRequests); err != nil {
+		return nil, "", err
+	}
+
+	return pullRequests, next, nil
+}
+
+func applyPullRequestFilter(
+	builder squirrel.SelectBuilder, filter models.ListPullRequestsRequest,
+) squirrel.SelectBuilder {
+	if filter.Status != "" {
+		builder = builder.Where(squirrel.Eq{"pr.pr_status": filter.Status})
+	}
+
+	if filter.AuthorID != "" {
+		builder = builder.Where(squirrel.Eq{"pr.author_id": filter.AuthorID})
+	}
+
+	if filter.TeamName != "" {
+		builder = builder.Where(squirrel.Eq{"u.team_name": filter.TeamName})
+	}
+
+	if filter.ReviewerID != "" {
+		builder = builder.Where(
+			"EXISTS (SELECT 1 FROM pr_reviewers rev WHERE rev.pr_id = pr.id AND rev.reviewer_id = ?)",
+			filter.ReviewerID,
+		)
+	}
+
+	if filter.CreatedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"pr.created_at": *filter.CreatedFrom})
+	}
+
+	if filter.CreatedTo != nil {
+		builder = builder.Where(squirrel.Lt{"pr.created_at": *filter.CreatedTo})
+	}
+
+	if filter.MergedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"pr.merged_at": *filter.MergedFrom})
+	}
+
+	if filter.MergedTo != nil {
+		builder = builder.Where(squirrel.Lt{"pr.merged_at": *filter.MergedTo})
+	}
+
+	// Unmerged pull requests have no merge time to sort by.
+	if filter.SortBy == models.SortByMergedAt {
+		builder = builder.Where(squirrel.NotEq{"pr.merged_at": nil})
+	}
+
+	return builder
+}
+
+// fillReviewerAssignments loads the reviewers of the pull requests with a single query.
+func (r *Repository) fillReviewerAssignments(ctx context.Context, pullRequests []*models.PullRequestDetails) error {
+	if len(pullRequests) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.PullRequestDetails, len(pullRequests))
+	ids := make([]string, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		pr.AssignedReviewers = make([]string, 0)
+		pr.Reviewers = make([]models.ReviewerAssignment, 0)
+		byID[pr.ID] = pr
+		ids = append(ids, pr.ID)
+	}
+
+	query, args, err := r.builder.
+		Select("rev.pr_id", "rev.reviewer_id", "u.user_name", "u.is_active", "rev.assigned_at").
+		From("pr_reviewers rev").
+		Join("users u ON u.id = rev.reviewer_id").
+		Where(squirrel.Eq{"rev.pr_id": ids}).
+		OrderBy("rev.assigned_at", "rev.reviewer_id").
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "fillReviewerAssignments: build query")
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "fillReviewerAssignments: execute query")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID string
+		var reviewer models.ReviewerAssignment
+
+		err = rows.Scan(&prID, &reviewer.UserID, &reviewer.Username, &reviewer.IsActive, &reviewer.AssignedAt)
+		if err != nil {
+			return wrapDBError(err, "fillReviewerAssignments: scan reviewer")
+		}
+
+		pr := byID[prID]
+		reviewer.ReviewState = models.ReviewStatePending
+		if pr.Status == "MERGED" {
+			reviewer.ReviewState = models.ReviewStateCompleted
+		}
+
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewer.UserID)
+		pr.Reviewers = append(pr.Reviewers, reviewer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return wrapDBError(err, "fillReviewerAssignments: iterate reviewers")
+	}
+
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"slices"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -12,6 +14,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
 )
 
 type PostgresCfg struct {
@@ -49,23 +52,50 @@ func NewRepository(cfg PostgresCfg) (*Repository, error) {
 }
 
 func wrapDBError(err error, context string) error {
-	return fmt.Errorf("database: %s: %w", context, err)
+	return fmt.Errorf("%w: %s: %w", ErrDB, context, err)
 }
 
 func (r *Repository) CloseConnection() {
 	r.pool.Close()
 }
 
-func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+func (r *Repository) PoolStat() *pgxpool.Stat {
+	return r.pool.Stat()
+}
+
+// transaction adapts pgx.Tx to models.Tx, nested transactions are savepoints.
+type transaction struct {
+	pgx.Tx
+}
+
+func (t transaction) Begin(ctx context.Context) (models.Tx, error) {
+	tx, err := t.Tx.Begin(ctx)
+	if err != nil {
+		return nil, wrapDBError(err, "Begin: create savepoint")
+	}
+
+	return transaction{tx}, nil
+}
+
+// pgxTx unwraps a transaction started by this repository.
+func pgxTx(tx models.Tx) pgx.Tx {
+	if tx == nil {
+		return nil
+	}
+
+	return tx.(transaction).Tx
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (models.Tx, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return nil, wrapDBError(err, "BeginTx")
 	}
 
-	return tx, nil
+	return transaction{tx}, nil
 }
 
-func (r *Repository) InsertTeam(ctx context.Context, tx pgx.Tx, team models.AddTeamRequest) error {
+func (r *Repository) InsertTeam(ctx context.Context, tx models.Tx, team models.AddTeamRequest) error {
 	query, args, err := r.builder.
 		Insert("teams").
 		Columns("team_name").
@@ -76,11 +106,11 @@ func (r *Repository) InsertTeam(ctx context.Context, tx pgx.Tx, team models.AddT
 		return wrapDBError(err, "InsertTeam: build query")
 	}
 
-	_, err = tx.Exec(ctx, query, args...)
+	_, err = pgxTx(tx).Exec(ctx, query, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return errors.New("team unique violation")
+			return &ErrConflict{Entity: EntityTeam}
 		}
 		return wrapDBError(err, "InsertTeam: execute query")
 	}
@@ -88,39 +118,42 @@ func (r *Repository) InsertTeam(ctx context.Context, tx pgx.Tx, team models.AddT
 	return nil
 }
 
-func (r *Repository) InsertTeamMember(ctx context.Context, tx pgx.Tx, member models.TeamMember, teamName string) error {
+func (r *Repository) InsertTeamMember(
+	ctx context.Context, tx models.Tx, member models.TeamMember, teamName string,
+) error {
 	query, args, err := r.builder.
 		Insert("users").
-		Columns("id", "user_name", "is_active", "team_name").
-		Values(member.ID, member.Username, member.IsActive, teamName).
+		Columns("id", "user_name", "email", "is_active", "team_name").
+		Values(member.ID, member.Username, nullableString(member.Email), member.IsActive, teamName).
 		ToSql()
 
 	if err != nil {
 		return wrapDBError(err, "InsertTeamMember: build query")
 	}
 
-	_, err = tx.Exec(ctx, query, args...)
+	_, err = pgxTx(tx).Exec(ctx, query, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
 			case "23505":
-				return errors.New("user unique violation")
+				return &ErrConflict{Entity: EntityUser}
 			case "23503":
-				return errors.New("not found")
+				return ErrNotFound
 			}
 		}
 		return wrapDBError(err, "InsertTeamMember: execute query")
 	}
 
-	return nil
+	return r.bumpTeamVersions(ctx, tx, squirrel.Eq{"team_name": teamName})
 }
 
 func (r *Repository) SelectTeam(ctx context.Context, teamName string) (*models.Team, error) {
 	query, args, err := r.builder.
-		Select("id", "user_name", "is_active").
-		From("users").
-		Where(squirrel.Eq{"team_name": teamName}).
+		Select("u.id", "u.user_name", "COALESCE(u.email, '')", "u.is_active", "t.version").
+		From("users u").
+		Join("teams t ON t.team_name = u.team_name").
+		Where(squirrel.Eq{"u.team_name": teamName}).
 		ToSql()
 
 	if err != nil {
@@ -140,7 +173,7 @@ func (r *Repository) SelectTeam(ctx context.Context, teamName string) (*models.T
 
 	for rows.Next() {
 		var member models.TeamMember
-		err = rows.Scan(&member.ID, &member.Username, &member.IsActive)
+		err = rows.Scan(&member.ID, &member.Username, &member.Email, &member.IsActive, &team.Version)
 		if err != nil {
 			return nil, wrapDBError(err, "SelectTeam: scan")
 		}
@@ -155,7 +188,7 @@ func (r *Repository) SelectTeam(ctx context.Context, teamName string) (*models.T
 	return team, nil
 }
 
-func (r *Repository) UpdateUserStatus(ctx context.Context, tx pgx.Tx, user models.SetUserStatusRequest) error {
+func (r *Repository) UpdateUserStatus(ctx context.Context, tx models.Tx, user models.SetUserStatusRequest) error {
 	query, args, err := r.builder.
 		Update("users").
 		Set("is_active", user.IsActive).
@@ -166,21 +199,21 @@ func (r *Repository) UpdateUserStatus(ctx context.Context, tx pgx.Tx, user model
 		return wrapDBError(err, "UpdateUserStatus: build query")
 	}
 
-	result, err := tx.Exec(ctx, query, args...)
+	result, err := pgxTx(tx).Exec(ctx, query, args...)
 	if err != nil {
 		return wrapDBError(err, "UpdateUserStatus: execute query")
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("user not found")
+		return NotFound("user")
 	}
 
-	return nil
+	return r.bumpTeamVersions(ctx, tx, squirrel.Expr("team_name = (SELECT team_name FROM users WHERE id = ?)", user.ID))
 }
 
 func (r *Repository) SelectUser(ctx context.Context, userID string) (models.User, error) {
 	query, args, err := r.builder.
-		Select("id", "user_name", "team_name", "is_active").
+		Select("id", "user_name", "COALESCE(email, '')", "team_name", "is_active").
 		From("users").
 		Where(squirrel.Eq{"id": userID}).
 		ToSql()
@@ -190,10 +223,11 @@ func (r *Repository) SelectUser(ctx context.Context, userID string) (models.User
 	}
 
 	var user models.User
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, NotFound("user")
 		}
 		return models.User{}, wrapDBError(err, "SelectUser: query row")
 	}
@@ -201,21 +235,132 @@ func (r *Repository) SelectUser(ctx context.Context, userID string) (models.User
 	return user, nil
 }
 
-func (r *Repository) SelectUserReviews(ctx context.Context, userID string) ([]*models.PullRequestShort, error) {
+func (r *Repository) SelectUserByEmail(ctx context.Context, email string) (models.User, error) {
 	query, args, err := r.builder.
-		Select("pr.id", "pr.pr_name", "pr.author_id", "pr.pr_status").
+		Select("id", "user_name", "COALESCE(email, '')", "team_name", "is_active").
+		From("users").
+		Where(squirrel.Eq{"email": email}).
+		ToSql()
+
+	if err != nil {
+		return models.User{}, wrapDBError(err, "SelectUserByEmail: build query")
+	}
+
+	var user models.User
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, NotFound("user")
+		}
+		return models.User{}, wrapDBError(err, "SelectUserByEmail: query row")
+	}
+
+	return user, nil
+}
+
+// SelectUserReviews returns the pull requests the user reviews, most recently assigned first.
+func (r *Repository) SelectUserReviews(
+	ctx context.Context, filter models.UserReviewsRequest,
+) ([]*models.PullRequestShort, string, error) {
+	const sort = "assigned_at:desc"
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "pr.pr_status", "pr.created_at", "prr.assigned_at").
 		From("pull_requests pr").
 		Join("pr_reviewers prr ON pr.id = prr.pr_id").
-		Where(squirrel.Eq{"prr.reviewer_id": userID}).
+		Where(squirrel.Eq{"prr.reviewer_id": filter.UserID}).
+		OrderBy("prr.assigned_at DESC", "pr.id DESC")
+
+	if filter.Status != "" {
+		builder = builder.Where(squirrel.Eq{"pr.pr_status": filter.Status})
+	}
+
+	if after != nil {
+		assignedAt, err := time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return nil, "", pagination.ErrInvalidCursor
+		}
+		builder = builder.Where("(prr.assigned_at, pr.id) < (?, ?)", assignedAt, after.ID)
+	}
+
+	if filter.Limit > 0 {
+		builder = builder.Limit(uint64(filter.Limit) + 1)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectUserReviews: build query")
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectUserReviews: execute query")
+	}
+	defer rows.Close()
+
+	var pullRequests []*models.PullRequestShort
+	for rows.Next() {
+		var pullRequest models.PullRequestShort
+		var assignedAt time.Time
+
+		err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID,
+			&pullRequest.Status, &pullRequest.CreatedAt, &assignedAt)
+		if err != nil {
+			return nil, "", wrapDBError(err, "SelectUserReviews: scan row")
+		}
+
+		pullRequest.AssignedAt = &assignedAt
+		pullRequest.ReviewState = models.ReviewStatePending
+		if pullRequest.Status == "MERGED" {
+			pullRequest.ReviewState = models.ReviewStateCompleted
+		}
+
+		pullRequests = append(pullRequests, &pullRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", wrapDBError(err, "SelectUserReviews: iterate rows")
+	}
+
+	var next string
+	if filter.Limit > 0 && len(pullRequests) > filter.Limit {
+		pullRequests = pullRequests[:filter.Limit]
+		last := pullRequests[len(pullRequests)-1]
+		next = pagination.Encode(pagination.Cursor{
+			Sort:  sort,
+			Value: last.AssignedAt.UTC().Format(time.RFC3339Nano),
+			ID:    last.ID,
+		})
+	}
+
+	return pullRequests, next, nil
+}
+
+func (r *Repository) SelectAuthoredPullRequests(
+	ctx context.Context, authorID, status string,
+) ([]*models.PullRequestShort, error) {
+	query, args, err := r.builder.
+		Select("id", "pr_name", "author_id", "pr_status", "COALESCE(created_at, NOW())").
+		From("pull_requests").
+		Where(squirrel.Eq{
+			"author_id": authorID,
+			"pr_status": status,
+		}).
+		OrderBy("created_at").
 		ToSql()
 
 	if err != nil {
-		return nil, wrapDBError(err, "SelectUserReviews: build query")
+		return nil, wrapDBError(err, "SelectAuthoredPullRequests: build query")
 	}
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, wrapDBError(err, "SelectUserReviews: execute query")
+		return nil, wrapDBError(err, "SelectAuthoredPullRequests: execute query")
 	}
 	defer rows.Close()
 
@@ -223,8 +368,10 @@ func (r *Repository) SelectUserReviews(ctx context.Context, userID string) ([]*m
 	for rows.Next() {
 		var pullRequest models.PullRequestShort
 
-		if err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID, &pullRequest.Status); err != nil {
-			return nil, wrapDBError(err, "SelectUserReviews: scan row")
+		err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID,
+			&pullRequest.Status, &pullRequest.CreatedAt)
+		if err != nil {
+			return nil, wrapDBError(err, "SelectAuthoredPullRequests: scan row")
 		}
 
 		pullRequests = append(pullRequests, &pullRequest)
@@ -233,7 +380,37 @@ func (r *Repository) SelectUserReviews(ctx context.Context, userID string) ([]*m
 	return pullRequests, nil
 }
 
-func (r *Repository) DeletePullRequestReviewer(ctx context.Context, tx pgx.Tx, prID, reviewerID string) error {
+func (r *Repository) SelectTeamNames(ctx context.Context) ([]string, error) {
+	query, args, err := r.builder.
+		Select("team_name").
+		From("teams").
+		OrderBy("team_name").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeamNames: build query")
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeamNames: execute query")
+	}
+	defer rows.Close()
+
+	var teams []string
+	for rows.Next() {
+		var teamName string
+		if err = rows.Scan(&teamName); err != nil {
+			return nil, wrapDBError(err, "SelectTeamNames: scan row")
+		}
+
+		teams = append(teams, teamName)
+	}
+
+	return teams, nil
+}
+
+func (r *Repository) DeletePullRequestReviewer(ctx context.Context, tx models.Tx, prID, reviewerID string) error {
 	query, args, err := r.builder.Delete("pr_reviewers").
 		Where(squirrel.Eq{
 			"pr_id":       prID,
@@ -246,7 +423,7 @@ func (r *Repository) DeletePullRequestReviewer(ctx context.Context, tx pgx.Tx, p
 	}
 
 	if tx != nil {
-		_, err = tx.Exec(ctx, query, args...)
+		_, err = pgxTx(tx).Exec(ctx, query, args...)
 	} else {
 		_, err = r.pool.Exec(ctx, query, args...)
 	}
@@ -255,10 +432,10 @@ func (r *Repository) DeletePullRequestReviewer(ctx context.Context, tx pgx.Tx, p
 		return wrapDBError(err, "DeletePullRequestReviewer: execute query")
 	}
 
-	return nil
+	return r.bumpPullRequestVersion(ctx, tx, prID)
 }
 
-func (r *Repository) FindAvailableReviewers(ctx context.Context, tx pgx.Tx, user models.User) ([]string, error) {
+func (r *Repository) FindAvailableReviewers(ctx context.Context, tx models.Tx, user models.User) ([]string, error) {
 	const defaultLimit = 10
 	query, args, err := r.builder.
 		Select("id").
@@ -278,7 +455,7 @@ func (r *Repository) FindAvailableReviewers(ctx context.Context, tx pgx.Tx, user
 
 	var rows pgx.Rows
 	if tx != nil {
-		rows, err = tx.Query(ctx, query, args...)
+		rows, err = pgxTx(tx).Query(ctx, query, args...)
 	} else {
 		rows, err = r.pool.Query(ctx, query, args...)
 	}
@@ -300,37 +477,92 @@ func (r *Repository) FindAvailableReviewers(ctx context.Context, tx pgx.Tx, user
 	return reviewers, nil
 }
 
-func (r *Repository) InsertPullRequest(ctx context.Context, tx pgx.Tx, pullRequest models.CreatePRRequest) error {
-	query, args, err := r.builder.
-		Insert("pull_requests").
-		Columns("id", "pr_name", "author_id", "pr_status").
-		Values(pullRequest.ID, pullRequest.Name, pullRequest.AuthorID, "OPEN").
-		ToSql()
-
+// InsertPullRequest creates the pull request with its reviewers and events, the statements are sent
+// in one batch so the pull request is created in a single round-trip.
+func (r *Repository) InsertPullRequest(
+	ctx context.Context,
+	tx models.Tx,
+	pullRequest models.CreatePRRequest,
+	reviewers []string,
+	events []models.PullRequestEvent,
+) (*models.PullRequest, error) {
+	batch, pr, err := r.insertPullRequestBatch(pullRequest, reviewers, events)
 	if err != nil {
-		return wrapDBError(err, "InsertPullRequest: build query")
+		return nil, err
 	}
 
-	_, err = tx.Exec(ctx, query, args...)
+	err = pgxTx(tx).SendBatch(ctx, batch).Close()
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
 			case "23505":
-				return errors.New("pr unique violation")
+				return nil, &ErrConflict{Entity: EntityPullRequest}
 			case "23503":
-				return errors.New("not found")
+				return nil, ErrNotFound
 			}
 		}
-		return wrapDBError(err, "InsertPullRequest: execute query")
+		return nil, wrapDBError(err, "InsertPullRequest: execute batch")
 	}
 
-	return nil
+	return pr, nil
+}
+
+// insertPullRequestBatch queues the statements of InsertPullRequest, the returned pull request
+// is filled when the batch is closed.
+func (r *Repository) insertPullRequestBatch(
+	pullRequest models.CreatePRRequest, reviewers []string, events []models.PullRequestEvent,
+) (*pgx.Batch, *models.PullRequest, error) {
+	batch := &pgx.Batch{}
+	pr := &models.PullRequest{AssignedReviewers: reviewers}
+
+	query, args, err := r.builder.
+		Insert("pull_requests").
+		Columns("id", "pr_name", "author_id", "pr_status").
+		Values(pullRequest.ID, pullRequest.Name, pullRequest.AuthorID, "OPEN").
+		Suffix("RETURNING id, pr_name, author_id, pr_status, version").
+		ToSql()
+
+	if err != nil {
+		return nil, nil, wrapDBError(err, "InsertPullRequest: build query")
+	}
+
+	batch.Queue(query, args...).QueryRow(func(row pgx.Row) error {
+		return row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version)
+	})
+
+	if len(reviewers) != 0 {
+		builder := r.builder.
+			Insert("pr_reviewers").
+			Columns("pr_id", "reviewer_id")
+
+		for _, reviewerID := range reviewers {
+			builder = builder.Values(pullRequest.ID, reviewerID)
+		}
+
+		query, args, err = builder.ToSql()
+		if err != nil {
+			return nil, nil, wrapDBError(err, "InsertPullRequest: build reviewers query")
+		}
+
+		batch.Queue(query, args...)
+	}
+
+	if len(events) != 0 {
+		query, args, err = r.insertEventsQuery(events)
+		if err != nil {
+			return nil, nil, wrapDBError(err, "InsertPullRequest: build events query")
+		}
+
+		batch.Queue(query, args...)
+	}
+
+	return batch, pr, nil
 }
 
 func (r *Repository) SelectPullRequest(ctx context.Context, pullRequestID string) (*models.PullRequest, time.Time, error) {
 	prQuery, prArgs, err := r.builder.
-		Select("id", "pr_name", "author_id", "pr_status", "merged_at").
+		Select("id", "pr_name", "author_id", "pr_status", "merged_at", "version").
 		From("pull_requests").
 		Where(squirrel.Eq{"id": pullRequestID}).
 		ToSql()
@@ -340,8 +572,9 @@ func (r *Repository) SelectPullRequest(ctx context.Context, pullRequestID string
 	}
 
 	var pr models.PullRequest
-	var mergedAt time.Time
-	err = r.pool.QueryRow(ctx, prQuery, prArgs...).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &mergedAt)
+	var mergedAt *time.Time
+	err = r.pool.QueryRow(ctx, prQuery, prArgs...).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &mergedAt, &pr.Version)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, time.Time{}, nil
 	}
@@ -379,57 +612,71 @@ func (r *Repository) SelectPullRequest(ctx context.Context, pullRequestID string
 	}
 	pr.AssignedReviewers = reviewers
 
-	return &pr, mergedAt, nil
+	if mergedAt == nil {
+		return &pr, time.Time{}, nil
+	}
+
+	return &pr, *mergedAt, nil
 }
 
-func (r *Repository) UpdatePullRequestStatus(ctx context.Context, pullRequestID string) error {
-	query, args, err := r.builder.
-		Update("pull_requests").
-		Set("pr_status", "MERGED").
-		Set("merged_at", "NOW()").
-		Where(squirrel.Eq{"id": pullRequestID}).
-		Where(squirrel.Eq{"pr_status": "OPEN"}).
-		Where(squirrel.NotEq{"pr_status": "MERGED"}).
+func (r *Repository) SelectPullRequestDetails(
+	ctx context.Context, pullRequestID string,
+) (*models.PullRequestDetails, error) {
+	prQuery, prArgs, err := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "u.team_name", "pr.pr_status", "pr.created_at", "pr.merged_at",
+			"pr.version").
+		From("pull_requests pr").
+		Join("users u ON u.id = pr.author_id").
+		Where(squirrel.Eq{"pr.id": pullRequestID}).
 		ToSql()
 
 	if err != nil {
-		return wrapDBError(err, "UpdatePullRequestStatus: build query")
+		return nil, wrapDBError(err, "SelectPullRequestDetails: build query")
+	}
+
+	var pr models.PullRequestDetails
+	err = r.pool.QueryRow(ctx, prQuery, prArgs...).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.AuthorTeam, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
 	if err != nil {
-		return wrapDBError(err, "UpdatePullRequestStatus: execute query")
+		return nil, wrapDBError(err, "SelectPullRequestDetails: query row")
 	}
 
-	return nil
+	if err = r.fillReviewerAssignments(ctx, []*models.PullRequestDetails{&pr}); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
 }
 
-func (r *Repository) AssignPullRequestReviewers(ctx context.Context, tx pgx.Tx, pullRequestID string, reviewers []string) error {
-	for _, reviewerID := range reviewers {
-		query, args, err := r.builder.
-			Insert("pr_reviewers").
-			Columns("pr_id", "reviewer_id").
-			Values(pullRequestID, reviewerID).
-			ToSql()
+func (r *Repository) UpdatePullRequestStatus(ctx context.Context, tx models.Tx, pullRequestID string) (bool, error) {
+	query, args, err := r.builder.
+		Update("pull_requests").
+		Set("pr_status", "MERGED").
+		Set("merged_at", "NOW()").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": pullRequestID}).
+		Where(squirrel.Eq{"pr_status": "OPEN"}).
+		ToSql()
 
-		if err != nil {
-			return wrapDBError(err, "AssignPullRequestReviewers: build query")
-		}
+	if err != nil {
+		return false, wrapDBError(err, "UpdatePullRequestStatus: build query")
+	}
 
-		_, err = tx.Exec(ctx, query, args...)
-		if err != nil {
-			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
-				return errors.New("not found")
-			}
-			return wrapDBError(err, "AssignPullRequestReviewers: execute query")
-		}
+	result, err := pgxTx(tx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, wrapDBError(err, "UpdatePullRequestStatus: execute query")
 	}
 
-	return nil
+	return result.RowsAffected() > 0, nil
 }
 
-func (r *Repository) SelectPullRequestReviewers(ctx context.Context, tx pgx.Tx, pullRequestID string) (map[string]bool, error) {
+func (r *Repository) SelectPullRequestReviewers(
+	ctx context.Context, tx models.Tx, pullRequestID string,
+) (map[string]bool, error) {
 	query, args, err := r.builder.Select("reviewer_id").
 		From("pr_reviewers").
 		Where(squirrel.Eq{"pr_id": pullRequestID}).
@@ -441,7 +688,7 @@ func (r *Repository) SelectPullRequestReviewers(ctx context.Context, tx pgx.Tx,
 
 	var rows pgx.Rows
 	if tx != nil {
-		rows, err = tx.Query(ctx, query, args...)
+		rows, err = pgxTx(tx).Query(ctx, query, args...)
 	} else {
 		rows, err = r.pool.Query(ctx, query, args...)
 	}
@@ -466,7 +713,7 @@ func (r *Repository) SelectPullRequestReviewers(ctx context.Context, tx pgx.Tx,
 }
 
 func (r *Repository) ReassignPullRequestReviewer(
-	ctx context.Context, tx pgx.Tx, prID, oldReviewerID, authorID, teamName string,
+	ctx context.Context, tx models.Tx, prID, oldReviewerID, authorID, teamName string,
 ) (string, error) {
 	user := models.User{
 		ID:       authorID,
@@ -507,7 +754,7 @@ func (r *Repository) ReassignPullRequestReviewer(
 	}
 
 	if tx != nil {
-		_, err = tx.Exec(ctx, deleteQuery, deleteArgs...)
+		_, err = pgxTx(tx).Exec(ctx, deleteQuery, deleteArgs...)
 	} else {
 		_, err = r.pool.Exec(ctx, deleteQuery, deleteArgs...)
 	}
@@ -527,7 +774,7 @@ func (r *Repository) ReassignPullRequestReviewer(
 	}
 
 	if tx != nil {
-		_, err = tx.Exec(ctx, insertQuery, insertArgs...)
+		_, err = pgxTx(tx).Exec(ctx, insertQuery, insertArgs...)
 	} else {
 		_, err = r.pool.Exec(ctx, insertQuery, insertArgs...)
 	}
@@ -536,5 +783,207 @@ func (r *Repository) ReassignPullRequestReviewer(
 		return "", wrapDBError(err, "ReassignPullRequestReviewer: execute insert query")
 	}
 
+	if err = r.bumpPullRequestVersion(ctx, tx, prID); err != nil {
+		return "", err
+	}
+
 	return newReviewerID, nil
 }
+
+// LockUser locks the user row until the end of the transaction and returns the user.
+func (r *Repository) LockUser(ctx context.Context, tx models.Tx, userID string) (models.User, error) {
+	query, args, err := r.builder.
+		Select("id", "user_name", "COALESCE(email, '')", "team_name", "is_active").
+		From("users").
+		Where(squirrel.Eq{"id": userID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return models.User{}, wrapDBError(err, "LockUser: build query")
+	}
+
+	var user models.User
+	err = pgxTx(tx).QueryRow(ctx, query, args...).
+		Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.User{}, NotFound("user")
+	}
+
+	if err != nil {
+		return models.User{}, wrapDBError(err, "LockUser: query row")
+	}
+
+	return user, nil
+}
+
+// LockOpenReviews locks the open pull requests the user reviews until the end of the transaction and returns them.
+// The rows are locked in the order of their ids, so that concurrent deactivations don't deadlock on them.
+func (r *Repository) LockOpenReviews(
+	ctx context.Context, tx models.Tx, reviewerID string,
+) ([]*models.PullRequestShort, error) {
+	query, args, err := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "pr.pr_status", "pr.created_at").
+		From("pull_requests pr").
+		Join("pr_reviewers prr ON pr.id = prr.pr_id").
+		Where(squirrel.Eq{"prr.reviewer_id": reviewerID, "pr.pr_status": "OPEN"}).
+		OrderBy("pr.id").
+		Suffix("FOR UPDATE OF pr").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockOpenReviews: build query")
+	}
+
+	rows, err := pgxTx(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "LockOpenReviews: execute query")
+	}
+	defer rows.Close()
+
+	var pullRequests []*models.PullRequestShort
+	for rows.Next() {
+		var pullRequest models.PullRequestShort
+		err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID,
+			&pullRequest.Status, &pullRequest.CreatedAt)
+		if err != nil {
+			return nil, wrapDBError(err, "LockOpenReviews: scan row")
+		}
+
+		pullRequests = append(pullRequests, &pullRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, "LockOpenReviews: iterate rows")
+	}
+
+	return pullRequests, nil
+}
+
+// LockPullRequest locks the pull request row until the end of the transaction and returns
+// the pull request with its reviewers, nil when it doesn't exist.
+func (r *Repository) LockPullRequest(
+	ctx context.Context, tx models.Tx, pullRequestID string,
+) (*models.PullRequest, error) {
+	query, args, err := r.builder.
+		Select("id", "pr_name", "author_id", "pr_status", "version").
+		From("pull_requests").
+		Where(squirrel.Eq{"id": pullRequestID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockPullRequest: build query")
+	}
+
+	var pr models.PullRequest
+	err = pgxTx(tx).QueryRow(ctx, query, args...).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockPullRequest: query row")
+	}
+
+	reviewers, err := r.SelectPullRequestReviewers(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.AssignedReviewers = slices.Sorted(maps.Keys(reviewers))
+
+	return &pr, nil
+}
+
+func (r *Repository) bumpPullRequestVersion(ctx context.Context, tx models.Tx, pullRequestID string) error {
+	query, args, err := r.builder.
+		Update("pull_requests").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "bumpPullRequestVersion: build query")
+	}
+
+	if tx != nil {
+		_, err = pgxTx(tx).Exec(ctx, query, args...)
+	} else {
+		_, err = r.pool.Exec(ctx, query, args...)
+	}
+
+	if err != nil {
+		return wrapDBError(err, "bumpPullRequestVersion: execute query")
+	}
+
+	return nil
+}
+
+// LockTeam locks the team row until the end of the transaction and returns its version.
+func (r *Repository) LockTeam(ctx context.Context, tx models.Tx, teamName string) (int64, error) {
+	query, args, err := r.builder.
+		Select("version").
+		From("teams").
+		Where(squirrel.Eq{"team_name": teamName}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return 0, wrapDBError(err, "LockTeam: build query")
+	}
+
+	var version int64
+	err = pgxTx(tx).QueryRow(ctx, query, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, NotFound(EntityTeam)
+	}
+
+	if err != nil {
+		return 0, wrapDBError(err, "LockTeam: query row")
+	}
+
+	return version, nil
+}
+
+// reviewerAssignmentLocks is the namespace of the advisory locks taken by LockReviewerAssignment.
+const reviewerAssignmentLocks = 1
+
+// LockReviewerAssignment serializes reviewer assignment within the team until the end of the transaction,
+// so that concurrent requests pick candidates with the reviewers assigned by each other.
+// Transactions take it after the team row and before the pull request rows, otherwise they could deadlock.
+func (r *Repository) LockReviewerAssignment(ctx context.Context, tx models.Tx, teamName string) error {
+	query, args, err := r.builder.
+		Select().
+		Column(squirrel.Expr("pg_advisory_xact_lock(?, hashtext(?))", reviewerAssignmentLocks, teamName)).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "LockReviewerAssignment: build query")
+	}
+
+	if _, err = pgxTx(tx).Exec(ctx, query, args...); err != nil {
+		return wrapDBError(err, "LockReviewerAssignment: execute query")
+	}
+
+	return nil
+}
+
+// bumpTeamVersions increments the version of the teams whose members changed.
+func (r *Repository) bumpTeamVersions(ctx context.Context, tx models.Tx, where squirrel.Sqlizer) error {
+	query, args, err := r.builder.
+		Update("teams").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(where).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "bumpTeamVersions: build query")
+	}
+
+	if _, err = pgxTx(tx).Exec(ctx, query, args...); err != nil {
+		return wrapDBError(err, "bumpTeamVersions: execute query")
+	}
+
+	return nil
+}
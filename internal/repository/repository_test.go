@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+// newRepository connects to the migrated database configured by the POSTGRES_* variables,
+// the benchmark is skipped when POSTGRES_HOST is not set.
+func newRepository(b *testing.B) *repository.Repository {
+	b.Helper()
+
+	if os.Getenv("POSTGRES_HOST") == "" {
+		b.Skip("POSTGRES_HOST is not set")
+	}
+
+	var cfg repository.PostgresCfg
+	if err := cleanenv.ReadEnv(&cfg); err != nil {
+		b.Fatalf("failed to read postgres config: %v", err)
+	}
+
+	repo, err := repository.NewRepository(cfg)
+	if err != nil {
+		b.Fatalf("NewRepository() error = %v", err)
+	}
+	b.Cleanup(repo.CloseConnection)
+
+	return repo
+}
+
+// BenchmarkInsertPullRequest compares the batched create path with sending its statements one by one.
+// Every pull request is rolled back, so the benchmark leaves the database as it was.
+func BenchmarkInsertPullRequest(b *testing.B) {
+	repo := newRepository(b)
+	ctx := b.Context()
+
+	tx, err := repo.BeginTx(ctx)
+	if err != nil {
+		b.Fatalf("BeginTx() error = %v", err)
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	teamName := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	if err = repo.InsertTeam(ctx, tx, models.AddTeamRequest{Name: teamName}); err != nil {
+		b.Fatalf("InsertTeam() error = %v", err)
+	}
+
+	ids := make([]string, 3)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s-u%d", teamName, i)
+		member := models.TeamMember{ID: ids[i], Username: ids[i], IsActive: true}
+		if err = repo.InsertTeamMember(ctx, tx, member, teamName); err != nil {
+			b.Fatalf("InsertTeamMember() error = %v", err)
+		}
+	}
+
+	author, reviewers := ids[0], ids[1:]
+	for _, bench := range []struct {
+		name   string
+		insert func(models.Tx, models.CreatePRRequest, []models.PullRequestEvent) error
+	}{
+		{"sequential", func(tx models.Tx, request models.CreatePRRequest, events []models.PullRequestEvent) error {
+			return repo.InsertPullRequestSequentially(ctx, tx, request, reviewers, events)
+		}},
+		{"batch", func(tx models.Tx, request models.CreatePRRequest, events []models.PullRequestEvent) error {
+			_, err := repo.InsertPullRequest(ctx, tx, request, reviewers, events)
+			return err
+		}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; b.Loop(); i++ {
+				request := models.CreatePRRequest{
+					ID:       fmt.Sprintf("%s-%s-%d", teamName, bench.name, i),
+					Name:     "benchmark",
+					AuthorID: author,
+				}
+
+				events := make([]models.PullRequestEvent, 0, len(reviewers))
+				for _, reviewerID := range reviewers {
+					events = append(events, models.PullRequestEvent{
+						Type:          models.EventReviewerAssigned,
+						PullRequestID: request.ID,
+						ReviewerID:    reviewerID,
+					})
+				}
+
+				savepoint, err := tx.Begin(ctx)
+				if err != nil {
+					b.Fatalf("Begin() error = %v", err)
+				}
+				if err = bench.insert(savepoint, request, events); err != nil {
+					b.Fatalf("insert error = %v", err)
+				}
+				if err = savepoint.Rollback(ctx); err != nil {
+					b.Fatalf("Rollback() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// copyBatchSize keeps the number of statement parameters below the SQLite limit.
+const copyBatchSize = 500
+
+var errUnknownBackupRecord = errors.New("unknown backup record type")
+
+// ExportBackup passes every row of the data tables to write, parents before children.
+// The rows are read in a single transaction, so the export is a consistent snapshot.
+// Errors returned by write are passed through as is.
+func (r *Repository) ExportBackup(
+	ctx context.Context, write func(models.BackupRecord) error,
+) (*models.BackupCounts, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrapDBError(err, "ExportBackup: begin tx")
+	}
+	// Nothing is written, so a failed rollback only means the connection is gone.
+	defer func() { _ = tx.Rollback() }()
+
+	counts := &models.BackupCounts{}
+
+	teams := r.builder.Select("team_name", "version").From("teams").OrderBy("team_name")
+	counts.Teams, err = exportRows(ctx, tx, teams, write, func(rows *sql.Rows) (models.BackupRecord, error) {
+		var team models.BackupTeam
+		err := rows.Scan(&team.Name, &team.Version)
+		return models.BackupRecord{Type: models.BackupTeamRecord, Team: &team}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := r.builder.
+		Select("id", "user_name", "email", "team_name", "is_active").
+		From("users").
+		OrderBy("id")
+	counts.Users, err = exportRows(ctx, tx, users, write, func(rows *sql.Rows) (models.BackupRecord, error) {
+		var user models.BackupUser
+		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive)
+		return models.BackupRecord{Type: models.BackupUserRecord, User: &user}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequests := r.builder.
+		Select("id", "pr_name", "author_id", "pr_status", "created_at", "merged_at", "version").
+		From("pull_requests").
+		OrderBy("id")
+	scanPullRequest := func(rows *sql.Rows) (models.BackupRecord, error) {
+		var pr models.BackupPullRequest
+		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Version)
+		return models.BackupRecord{Type: models.BackupPullRequestRecord, PullRequest: &pr}, err
+	}
+	counts.PullRequests, err = exportRows(ctx, tx, pullRequests, write, scanPullRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := r.builder.
+		Select("pr_id", "reviewer_id", "assigned_at").
+		From("pr_reviewers").
+		OrderBy("pr_id", "reviewer_id")
+	counts.Reviewers, err = exportRows(ctx, tx, reviewers, write, func(rows *sql.Rows) (models.BackupRecord, error) {
+		var reviewer models.BackupReviewer
+		err := rows.Scan(&reviewer.PullRequestID, &reviewer.ReviewerID, &reviewer.AssignedAt)
+		return models.BackupRecord{Type: models.BackupReviewerRecord, Reviewer: &reviewer}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := r.builder.
+		Select("id", "pr_id", "event_type", "reviewer_id", "old_reviewer_id", "created_at").
+		From("pr_events").
+		OrderBy("id")
+	counts.Events, err = exportRows(ctx, tx, events, write, func(rows *sql.Rows) (models.BackupRecord, error) {
+		var event models.BackupEvent
+		err := rows.Scan(&event.ID, &event.PullRequestID, &event.Type,
+			&event.ReviewerID, &event.OldReviewerID, &event.CreatedAt)
+		return models.BackupRecord{Type: models.BackupEventRecord, Event: &event}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func exportRows(
+	ctx context.Context,
+	tx *sql.Tx,
+	builder squirrel.SelectBuilder,
+	write func(models.BackupRecord) error,
+	scan func(*sql.Rows) (models.BackupRecord, error),
+) (int64, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, wrapDBError(err, "ExportBackup: build query")
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, wrapDBError(err, "ExportBackup: execute query")
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		record, err := scan(rows)
+		if err != nil {
+			return 0, wrapDBError(err, "ExportBackup: scan row")
+		}
+
+		if err = write(record); err != nil {
+			return 0, err
+		}
+		count++
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, wrapDBError(err, "ExportBackup: read rows")
+	}
+
+	return count, nil
+}
+
+// LockBackupTables does nothing, a transaction holds the database write lock from its start.
+func (r *Repository) LockBackupTables(_ context.Context, _ models.Tx) error {
+	return nil
+}
+
+// CountBackupRows returns the number of rows in the data tables.
+func (r *Repository) CountBackupRows(ctx context.Context, tx models.Tx) (*models.BackupCounts, error) {
+	builder := r.builder.Select()
+	for _, table := range []string{"teams", "users", "pull_requests", "pr_reviewers", "pr_events"} {
+		builder = builder.Column("(SELECT COUNT(*) FROM " + table + ")")
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, wrapDBError(err, "CountBackupRows: build query")
+	}
+
+	var counts models.BackupCounts
+	err = r.conn(tx).QueryRowContext(ctx, query, args...).Scan(
+		&counts.Teams, &counts.Users, &counts.PullRequests, &counts.Reviewers, &counts.Events)
+	if err != nil {
+		return nil, wrapDBError(err, "CountBackupRows: execute query")
+	}
+
+	return &counts, nil
+}
+
+// CopyBackupRecords inserts records of a single type with multi-row inserts.
+func (r *Repository) CopyBackupRecords(ctx context.Context, tx models.Tx, records []models.BackupRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var table string
+	var columns []string
+	var row func(models.BackupRecord) []any
+
+	switch records[0].Type {
+	case models.BackupTeamRecord:
+		table, columns = "teams", []string{"team_name", "version"}
+		row = func(record models.BackupRecord) []any {
+			return []any{record.Team.Name, record.Team.Version}
+		}
+	case models.BackupUserRecord:
+		table, columns = "users", []string{"id", "user_name", "email", "team_name", "is_active"}
+		row = func(record models.BackupRecord) []any {
+			user := record.User
+			return []any{user.ID, user.Username, user.Email, user.TeamName, user.IsActive}
+		}
+	case models.BackupPullRequestRecord:
+		table = "pull_requests"
+		columns = []string{"id", "pr_name", "author_id", "pr_status", "created_at", "merged_at", "version"}
+		row = func(record models.BackupRecord) []any {
+			pr := record.PullRequest
+			return []any{pr.ID, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt, pr.Version}
+		}
+	case models.BackupReviewerRecord:
+		table, columns = "pr_reviewers", []string{"pr_id", "reviewer_id", "assigned_at"}
+		row = func(record models.BackupRecord) []any {
+			reviewer := record.Reviewer
+			return []any{reviewer.PullRequestID, reviewer.ReviewerID, reviewer.AssignedAt}
+		}
+	case models.BackupEventRecord:
+		table = "pr_events"
+		columns = []string{"id", "pr_id", "event_type", "reviewer_id", "old_reviewer_id", "created_at"}
+		row = func(record models.BackupRecord) []any {
+			event := record.Event
+			return []any{
+				event.ID, event.PullRequestID, event.Type, event.ReviewerID, event.OldReviewerID, event.CreatedAt,
+			}
+		}
+	default:
+		return wrapDBError(errUnknownBackupRecord, "CopyBackupRecords")
+	}
+
+	for start := 0; start < len(records); start += copyBatchSize {
+		builder := r.builder.Insert(table).Columns(columns...)
+		for _, record := range records[start:min(start+copyBatchSize, len(records))] {
+			builder = builder.Values(row(record)...)
+		}
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return wrapDBError(err, "CopyBackupRecords: build query")
+		}
+
+		if _, err = r.conn(tx).ExecContext(ctx, query, args...); err != nil {
+			return wrapDBError(err, "CopyBackupRecords: insert "+table)
+		}
+	}
+
+	return nil
+}
+
+// ResetEventSequence does nothing, new event ids always follow the largest one in the table.
+func (r *Repository) ResetEventSequence(_ context.Context, _ models.Tx) error {
+	return nil
+}
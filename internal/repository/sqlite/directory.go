@@ -0,0 +1,229 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+// SelectDirectoryUsers returns a page of users matching all the set filter fields ordered by id,
+// along with the total number of matching users.
+func (r *Repository) SelectDirectoryUsers(
+	ctx context.Context, filter models.DirectoryUserFilter,
+) ([]models.User, int, error) {
+	where := squirrel.And{}
+	if filter.ID != "" {
+		where = append(where, squirrel.Eq{"id": filter.ID})
+	}
+
+	if filter.Username != "" {
+		where = append(where, squirrel.Eq{"user_name": filter.Username})
+	}
+
+	if filter.Email != "" {
+		where = append(where, squirrel.Expr("LOWER(email) = LOWER(?)", filter.Email))
+	}
+
+	builder := r.builder.
+		Select("id", "COALESCE(user_name, '')", "COALESCE(email, '')", "team_name", "COALESCE(is_active, true)",
+			"COUNT(*) OVER ()").
+		From("users").
+		Where(where).
+		OrderBy("id").
+		Offset(uint64(filter.Offset))
+
+	if filter.Limit > 0 {
+		builder = builder.Limit(uint64(filter.Limit))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, 0, wrapDBError(err, "SelectDirectoryUsers: build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, wrapDBError(err, "SelectDirectoryUsers: execute query")
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	var total int
+	for rows.Next() {
+		var user models.User
+		err = rows.Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive, &total)
+		if err != nil {
+			return nil, 0, wrapDBError(err, "SelectDirectoryUsers: scan row")
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, wrapDBError(err, "SelectDirectoryUsers: read rows")
+	}
+
+	// The window count is missing when the offset is past the last row.
+	if len(users) == 0 && filter.Offset > 0 {
+		countQuery, countArgs, err := r.builder.Select("COUNT(*)").From("users").Where(where).ToSql()
+		if err != nil {
+			return nil, 0, wrapDBError(err, "SelectDirectoryUsers: build count query")
+		}
+
+		if err = r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+			return nil, 0, wrapDBError(err, "SelectDirectoryUsers: count rows")
+		}
+	}
+
+	return users, total, nil
+}
+
+func (r *Repository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	query, args, err := r.builder.
+		Select("1").
+		From("teams").
+		Where(squirrel.Eq{"team_name": teamName}).
+		ToSql()
+
+	if err != nil {
+		return false, wrapDBError(err, "TeamExists: build query")
+	}
+
+	var exists int
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, wrapDBError(err, "TeamExists: query row")
+	}
+
+	return true, nil
+}
+
+// SelectTeamMemberIDs returns the ids of the team members in tx ordered by id.
+func (r *Repository) SelectTeamMemberIDs(ctx context.Context, tx models.Tx, teamName string) ([]string, error) {
+	query, args, err := r.builder.
+		Select("id").
+		From("users").
+		Where(squirrel.Eq{"team_name": teamName}).
+		OrderBy("id").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeamMemberIDs: build query")
+	}
+
+	rows, err := r.conn(tx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeamMemberIDs: execute query")
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, wrapDBError(err, "SelectTeamMemberIDs: scan row")
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, "SelectTeamMemberIDs: iterate rows")
+	}
+
+	return ids, nil
+}
+
+// MoveUsersToTeam changes the team of the given users, with fromTeam set only its members are moved.
+// It returns the number of moved users.
+func (r *Repository) MoveUsersToTeam(
+	ctx context.Context, tx models.Tx, userIDs []string, fromTeam, teamName string,
+) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	var source squirrel.Sqlizer = squirrel.Expr("team_name IN (?)",
+		squirrel.Select("team_name").From("users").Where(squirrel.Eq{"id": userIDs}))
+	if fromTeam != "" {
+		source = squirrel.Eq{"team_name": fromTeam}
+	}
+
+	if err := r.bumpTeamVersions(ctx, tx, squirrel.Or{squirrel.Eq{"team_name": teamName}, source}); err != nil {
+		return 0, err
+	}
+
+	builder := r.builder.
+		Update("users").
+		Set("team_name", teamName).
+		Where(squirrel.Eq{"id": userIDs})
+
+	if fromTeam != "" {
+		builder = builder.Where(squirrel.Eq{"team_name": fromTeam})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, wrapDBError(err, "MoveUsersToTeam: build query")
+	}
+
+	result, err := r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, wrapDBError(err, "MoveUsersToTeam: execute query")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, wrapDBError(err, "MoveUsersToTeam: rows affected")
+	}
+
+	return affected, nil
+}
+
+// MoveTeamMembers moves every member of a team to another one.
+func (r *Repository) MoveTeamMembers(ctx context.Context, tx models.Tx, fromTeam, teamName string) error {
+	query, args, err := r.builder.
+		Update("users").
+		Set("team_name", teamName).
+		Where(squirrel.Eq{"team_name": fromTeam}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "MoveTeamMembers: build query")
+	}
+
+	if _, err = r.conn(tx).ExecContext(ctx, query, args...); err != nil {
+		return wrapDBError(err, "MoveTeamMembers: execute query")
+	}
+
+	return r.bumpTeamVersions(ctx, tx, squirrel.Eq{"team_name": []string{fromTeam, teamName}})
+}
+
+func (r *Repository) DeleteTeam(ctx context.Context, tx models.Tx, teamName string) error {
+	query, args, err := r.builder.
+		Delete("teams").
+		Where(squirrel.Eq{"team_name": teamName}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "DeleteTeam: build query")
+	}
+
+	result, err := r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "DeleteTeam: execute query")
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return repository.NotFound("team")
+	}
+
+	return nil
+}
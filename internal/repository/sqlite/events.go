@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (r *Repository) InsertPullRequestEvents(
+	ctx context.Context, tx models.Tx, events []models.PullRequestEvent,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	builder := r.builder.
+		Insert("pr_events").
+		Columns("pr_id", "event_type", "reviewer_id", "old_reviewer_id", "created_at")
+
+	now := time.Now()
+	for _, event := range events {
+		builder = builder.Values(
+			event.PullRequestID,
+			event.Type,
+			nullableString(event.ReviewerID),
+			nullableString(event.OldReviewerID),
+			now,
+		)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return wrapDBError(err, "InsertPullRequestEvents: build query")
+	}
+
+	_, err = r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "InsertPullRequestEvents: execute query")
+	}
+
+	return nil
+}
+
+func (r *Repository) SelectBreachedReviews(
+	ctx context.Context, assignedBefore time.Time,
+) ([]models.PullRequestEvent, error) {
+	query, args, err := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "u.team_name", "prr.reviewer_id", "prr.assigned_at").
+		From("pr_reviewers prr").
+		Join("pull_requests pr ON pr.id = prr.pr_id").
+		Join("users u ON u.id = pr.author_id").
+		Where(squirrel.Eq{"pr.pr_status": "OPEN"}).
+		Where(squirrel.Lt{"prr.assigned_at": assignedBefore}).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM pr_events e
+			WHERE e.pr_id = prr.pr_id
+				AND e.reviewer_id = prr.reviewer_id
+				AND e.event_type = ?
+				AND e.created_at >= prr.assigned_at
+		)`, models.EventReviewSLABreached).
+		OrderBy("prr.assigned_at").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectBreachedReviews: build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "SelectBreachedReviews: execute query")
+	}
+	defer rows.Close()
+
+	var events []models.PullRequestEvent
+	for rows.Next() {
+		event := models.PullRequestEvent{Type: models.EventReviewSLABreached}
+
+		err = rows.Scan(&event.PullRequestID, &event.PullRequestName, &event.AuthorID,
+			&event.TeamName, &event.ReviewerID, &event.AssignedAt)
+		if err != nil {
+			return nil, wrapDBError(err, "SelectBreachedReviews: scan row")
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, "SelectBreachedReviews: iterate rows")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+// InsertIdempotencyKey claims the key for a new request, an expired key is claimed again.
+// It reports false when the key is held by another request.
+func (r *Repository) InsertIdempotencyKey(ctx context.Context, key string, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+	query, args, err := r.builder.
+		Insert("idempotency_keys").
+		Columns("idempotency_key", "created_at", "expires_at").
+		Values(key, now, expiresAt).
+		Suffix(`ON CONFLICT (idempotency_key) DO UPDATE
+			SET request_hash = NULL, status_code = NULL, response_headers = NULL, response_body = NULL,
+				created_at = excluded.created_at, expires_at = excluded.expires_at
+			WHERE idempotency_keys.expires_at <= ?`, now).
+		ToSql()
+
+	if err != nil {
+		return false, wrapDBError(err, "InsertIdempotencyKey: build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, wrapDBError(err, "InsertIdempotencyKey: execute query")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, wrapDBError(err, "InsertIdempotencyKey: rows affected")
+	}
+
+	return affected > 0, nil
+}
+
+// SelectIdempotencyKey returns nil when the key is unknown or expired.
+func (r *Repository) SelectIdempotencyKey(ctx context.Context, key string) (*models.IdempotencyRecord, error) {
+	query, args, err := r.builder.
+		Select("idempotency_key", "COALESCE(request_hash, '')", "COALESCE(status_code, 0)",
+			"COALESCE(response_headers, '{}')", "response_body", "expires_at").
+		From("idempotency_keys").
+		Where(squirrel.Eq{"idempotency_key": key}).
+		Where(squirrel.Gt{"expires_at": time.Now()}).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectIdempotencyKey: build query")
+	}
+
+	var (
+		record  models.IdempotencyRecord
+		headers string
+	)
+	err = r.db.QueryRowContext(ctx, query, args...).
+		Scan(&record.Key, &record.RequestHash, &record.StatusCode, &headers, &record.Body, &record.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectIdempotencyKey: query row")
+	}
+
+	if err = json.Unmarshal([]byte(headers), &record.Headers); err != nil {
+		return nil, wrapDBError(err, "SelectIdempotencyKey: decode headers")
+	}
+
+	return &record, nil
+}
+
+func (r *Repository) UpdateIdempotencyKey(ctx context.Context, record models.IdempotencyRecord) error {
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return wrapDBError(err, "UpdateIdempotencyKey: encode headers")
+	}
+
+	query, args, err := r.builder.
+		Update("idempotency_keys").
+		Set("request_hash", record.RequestHash).
+		Set("status_code", record.StatusCode).
+		Set("response_headers", string(headers)).
+		Set("response_body", record.Body).
+		Set("expires_at", record.ExpiresAt).
+		Where(squirrel.Eq{"idempotency_key": record.Key}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "UpdateIdempotencyKey: build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "UpdateIdempotencyKey: execute query")
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return repository.NotFound("idempotency key")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	query, args, err := r.builder.
+		Delete("idempotency_keys").
+		Where(squirrel.Eq{"idempotency_key": key}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "DeleteIdempotencyKey: build query")
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return wrapDBError(err, "DeleteIdempotencyKey: execute query")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	query, args, err := r.builder.
+		Delete("idempotency_keys").
+		Where(squirrel.LtOrEq{"expires_at": time.Now()}).
+		ToSql()
+
+	if err != nil {
+		return 0, wrapDBError(err, "DeleteExpiredIdempotencyKeys: build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, wrapDBError(err, "DeleteExpiredIdempotencyKeys: execute query")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, wrapDBError(err, "DeleteExpiredIdempotencyKeys: rows affected")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+)
+
+// UpsertTeam creates the team unless it exists and reports whether it was created.
+func (r *Repository) UpsertTeam(ctx context.Context, tx models.Tx, teamName string) (bool, error) {
+	query, args, err := r.builder.
+		Insert("teams").
+		Columns("team_name").
+		Values(teamName).
+		Suffix("ON CONFLICT (team_name) DO NOTHING RETURNING team_name").
+		ToSql()
+
+	if err != nil {
+		return false, wrapDBError(err, "UpsertTeam: build query")
+	}
+
+	var inserted string
+	err = r.conn(tx).QueryRowContext(ctx, query, args...).Scan(&inserted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, wrapDBError(err, "UpsertTeam: query row")
+	}
+
+	return true, nil
+}
+
+// UpsertTeamMember creates or updates the user. It returns the import action
+// and whether the user was active before, so that deactivation can reassign reviews.
+func (r *Repository) UpsertTeamMember(
+	ctx context.Context, tx models.Tx, member models.TeamMember, teamName string,
+) (string, bool, error) {
+	query, args, err := r.builder.
+		Select("COALESCE(user_name, '')", "COALESCE(email, '')", "is_active", "team_name").
+		From("users").
+		Where(squirrel.Eq{"id": member.ID}).
+		ToSql()
+
+	if err != nil {
+		return "", false, wrapDBError(err, "UpsertTeamMember: build select query")
+	}
+
+	var previous models.User
+	err = r.conn(tx).QueryRowContext(ctx, query, args...).
+		Scan(&previous.Username, &previous.Email, &previous.IsActive, &previous.TeamName)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err = r.InsertTeamMember(ctx, tx, member, teamName); err != nil {
+			return "", false, upsertError(err)
+		}
+		return models.ImportActionCreated, false, nil
+	}
+
+	if err != nil {
+		return "", false, wrapDBError(err, "UpsertTeamMember: query row")
+	}
+
+	if previous.Username == member.Username && previous.Email == member.Email &&
+		previous.IsActive == member.IsActive && previous.TeamName == teamName {
+		return models.ImportActionUnchanged, member.IsActive, nil
+	}
+
+	query, args, err = r.builder.
+		Update("users").
+		Set("user_name", member.Username).
+		Set("email", nullableString(member.Email)).
+		Set("is_active", member.IsActive).
+		Set("team_name", teamName).
+		Where(squirrel.Eq{"id": member.ID}).
+		ToSql()
+
+	if err != nil {
+		return "", false, wrapDBError(err, "UpsertTeamMember: build update query")
+	}
+
+	if _, err = r.conn(tx).ExecContext(ctx, query, args...); err != nil {
+		return "", false, upsertError(wrapDBError(err, "UpsertTeamMember: execute query"))
+	}
+
+	err = r.bumpTeamVersions(ctx, tx, squirrel.Eq{"team_name": []string{teamName, previous.TeamName}})
+	if err != nil {
+		return "", false, err
+	}
+
+	return models.ImportActionUpdated, previous.IsActive, nil
+}
+
+// upsertError reports a taken email as a conflict and the rest as database errors,
+// as the single upsert statement does in Postgres. The id is known to be free, so any conflict is on the email.
+func upsertError(err error) error {
+	var conflict *repository.ErrConflict
+	switch {
+	case isUniqueViolation(err), errors.As(err, &conflict):
+		return &repository.ErrConflict{Entity: repository.EntityEmail}
+	case errors.Is(err, repository.ErrNotFound):
+		return wrapDBError(err, "UpsertTeamMember: insert user")
+	}
+
+	return err
+}
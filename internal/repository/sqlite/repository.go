@@ -0,0 +1,764 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+)
+
+func (r *Repository) InsertTeam(ctx context.Context, tx models.Tx, team models.AddTeamRequest) error {
+	query, args, err := r.builder.
+		Insert("teams").
+		Columns("team_name").
+		Values(team.Name).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "InsertTeam: build query")
+	}
+
+	_, err = r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &repository.ErrConflict{Entity: repository.EntityTeam}
+		}
+		return wrapDBError(err, "InsertTeam: execute query")
+	}
+
+	return nil
+}
+
+func (r *Repository) InsertTeamMember(
+	ctx context.Context, tx models.Tx, member models.TeamMember, teamName string,
+) error {
+	query, args, err := r.builder.
+		Insert("users").
+		Columns("id", "user_name", "email", "is_active", "team_name").
+		Values(member.ID, member.Username, nullableString(member.Email), member.IsActive, teamName).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "InsertTeamMember: build query")
+	}
+
+	_, err = r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return &repository.ErrConflict{Entity: repository.EntityUser}
+		case isForeignKeyViolation(err):
+			return repository.ErrNotFound
+		}
+		return wrapDBError(err, "InsertTeamMember: execute query")
+	}
+
+	return r.bumpTeamVersions(ctx, tx, squirrel.Eq{"team_name": teamName})
+}
+
+func (r *Repository) SelectTeam(ctx context.Context, teamName string) (*models.Team, error) {
+	query, args, err := r.builder.
+		Select("u.id", "u.user_name", "COALESCE(u.email, '')", "u.is_active", "t.version").
+		From("users u").
+		Join("teams t ON t.team_name = u.team_name").
+		Where(squirrel.Eq{"u.team_name": teamName}).
+		OrderBy("u.id").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeam: build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeam: query row")
+	}
+	defer rows.Close()
+
+	team := &models.Team{
+		Name:    teamName,
+		Members: make([]models.TeamMember, 0),
+	}
+
+	for rows.Next() {
+		var member models.TeamMember
+		err = rows.Scan(&member.ID, &member.Username, &member.Email, &member.IsActive, &team.Version)
+		if err != nil {
+			return nil, wrapDBError(err, "SelectTeam: scan")
+		}
+
+		team.Members = append(team.Members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, "SelectTeam: read rows")
+	}
+
+	if len(team.Members) == 0 {
+		return nil, nil
+	}
+
+	return team, nil
+}
+
+func (r *Repository) UpdateUserStatus(ctx context.Context, tx models.Tx, user models.SetUserStatusRequest) error {
+	query, args, err := r.builder.
+		Update("users").
+		Set("is_active", user.IsActive).
+		Where(squirrel.Eq{"id": user.ID}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "UpdateUserStatus: build query")
+	}
+
+	result, err := r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "UpdateUserStatus: execute query")
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return repository.NotFound("user")
+	}
+
+	return r.bumpTeamVersions(ctx, tx, squirrel.Expr("team_name = (SELECT team_name FROM users WHERE id = ?)", user.ID))
+}
+
+func (r *Repository) selectUser(
+	ctx context.Context, q querier, where squirrel.Sqlizer, fn string,
+) (models.User, error) {
+	query, args, err := r.builder.
+		Select("id", "user_name", "COALESCE(email, '')", "team_name", "is_active").
+		From("users").
+		Where(where).
+		ToSql()
+
+	if err != nil {
+		return models.User{}, wrapDBError(err, fn+": build query")
+	}
+
+	var user models.User
+	err = q.QueryRowContext(ctx, query, args...).
+		Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, repository.NotFound("user")
+		}
+		return models.User{}, wrapDBError(err, fn+": query row")
+	}
+
+	return user, nil
+}
+
+func (r *Repository) SelectUser(ctx context.Context, userID string) (models.User, error) {
+	return r.selectUser(ctx, r.db, squirrel.Eq{"id": userID}, "SelectUser")
+}
+
+func (r *Repository) SelectUserByEmail(ctx context.Context, email string) (models.User, error) {
+	return r.selectUser(ctx, r.db, squirrel.Eq{"email": email}, "SelectUserByEmail")
+}
+
+// SelectUserReviews returns the pull requests the user reviews, most recently assigned first.
+func (r *Repository) SelectUserReviews(
+	ctx context.Context, filter models.UserReviewsRequest,
+) ([]*models.PullRequestShort, string, error) {
+	const sort = "assigned_at:desc"
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "pr.pr_status", "pr.created_at", "prr.assigned_at").
+		From("pull_requests pr").
+		Join("pr_reviewers prr ON pr.id = prr.pr_id").
+		Where(squirrel.Eq{"prr.reviewer_id": filter.UserID}).
+		OrderBy("prr.assigned_at DESC", "pr.id DESC")
+
+	if filter.Status != "" {
+		builder = builder.Where(squirrel.Eq{"pr.pr_status": filter.Status})
+	}
+
+	if after != nil {
+		assignedAt, err := time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return nil, "", pagination.ErrInvalidCursor
+		}
+		builder = builder.Where("(prr.assigned_at, pr.id) < (?, ?)", assignedAt, after.ID)
+	}
+
+	if filter.Limit > 0 {
+		builder = builder.Limit(uint64(filter.Limit) + 1)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectUserReviews: build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectUserReviews: execute query")
+	}
+	defer rows.Close()
+
+	var pullRequests []*models.PullRequestShort
+	for rows.Next() {
+		var pullRequest models.PullRequestShort
+		var assignedAt time.Time
+
+		err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID,
+			&pullRequest.Status, &pullRequest.CreatedAt, &assignedAt)
+		if err != nil {
+			return nil, "", wrapDBError(err, "SelectUserReviews: scan row")
+		}
+
+		pullRequest.AssignedAt = &assignedAt
+		pullRequest.ReviewState = models.ReviewStatePending
+		if pullRequest.Status == "MERGED" {
+			pullRequest.ReviewState = models.ReviewStateCompleted
+		}
+
+		pullRequests = append(pullRequests, &pullRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", wrapDBError(err, "SelectUserReviews: iterate rows")
+	}
+
+	var next string
+	if filter.Limit > 0 && len(pullRequests) > filter.Limit {
+		pullRequests = pullRequests[:filter.Limit]
+		last := pullRequests[len(pullRequests)-1]
+		next = pagination.Encode(pagination.Cursor{
+			Sort:  sort,
+			Value: last.AssignedAt.UTC().Format(time.RFC3339Nano),
+			ID:    last.ID,
+		})
+	}
+
+	return pullRequests, next, nil
+}
+
+func (r *Repository) SelectAuthoredPullRequests(
+	ctx context.Context, authorID, status string,
+) ([]*models.PullRequestShort, error) {
+	query, args, err := r.builder.
+		Select("id", "pr_name", "author_id", "pr_status", "created_at").
+		From("pull_requests").
+		Where(squirrel.Eq{
+			"author_id": authorID,
+			"pr_status": status,
+		}).
+		OrderBy("created_at").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectAuthoredPullRequests: build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "SelectAuthoredPullRequests: execute query")
+	}
+	defer rows.Close()
+
+	var pullRequests []*models.PullRequestShort
+	for rows.Next() {
+		var pullRequest models.PullRequestShort
+
+		err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID,
+			&pullRequest.Status, &pullRequest.CreatedAt)
+		if err != nil {
+			return nil, wrapDBError(err, "SelectAuthoredPullRequests: scan row")
+		}
+
+		pullRequests = append(pullRequests, &pullRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, "SelectAuthoredPullRequests: iterate rows")
+	}
+
+	return pullRequests, nil
+}
+
+func (r *Repository) SelectTeamNames(ctx context.Context) ([]string, error) {
+	query, args, err := r.builder.
+		Select("team_name").
+		From("teams").
+		OrderBy("team_name").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectTeamNames: build query")
+	}
+
+	return r.selectStrings(ctx, r.db, query, args, "SelectTeamNames")
+}
+
+// selectStrings returns the single text column of the query rows.
+func (r *Repository) selectStrings(
+	ctx context.Context, conn querier, query string, args []any, fn string,
+) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, fn+": execute query")
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, wrapDBError(err, fn+": scan row")
+		}
+
+		values = append(values, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, fn+": iterate rows")
+	}
+
+	return values, nil
+}
+
+func (r *Repository) DeletePullRequestReviewer(ctx context.Context, tx models.Tx, prID, reviewerID string) error {
+	query, args, err := r.builder.Delete("pr_reviewers").
+		Where(squirrel.Eq{
+			"pr_id":       prID,
+			"reviewer_id": reviewerID,
+		}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "DeletePullRequestReviewer: build query")
+	}
+
+	if _, err = r.conn(tx).ExecContext(ctx, query, args...); err != nil {
+		return wrapDBError(err, "DeletePullRequestReviewer: execute query")
+	}
+
+	return r.bumpPullRequestVersion(ctx, tx, prID)
+}
+
+func (r *Repository) FindAvailableReviewers(ctx context.Context, tx models.Tx, user models.User) ([]string, error) {
+	const defaultLimit = 10
+	query, args, err := r.builder.
+		Select("id").
+		From("users").
+		Where(squirrel.Eq{
+			"team_name": user.TeamName,
+			"is_active": true,
+		}).
+		Where(squirrel.NotEq{"id": user.ID}).
+		OrderBy("RANDOM()").
+		Limit(defaultLimit).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "FindAvailableReviewers: build query")
+	}
+
+	return r.selectStrings(ctx, r.conn(tx), query, args, "FindAvailableReviewers")
+}
+
+// InsertPullRequest creates the pull request with its reviewers and events.
+func (r *Repository) InsertPullRequest(
+	ctx context.Context,
+	tx models.Tx,
+	pullRequest models.CreatePRRequest,
+	reviewers []string,
+	events []models.PullRequestEvent,
+) (*models.PullRequest, error) {
+	query, args, err := r.builder.
+		Insert("pull_requests").
+		Columns("id", "pr_name", "author_id", "pr_status", "created_at").
+		Values(pullRequest.ID, pullRequest.Name, pullRequest.AuthorID, "OPEN", time.Now()).
+		Suffix("RETURNING id, pr_name, author_id, pr_status, version").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "InsertPullRequest: build query")
+	}
+
+	pr := models.PullRequest{AssignedReviewers: reviewers}
+	err = r.conn(tx).QueryRowContext(ctx, query, args...).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return nil, &repository.ErrConflict{Entity: repository.EntityPullRequest}
+		case isForeignKeyViolation(err):
+			return nil, repository.ErrNotFound
+		}
+		return nil, wrapDBError(err, "InsertPullRequest: execute query")
+	}
+
+	if err = r.assignPullRequestReviewers(ctx, tx, pullRequest.ID, reviewers); err != nil {
+		return nil, err
+	}
+
+	if err = r.InsertPullRequestEvents(ctx, tx, events); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+func (r *Repository) SelectPullRequest(
+	ctx context.Context, pullRequestID string,
+) (*models.PullRequest, time.Time, error) {
+	prQuery, prArgs, err := r.builder.
+		Select("id", "pr_name", "author_id", "pr_status", "merged_at", "version").
+		From("pull_requests").
+		Where(squirrel.Eq{"id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return nil, time.Time{}, wrapDBError(err, "SelectPullRequest: build query")
+	}
+
+	var pr models.PullRequest
+	var mergedAt sql.NullTime
+	err = r.db.QueryRowContext(ctx, prQuery, prArgs...).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &mergedAt, &pr.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, time.Time{}, nil
+	}
+
+	if err != nil {
+		return nil, time.Time{}, wrapDBError(err, "SelectPullRequest: query row")
+	}
+
+	reviewersQuery, reviewersArgs, err := r.builder.
+		Select("reviewer_id").
+		From("pr_reviewers").
+		Where(squirrel.Eq{"pr_id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return nil, time.Time{}, wrapDBError(err, "SelectPullRequest: build reviewers query")
+	}
+
+	pr.AssignedReviewers, err = r.selectStrings(ctx, r.db, reviewersQuery, reviewersArgs, "SelectPullRequest")
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return &pr, mergedAt.Time, nil
+}
+
+func (r *Repository) SelectPullRequestDetails(
+	ctx context.Context, pullRequestID string,
+) (*models.PullRequestDetails, error) {
+	prQuery, prArgs, err := r.selectPullRequestDetails().
+		Where(squirrel.Eq{"pr.id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectPullRequestDetails: build query")
+	}
+
+	pr, err := scanPullRequestDetails(r.db.QueryRowContext(ctx, prQuery, prArgs...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectPullRequestDetails: query row")
+	}
+
+	if err = r.fillReviewerAssignments(ctx, []*models.PullRequestDetails{pr}); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (r *Repository) UpdatePullRequestStatus(ctx context.Context, tx models.Tx, pullRequestID string) (bool, error) {
+	query, args, err := r.builder.
+		Update("pull_requests").
+		Set("pr_status", "MERGED").
+		Set("merged_at", time.Now()).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": pullRequestID}).
+		Where(squirrel.Eq{"pr_status": "OPEN"}).
+		ToSql()
+
+	if err != nil {
+		return false, wrapDBError(err, "UpdatePullRequestStatus: build query")
+	}
+
+	result, err := r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, wrapDBError(err, "UpdatePullRequestStatus: execute query")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, wrapDBError(err, "UpdatePullRequestStatus: rows affected")
+	}
+
+	return affected > 0, nil
+}
+
+func (r *Repository) assignPullRequestReviewers(
+	ctx context.Context, tx models.Tx, pullRequestID string, reviewers []string,
+) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	builder := r.builder.
+		Insert("pr_reviewers").
+		Columns("pr_id", "reviewer_id", "assigned_at")
+
+	now := time.Now()
+	for _, reviewerID := range reviewers {
+		builder = builder.Values(pullRequestID, reviewerID, now)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return wrapDBError(err, "assignPullRequestReviewers: build query")
+	}
+
+	_, err = r.conn(tx).ExecContext(ctx, query, args...)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return repository.ErrNotFound
+		}
+		return wrapDBError(err, "assignPullRequestReviewers: execute query")
+	}
+
+	return nil
+}
+
+func (r *Repository) SelectPullRequestReviewers(
+	ctx context.Context, tx models.Tx, pullRequestID string,
+) (map[string]bool, error) {
+	query, args, err := r.builder.Select("reviewer_id").
+		From("pr_reviewers").
+		Where(squirrel.Eq{"pr_id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectPullRequestReviewers: build query")
+	}
+
+	ids, err := r.selectStrings(ctx, r.conn(tx), query, args, "SelectPullRequestReviewers")
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		reviewers[id] = true
+	}
+
+	return reviewers, nil
+}
+
+func (r *Repository) ReassignPullRequestReviewer(
+	ctx context.Context, tx models.Tx, prID, oldReviewerID, authorID, teamName string,
+) (string, error) {
+	user := models.User{
+		ID:       authorID,
+		TeamName: teamName,
+	}
+	reviewers, err := r.FindAvailableReviewers(ctx, tx, user)
+	if err != nil {
+		return "", err
+	}
+
+	currentReviewers, err := r.SelectPullRequestReviewers(ctx, tx, prID)
+	if err != nil {
+		return "", err
+	}
+
+	newReviewerID := ""
+	for _, candidateID := range reviewers {
+		if candidateID != oldReviewerID && !currentReviewers[candidateID] {
+			newReviewerID = candidateID
+			break
+		}
+	}
+
+	if newReviewerID == "" {
+		return "", nil
+	}
+
+	if err = r.DeletePullRequestReviewer(ctx, tx, prID, oldReviewerID); err != nil {
+		return "", err
+	}
+
+	if err = r.assignPullRequestReviewers(ctx, tx, prID, []string{newReviewerID}); err != nil {
+		return "", err
+	}
+
+	return newReviewerID, nil
+}
+
+// LockReviewerAssignment does nothing, a transaction holds the database write lock from its start.
+func (r *Repository) LockReviewerAssignment(_ context.Context, _ models.Tx, _ string) error {
+	return nil
+}
+
+// LockUser returns the user as seen by tx, see LockPullRequest.
+func (r *Repository) LockUser(ctx context.Context, tx models.Tx, userID string) (models.User, error) {
+	return r.selectUser(ctx, r.conn(tx), squirrel.Eq{"id": userID}, "LockUser")
+}
+
+// LockOpenReviews returns the open pull requests the user reviews ordered by id, see LockPullRequest.
+func (r *Repository) LockOpenReviews(
+	ctx context.Context, tx models.Tx, reviewerID string,
+) ([]*models.PullRequestShort, error) {
+	query, args, err := r.builder.
+		Select("pr.id", "pr.pr_name", "pr.author_id", "pr.pr_status", "pr.created_at").
+		From("pull_requests pr").
+		Join("pr_reviewers prr ON pr.id = prr.pr_id").
+		Where(squirrel.Eq{"prr.reviewer_id": reviewerID, "pr.pr_status": "OPEN"}).
+		OrderBy("pr.id").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockOpenReviews: build query")
+	}
+
+	rows, err := r.conn(tx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "LockOpenReviews: execute query")
+	}
+	defer rows.Close()
+
+	var pullRequests []*models.PullRequestShort
+	for rows.Next() {
+		var pullRequest models.PullRequestShort
+		err = rows.Scan(&pullRequest.ID, &pullRequest.Name, &pullRequest.AuthorID,
+			&pullRequest.Status, &pullRequest.CreatedAt)
+		if err != nil {
+			return nil, wrapDBError(err, "LockOpenReviews: scan row")
+		}
+
+		pullRequests = append(pullRequests, &pullRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(err, "LockOpenReviews: iterate rows")
+	}
+
+	return pullRequests, nil
+}
+
+// LockPullRequest returns the pull request with its reviewers, nil when it doesn't exist.
+// The transaction already holds the write lock of the database, so the row needs no lock of its own.
+func (r *Repository) LockPullRequest(
+	ctx context.Context, tx models.Tx, pullRequestID string,
+) (*models.PullRequest, error) {
+	query, args, err := r.builder.
+		Select("id", "pr_name", "author_id", "pr_status", "version").
+		From("pull_requests").
+		Where(squirrel.Eq{"id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockPullRequest: build query")
+	}
+
+	var pr models.PullRequest
+	err = r.conn(tx).QueryRowContext(ctx, query, args...).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockPullRequest: query row")
+	}
+
+	reviewersQuery, reviewersArgs, err := r.builder.
+		Select("reviewer_id").
+		From("pr_reviewers").
+		Where(squirrel.Eq{"pr_id": pullRequestID}).
+		OrderBy("reviewer_id").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "LockPullRequest: build reviewers query")
+	}
+
+	pr.AssignedReviewers, err = r.selectStrings(ctx, r.conn(tx), reviewersQuery, reviewersArgs, "LockPullRequest")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+func (r *Repository) bumpPullRequestVersion(ctx context.Context, tx models.Tx, pullRequestID string) error {
+	query, args, err := r.builder.
+		Update("pull_requests").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": pullRequestID}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "bumpPullRequestVersion: build query")
+	}
+
+	if _, err = r.conn(tx).ExecContext(ctx, query, args...); err != nil {
+		return wrapDBError(err, "bumpPullRequestVersion: execute query")
+	}
+
+	return nil
+}
+
+// LockTeam returns the version of the team, see LockPullRequest.
+func (r *Repository) LockTeam(ctx context.Context, tx models.Tx, teamName string) (int64, error) {
+	query, args, err := r.builder.
+		Select("version").
+		From("teams").
+		Where(squirrel.Eq{"team_name": teamName}).
+		ToSql()
+
+	if err != nil {
+		return 0, wrapDBError(err, "LockTeam: build query")
+	}
+
+	var version int64
+	err = r.conn(tx).QueryRowContext(ctx, query, args...).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, repository.NotFound(repository.EntityTeam)
+	}
+
+	if err != nil {
+		return 0, wrapDBError(err, "LockTeam: query row")
+	}
+
+	return version, nil
+}
+
+// bumpTeamVersions increments the version of the teams whose members changed.
+func (r *Repository) bumpTeamVersions(ctx context.Context, tx models.Tx, where squirrel.Sqlizer) error {
+	query, args, err := r.builder.
+		Update("teams").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(where).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "bumpTeamVersions: build query")
+	}
+
+	if _, err = r.conn(tx).ExecContext(ctx, query, args...); err != nil {
+		return wrapDBError(err, "bumpTeamVersions: execute query")
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package sqlite_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/sqlite"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/sqlite/sqlitetest"
+)
+
+func addTeam(t *testing.T, repo *sqlite.Repository, teamName string, ids ...string) {
+	t.Helper()
+
+	tx, err := repo.BeginTx(t.Context())
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	defer func() { _ = tx.Rollback(t.Context()) }()
+
+	if err = repo.InsertTeam(t.Context(), tx, models.AddTeamRequest{Name: teamName}); err != nil {
+		t.Fatalf("InsertTeam() error = %v", err)
+	}
+	for _, id := range ids {
+		member := models.TeamMember{ID: id, Username: id, IsActive: true}
+		if err = repo.InsertTeamMember(t.Context(), tx, member, teamName); err != nil {
+			t.Fatalf("InsertTeamMember(%s) error = %v", id, err)
+		}
+	}
+
+	if err = tx.Commit(t.Context()); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+}
+
+// insertPullRequest runs InsertPullRequest in its own transaction.
+func insertPullRequest(
+	t *testing.T, repo *sqlite.Repository, request models.CreatePRRequest, reviewers []string,
+) (*models.PullRequest, error) {
+	t.Helper()
+
+	tx, err := repo.BeginTx(t.Context())
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	defer func() { _ = tx.Rollback(t.Context()) }()
+
+	events := make([]models.PullRequestEvent, 0, len(reviewers))
+	for _, reviewerID := range reviewers {
+		events = append(events, models.PullRequestEvent{
+			Type:          models.EventReviewerAssigned,
+			PullRequestID: request.ID,
+			ReviewerID:    reviewerID,
+		})
+	}
+
+	pr, err := repo.InsertPullRequest(t.Context(), tx, request, reviewers, events)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(t.Context()); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	return pr, nil
+}
+
+func TestInsertPullRequest(t *testing.T) {
+	repo := sqlitetest.NewRepository(t)
+	addTeam(t, repo, "backend", "u1", "u2", "u3")
+
+	request := models.CreatePRRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	pr, err := insertPullRequest(t, repo, request, []string{"u2", "u3"})
+	if err != nil {
+		t.Fatalf("InsertPullRequest() error = %v", err)
+	}
+
+	want := models.PullRequest{
+		ID:                "pr-1",
+		Name:              "Add search",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+		Version:           1,
+	}
+	if pr.ID != want.ID || pr.Name != want.Name || pr.AuthorID != want.AuthorID || pr.Status != want.Status ||
+		pr.Version != want.Version || !slices.Equal(pr.AssignedReviewers, want.AssignedReviewers) {
+		t.Errorf("InsertPullRequest() = %+v, want %+v", *pr, want)
+	}
+
+	details, err := repo.SelectPullRequestDetails(t.Context(), "pr-1")
+	if err != nil {
+		t.Fatalf("SelectPullRequestDetails() error = %v", err)
+	}
+	if details.AuthorTeam != "backend" || !slices.Equal(details.AssignedReviewers, []string{"u2", "u3"}) {
+		t.Errorf("stored pull request = %+v, want team backend and reviewers u2, u3", *details)
+	}
+
+	counts, err := repo.ExportBackup(t.Context(), func(models.BackupRecord) error { return nil })
+	if err != nil {
+		t.Fatalf("ExportBackup() error = %v", err)
+	}
+	if counts.Reviewers != 2 || counts.Events != 2 {
+		t.Errorf("stored %d reviewers and %d events, want 2 and 2", counts.Reviewers, counts.Events)
+	}
+}
+
+func TestInsertPullRequestWithoutReviewers(t *testing.T) {
+	repo := sqlitetest.NewRepository(t)
+	addTeam(t, repo, "backend", "u1")
+
+	pr, err := insertPullRequest(t, repo, models.CreatePRRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1"}, nil)
+	if err != nil {
+		t.Fatalf("InsertPullRequest() error = %v", err)
+	}
+	if pr.Status != "OPEN" || len(pr.AssignedReviewers) != 0 {
+		t.Errorf("InsertPullRequest() = %+v, want an open pull request without reviewers", *pr)
+	}
+}
+
+func TestInsertPullRequestErrors(t *testing.T) {
+	repo := sqlitetest.NewRepository(t)
+	addTeam(t, repo, "backend", "u1", "u2")
+
+	request := models.CreatePRRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	if _, err := insertPullRequest(t, repo, request, []string{"u2"}); err != nil {
+		t.Fatalf("InsertPullRequest() error = %v", err)
+	}
+
+	_, err := insertPullRequest(t, repo, request, []string{"u2"})
+	var conflict *repository.ErrConflict
+	if !errors.As(err, &conflict) || conflict.Entity != repository.EntityPullRequest {
+		t.Errorf("InsertPullRequest(existing) error = %v, want a pull request conflict", err)
+	}
+
+	request = models.CreatePRRequest{ID: "pr-2", Name: "Add search", AuthorID: "unknown"}
+	if _, err = insertPullRequest(t, repo, request, nil); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("InsertPullRequest(unknown author) error = %v, want %v", err, repository.ErrNotFound)
+	}
+
+	request = models.CreatePRRequest{ID: "pr-3", Name: "Add search", AuthorID: "u1"}
+	if _, err = insertPullRequest(t, repo, request, []string{"unknown"}); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("InsertPullRequest(unknown reviewer) error = %v, want %v", err, repository.ErrNotFound)
+	}
+	if details, _ := repo.SelectPullRequestDetails(t.Context(), "pr-3"); details != nil {
+		t.Errorf("pull request with an unknown reviewer was stored: %+v", *details)
+	}
+}
@@ -0,0 +1,161 @@
+// Package sqlite implements the service repository on an SQLite database file.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type SQLiteCfg struct {
+	Path string `env:"SQLITE_PATH" env-default:"pr-review.db"`
+}
+
+type Repository struct {
+	db      *sql.DB
+	builder squirrel.StatementBuilderType
+}
+
+func NewRepository(cfg SQLiteCfg) (*Repository, error) {
+	db, err := sql.Open("sqlite", dataSource(cfg.Path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err = db.PingContext(context.Background()); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	repo := Repository{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+	return &repo, nil
+}
+
+// dataSource enables foreign keys on every connection and makes transactions take the write lock
+// when they begin, so that concurrent writers wait for each other instead of failing.
+// Timestamps are stored as unix milliseconds, so that they compare as numbers.
+func dataSource(path string) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Set("_txlock", "immediate")
+	query.Set("_time_integer_format", "unix_milli")
+	query.Set("_inttotime", "true")
+
+	return "file:" + path + "?" + query.Encode()
+}
+
+func wrapDBError(err error, context string) error {
+	return fmt.Errorf("%w: %s: %w", repository.ErrDB, context, err)
+}
+
+func constraintViolation(err error, code int) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == code
+}
+
+func isUniqueViolation(err error) bool {
+	return constraintViolation(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) ||
+		constraintViolation(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+}
+
+func isForeignKeyViolation(err error) bool {
+	return constraintViolation(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY)
+}
+
+func (r *Repository) CloseConnection() {
+	_ = r.db.Close()
+}
+
+// transaction adapts sql.Tx to models.Tx, nested transactions are savepoints.
+type transaction struct {
+	tx        *sql.Tx
+	savepoint string
+	depth     int
+	closed    bool
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (models.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrapDBError(err, "BeginTx")
+	}
+
+	return &transaction{tx: tx}, nil
+}
+
+func (t *transaction) Begin(ctx context.Context) (models.Tx, error) {
+	name := fmt.Sprintf("sp_%d", t.depth+1)
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, wrapDBError(err, "Begin: create savepoint")
+	}
+
+	return &transaction{tx: t.tx, savepoint: name, depth: t.depth + 1}, nil
+}
+
+func (t *transaction) Commit(ctx context.Context) error {
+	if t.savepoint == "" {
+		return t.tx.Commit()
+	}
+
+	if t.closed {
+		return sql.ErrTxDone
+	}
+	t.closed = true
+
+	_, err := t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+t.savepoint)
+	return err
+}
+
+func (t *transaction) Rollback(ctx context.Context) error {
+	if t.savepoint == "" {
+		return t.tx.Rollback()
+	}
+
+	if t.closed {
+		return sql.ErrTxDone
+	}
+	t.closed = true
+
+	if _, err := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+t.savepoint); err != nil {
+		return err
+	}
+
+	_, err := t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+t.savepoint)
+	return err
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction started by this repository or the database when tx is nil.
+func (r *Repository) conn(tx models.Tx) querier {
+	if tx == nil {
+		return r.db
+	}
+
+	return tx.(*transaction).tx
+}
+
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
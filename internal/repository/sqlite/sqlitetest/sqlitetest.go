@@ -0,0 +1,42 @@
+// Package sqlitetest opens SQLite repositories with all migrations applied, for tests of the packages above it.
+package sqlitetest
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/sqlite"
+)
+
+// NewRepository migrates a new database file in the temporary directory of the test and opens it.
+func NewRepository(t testing.TB) *sqlite.Repository {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	migrations := filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "migrations", "sqlite")
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	m, err := migrate.New("file://"+migrations, "sqlite://"+path)
+	if err != nil {
+		t.Fatalf("failed to create migrate instance: %v", err)
+	}
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if sourceErr, dbErr := m.Close(); sourceErr != nil || dbErr != nil {
+		t.Fatalf("failed to close migrate instance: %v, %v", sourceErr, dbErr)
+	}
+
+	repo, err := sqlite.NewRepository(sqlite.SQLiteCfg{Path: path})
+	if err != nil {
+		t.Fatalf("sqlite.NewRepository() error = %v", err)
+	}
+	t.Cleanup(repo.CloseConnection)
+
+	return repo
+}
@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+)
+
+func (r *Repository) selectUserDetails() squirrel.SelectBuilder {
+	return r.builder.
+		Select(
+			"u.id",
+			"COALESCE(u.user_name, '')",
+			"COALESCE(u.email, '')",
+			"u.team_name",
+			"u.is_active",
+			"(SELECT COUNT(*) FROM pr_reviewers prr JOIN pull_requests pr ON pr.id = prr.pr_id "+
+				"WHERE prr.reviewer_id = u.id AND pr.pr_status = 'OPEN')",
+			"(SELECT COUNT(*) FROM pull_requests pr WHERE pr.author_id = u.id AND pr.pr_status = 'OPEN')",
+		).
+		From("users u")
+}
+
+func scanUserDetails(row scanner) (*models.UserDetails, error) {
+	var user models.UserDetails
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.TeamName, &user.IsActive,
+		&user.OpenReviews, &user.OpenPullRequests)
+
+	return &user, err
+}
+
+func (r *Repository) SelectUserDetails(ctx context.Context, userID string) (*models.UserDetails, error) {
+	query, args, err := r.selectUserDetails().
+		Where(squirrel.Eq{"u.id": userID}).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectUserDetails: build query")
+	}
+
+	user, err := scanUserDetails(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.NotFound("user")
+		}
+		return nil, wrapDBError(err, "SelectUserDetails: query row")
+	}
+
+	return user, nil
+}
+
+// SelectUsers returns a page of users ordered by id and the cursor of the next page.
+func (r *Repository) SelectUsers(
+	ctx context.Context, filter models.ListUsersRequest,
+) ([]*models.UserDetails, string, error) {
+	const sort = "user_id:asc"
+	after, err := pagination.Decode(filter.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder := r.selectUserDetails().
+		OrderBy("u.id").
+		Limit(uint64(filter.Limit) + 1)
+
+	if filter.TeamName != "" {
+		builder = builder.Where(squirrel.Eq{"u.team_name": filter.TeamName})
+	}
+
+	if filter.IsActive != nil {
+		builder = builder.Where(squirrel.Eq{"u.is_active": *filter.IsActive})
+	}
+
+	if filter.NamePrefix != "" {
+		builder = builder.Where(`LOWER(u.user_name) LIKE ? ESCAPE '\'`, likePrefix(strings.ToLower(filter.NamePrefix)))
+	}
+
+	if after != nil {
+		builder = builder.Where(squirrel.Gt{"u.id": after.ID})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectUsers: build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", wrapDBError(err, "SelectUsers: execute query")
+	}
+	defer rows.Close()
+
+	users := make([]*models.UserDetails, 0, filter.Limit)
+	for rows.Next() {
+		user, err := scanUserDetails(rows)
+		if err != nil {
+			return nil, "", wrapDBError(err, "SelectUsers: scan row")
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", wrapDBError(err, "SelectUsers: iterate rows")
+	}
+
+	var next string
+	if len(users) > filter.Limit {
+		users = users[:filter.Limit]
+		next = pagination.Encode(pagination.Cursor{Sort: sort, ID: users[len(users)-1].ID})
+	}
+
+	return users, next, nil
+}
+
+// likePrefix escapes LIKE wildcards so that the value matches literally as a prefix.
+func likePrefix(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value) + "%"
+}
@@ -171,3 +171,38 @@ func (r *Repository) SelectReviewerStats(ctx context.Context) (*models.Reviewers
 
 	return stats, nil
 }
+
+func (r *Repository) SelectOpenReviewsByTeam(ctx context.Context) ([]models.TeamOpenReviews, error) {
+	query, args, err := r.builder.
+		Select("t.team_name", "COUNT(pr.id) AS open_reviews").
+		From("teams t").
+		LeftJoin("users u ON u.team_name = t.team_name").
+		LeftJoin("pr_reviewers prr ON prr.reviewer_id = u.id").
+		LeftJoin("pull_requests pr ON pr.id = prr.pr_id AND pr.pr_status = 'OPEN'").
+		GroupBy("t.team_name").
+		OrderBy("t.team_name").
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectOpenReviewsByTeam: build query")
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, wrapDBError(err, "SelectOpenReviewsByTeam: execute query")
+	}
+	defer rows.Close()
+
+	var teams []models.TeamOpenReviews
+	for rows.Next() {
+		var team models.TeamOpenReviews
+		err = rows.Scan(&team.TeamName, &team.OpenReviews)
+		if err != nil {
+			return nil, wrapDBError(err, "SelectOpenReviewsByTeam: scan row")
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (r *Repository) InsertAPIToken(ctx context.Context, token models.APIToken) (int64, error) {
+	query, args, err := r.builder.
+		Insert("api_tokens").
+		Columns("token_name", "token_hash", "token_role", "user_id", "expires_at").
+		Values(token.Name, token.Hash, token.Role, nullableString(token.UserID), token.ExpiresAt).
+		Suffix("ON CONFLICT (token_hash) DO UPDATE SET token_hash = EXCLUDED.token_hash RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, wrapDBError(err, "InsertAPIToken: build query")
+	}
+
+	var id int64
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return 0, NotFound("user")
+		}
+		return 0, wrapDBError(err, "InsertAPIToken: execute query")
+	}
+
+	return id, nil
+}
+
+func (r *Repository) SelectAPIToken(ctx context.Context, hash string) (*models.APIToken, error) {
+	query, args, err := r.builder.
+		Select("t.id", "t.token_name", "t.token_hash", "t.token_role",
+			"COALESCE(t.user_id, '')", "COALESCE(u.team_name, '')", "COALESCE(u.is_active, t.user_id IS NULL)",
+			"t.expires_at", "t.revoked_at").
+		From("api_tokens t").
+		LeftJoin("users u ON u.id = t.user_id").
+		Where(squirrel.Eq{"t.token_hash": hash}).
+		ToSql()
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectAPIToken: build query")
+	}
+
+	var token models.APIToken
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&token.ID, &token.Name, &token.Hash, &token.Role,
+		&token.UserID, &token.TeamName, &token.UserIsActive, &token.ExpiresAt, &token.RevokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, wrapDBError(err, "SelectAPIToken: query row")
+	}
+
+	return &token, nil
+}
+
+func (r *Repository) RevokeAPIToken(ctx context.Context, tokenID int64) error {
+	query, args, err := r.builder.
+		Update("api_tokens").
+		Set("revoked_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": tokenID}).
+		Where(squirrel.Eq{"revoked_at": nil}).
+		ToSql()
+
+	if err != nil {
+		return wrapDBError(err, "RevokeAPIToken: build query")
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return wrapDBError(err, "RevokeAPIToken: execute query")
+	}
+
+	if result.RowsAffected() == 0 {
+		return NotFound("token")
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+func (s *Service) Authenticate(ctx context.Context, rawToken string) (*models.Caller, *models.ErrDetails) {
+	token, err := s.repository.SelectAPIToken(ctx, auth.HashToken(rawToken))
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if token == nil || token.RevokedAt != nil || (token.ExpiresAt != nil && token.ExpiresAt.Before(time.Now())) {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("Authenticate: invalid token")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.UnauthorizedErr, Message: "invalid or expired token"}
+	}
+
+	// The token stops working as soon as its user is deactivated, as an OIDC identity does.
+	if !token.UserIsActive {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("Authenticate: user is inactive")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.UnauthorizedErr, Message: "user is inactive"}
+	}
+
+	caller := &models.Caller{
+		TokenID:  token.ID,
+		UserID:   token.UserID,
+		TeamName: token.TeamName,
+		Role:     token.Role,
+	}
+	return caller, nil
+}
+
+// AuthenticateIdentity maps an identity verified by the OIDC provider to a user of the service.
+func (s *Service) AuthenticateIdentity(ctx context.Context, identity models.Identity) (*models.Caller, *models.ErrDetails) {
+	var user models.User
+	var err error
+	if identity.Email != "" {
+		user, err = s.repository.SelectUserByEmail(ctx, identity.Email)
+	} else {
+		user, err = s.repository.SelectUser(ctx, identity.UserID)
+	}
+
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, mapRepositoryError(err)
+		}
+
+		zap.L().Info("business logic error",
+			zap.Error(fmt.Errorf("AuthenticateIdentity: %w", err)),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.UnauthorizedErr, Message: "unknown user"}
+	}
+
+	if !user.IsActive {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("AuthenticateIdentity: user is inactive")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.UnauthorizedErr, Message: "user is inactive"}
+	}
+
+	caller := &models.Caller{
+		UserID:   user.ID,
+		TeamName: user.TeamName,
+		Role:     identity.Role,
+	}
+	return caller, nil
+}
+
+func (s *Service) CreateAPIToken(
+	ctx context.Context,
+	request models.CreateAPITokenRequest,
+) (*models.CreateAPITokenResponse, *models.ErrDetails) {
+	if errDetails := validationFailed("CreateAPIToken", request.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+
+	rawToken, err := auth.GenerateToken()
+	if err != nil {
+		zap.L().Error("server error",
+			zap.Error(fmt.Errorf("CreateAPIToken: %w", err)),
+			zap.String("type", "technical"))
+
+		return nil, &models.ErrDetails{Code: models.InternalErr, Message: "service unavailable, try again later"}
+	}
+
+	id, err := s.repository.InsertAPIToken(ctx, models.APIToken{
+		Name:      request.Name,
+		Hash:      auth.HashToken(rawToken),
+		Role:      request.Role,
+		UserID:    request.UserID,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	resp := &models.CreateAPITokenResponse{
+		ID:        id,
+		Token:     rawToken,
+		Name:      request.Name,
+		Role:      request.Role,
+		UserID:    request.UserID,
+		ExpiresAt: request.ExpiresAt,
+	}
+	return resp, nil
+}
+
+// EnsureAdminToken registers a statically configured admin token, so the first real tokens can be issued.
+func (s *Service) EnsureAdminToken(ctx context.Context, rawToken string) *models.ErrDetails {
+	_, err := s.repository.InsertAPIToken(ctx, models.APIToken{
+		Name: "bootstrap admin",
+		Hash: auth.HashToken(rawToken),
+		Role: models.RoleAdmin,
+	})
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+
+	return nil
+}
+
+func (s *Service) RevokeAPIToken(ctx context.Context, tokenID int64) *models.ErrDetails {
+	if err := s.repository.RevokeAPIToken(ctx, tokenID); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	return nil
+}
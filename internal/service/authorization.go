@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+// authorizeTeamAction allows admins everything and the given roles only within their own team,
+// without roles the action is for admins only.
+// Requests without a caller are allowed, authentication is optional.
+func authorizeTeamAction(ctx context.Context, action, teamName string, roles ...string) *models.ErrDetails {
+	caller, ok := auth.CallerFromContext(ctx)
+	if !ok || caller.Role == models.RoleAdmin {
+		return nil
+	}
+
+	if slices.Contains(roles, caller.Role) && caller.TeamName != "" && caller.TeamName == teamName {
+		return nil
+	}
+
+	zap.L().Info("business logic error",
+		zap.Error(fmt.Errorf("%s: %s of team %q is not allowed to manage team %q",
+			action, caller.Role, caller.TeamName, teamName)),
+		zap.String("type", "business"))
+
+	return &models.ErrDetails{Code: models.ForbiddenErr, Message: "operation is not allowed for this team"}
+}
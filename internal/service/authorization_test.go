@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+// teamActions are the service calls that check the team of the caller, each one runs on a fresh service
+// with the teams backend (u1-u4, pr-1 by u1) and payments (p1, p2).
+func teamActions() map[string]func(ctx context.Context, s *service.Service, pr *models.PullRequest) *models.ErrDetails {
+	return map[string]func(context.Context, *service.Service, *models.PullRequest) *models.ErrDetails{
+		"AddTeam": func(ctx context.Context, s *service.Service, _ *models.PullRequest) *models.ErrDetails {
+			_, errDetails := s.AddTeam(ctx, models.AddTeamRequest{
+				Name:    "frontend",
+				Members: []models.TeamMember{{ID: "f1", Username: "user f1", IsActive: true}},
+			})
+			return errDetails
+		},
+		"SetUserStatus": func(ctx context.Context, s *service.Service, _ *models.PullRequest) *models.ErrDetails {
+			_, errDetails := s.SetUserStatus(ctx, models.SetUserStatusRequest{ID: "u4", IsActive: false})
+			return errDetails
+		},
+		"MergePullRequest": func(ctx context.Context, s *service.Service, pr *models.PullRequest) *models.ErrDetails {
+			_, errDetails := s.MergePullRequest(ctx, models.MergePRRequest{ID: pr.ID})
+			return errDetails
+		},
+		"ReassignPullRequestReviewer": func(
+			ctx context.Context, s *service.Service, pr *models.PullRequest,
+		) *models.ErrDetails {
+			_, _, errDetails := s.ReassignPullRequestReviewer(ctx, models.ReassignPRReviewerRequest{
+				PullRequestID: pr.ID,
+				OldReviewerID: pr.AssignedReviewers[0],
+			})
+			return errDetails
+		},
+	}
+}
+
+func TestTeamActionsAreAuthorized(t *testing.T) {
+	const (
+		allowed   = ""
+		forbidden = models.ForbiddenErr
+	)
+
+	callers := map[string]*models.Caller{
+		"no caller":           nil,
+		"admin":               {Role: models.RoleAdmin},
+		"team lead":           {Role: models.RoleTeamLead, UserID: "u2", TeamName: "backend"},
+		"other team lead":     {Role: models.RoleTeamLead, UserID: "p1", TeamName: "payments"},
+		"member":              {Role: models.RoleMember, UserID: "u2", TeamName: "backend"},
+		"other member":        {Role: models.RoleMember, UserID: "p1", TeamName: "payments"},
+		"read-only":           {Role: models.RoleReadOnly, UserID: "u2", TeamName: "backend"},
+		"member without team": {Role: models.RoleMember},
+	}
+
+	want := map[string]map[string]string{
+		"AddTeam": {
+			"no caller": allowed, "admin": allowed, "team lead": forbidden, "other team lead": forbidden,
+			"member": forbidden, "other member": forbidden, "read-only": forbidden, "member without team": forbidden,
+		},
+		"SetUserStatus": {
+			"no caller": allowed, "admin": allowed, "team lead": allowed, "other team lead": forbidden,
+			"member": forbidden, "other member": forbidden, "read-only": forbidden, "member without team": forbidden,
+		},
+		"MergePullRequest": {
+			"no caller": allowed, "admin": allowed, "team lead": allowed, "other team lead": forbidden,
+			"member": allowed, "other member": forbidden, "read-only": forbidden, "member without team": forbidden,
+		},
+		"ReassignPullRequestReviewer": {
+			"no caller": allowed, "admin": allowed, "team lead": allowed, "other team lead": forbidden,
+			"member": allowed, "other member": forbidden, "read-only": forbidden, "member without team": forbidden,
+		},
+	}
+
+	for action, call := range teamActions() {
+		for name, caller := range callers {
+			t.Run(action+"/"+name, func(t *testing.T) {
+				s, _ := newTestService(t)
+				addTeam(t, s, "backend", "u1", "u2", "u3", "u4")
+				addTeam(t, s, "payments", "p1", "p2")
+				pr := createPullRequest(t, s, "pr-1", "u1")
+
+				ctx := t.Context()
+				if caller != nil {
+					ctx = auth.WithCaller(ctx, caller)
+				}
+
+				var got string
+				if errDetails := call(ctx, s, pr); errDetails != nil {
+					got = errDetails.Code
+				}
+				if got != want[action][name] {
+					t.Errorf("%s by %s error code = %q, want %q", action, name, got, want[action][name])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	restoreBatchSize = 1000
+	maxRestoreErrors = 100
+)
+
+// ExportBackup writes a consistent snapshot of teams, users, pull requests, reviewers and events.
+// The header is written only after the snapshot is taken, so nothing is written if the database is unavailable.
+func (s *Service) ExportBackup(ctx context.Context, write func(models.BackupRecord) error) *models.ErrDetails {
+	var writeErr error
+	started := false
+	writeHeader := func() error {
+		started = true
+		header := models.BackupHeader{Version: models.BackupVersion, ExportedAt: time.Now().UTC()}
+		return write(models.BackupRecord{Type: models.BackupHeaderRecord, Header: &header})
+	}
+
+	emit := func(record models.BackupRecord) error {
+		if !started {
+			if writeErr = writeHeader(); writeErr != nil {
+				return writeErr
+			}
+		}
+
+		writeErr = write(record)
+		return writeErr
+	}
+
+	counts, err := s.repository.ExportBackup(ctx, emit)
+	if err != nil && writeErr == nil {
+		return mapRepositoryError(err)
+	}
+
+	if writeErr == nil && !started {
+		writeErr = writeHeader()
+	}
+	if writeErr == nil {
+		writeErr = write(models.BackupRecord{Type: models.BackupFooterRecord, Footer: counts})
+	}
+
+	if writeErr != nil {
+		zap.L().Warn("export interrupted",
+			zap.Error(fmt.Errorf("ExportBackup: %w", writeErr)),
+			zap.String("type", "technical"))
+
+		return &models.ErrDetails{Code: models.InternalErr, Message: "failed to write backup"}
+	}
+
+	return nil
+}
+
+// restoreState tracks what has been read so far to check the order and the references of backup records.
+type restoreState struct {
+	stage        int
+	footer       *models.BackupCounts
+	counts       models.BackupCounts
+	teams        map[string]bool
+	users        map[string]bool
+	emails       map[string]bool
+	pullRequests map[string]bool
+	reviewers    map[[2]string]bool
+	events       map[int64]bool
+	errs         []models.FieldError
+}
+
+// recordStage orders backup records, parents have to be restored before the records referencing them.
+func recordStage(recordType string) int {
+	switch recordType {
+	case models.BackupHeaderRecord:
+		return 1
+	case models.BackupTeamRecord:
+		return 2
+	case models.BackupUserRecord:
+		return 3
+	case models.BackupPullRequestRecord:
+		return 4
+	case models.BackupReviewerRecord:
+		return 5
+	case models.BackupEventRecord:
+		return 6
+	case models.BackupFooterRecord:
+		return 7
+	default:
+		return 0
+	}
+}
+
+// RestoreBackup loads a backup written by ExportBackup into an empty database in a single transaction.
+// Records are checked for order, duplicates and references first, nothing is applied if any of them fails.
+func (s *Service) RestoreBackup(
+	ctx context.Context, reader models.BackupReader,
+) (*models.BackupCounts, *models.ErrDetails) {
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("RestoreBackup: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if err = s.repository.LockBackupTables(ctx, tx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	existing, err := s.repository.CountBackupRows(ctx, tx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if *existing != (models.BackupCounts{}) {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("RestoreBackup: database is not empty")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: "backup can only be restored into an empty database",
+		}
+	}
+
+	state := &restoreState{
+		teams:        make(map[string]bool),
+		users:        make(map[string]bool),
+		emails:       make(map[string]bool),
+		pullRequests: make(map[string]bool),
+		reviewers:    make(map[[2]string]bool),
+		events:       make(map[int64]bool),
+	}
+
+	var batch []models.BackupRecord
+	flush := func() error {
+		if len(state.errs) > 0 {
+			batch = batch[:0]
+			return nil
+		}
+
+		err := s.repository.CopyBackupRecords(ctx, tx, batch)
+		batch = batch[:0]
+		return err
+	}
+
+	for len(state.errs) < maxRestoreErrors {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			state.errs = append(state.errs, backupLineError(reader.Line(), "", "format", err.Error()))
+			break
+		}
+
+		valid := state.check(reader.Line(), record)
+		if !valid || record.Type == models.BackupHeaderRecord || record.Type == models.BackupFooterRecord {
+			continue
+		}
+
+		if len(batch) > 0 && (batch[0].Type != record.Type || len(batch) >= restoreBatchSize) {
+			if err = flush(); err != nil {
+				return nil, mapRepositoryError(err)
+			}
+		}
+		batch = append(batch, *record)
+	}
+
+	if len(state.errs) < maxRestoreErrors {
+		state.finish(reader.Line())
+	}
+
+	if len(state.errs) > 0 {
+		zap.L().Info("business logic error",
+			zap.Error(fmt.Errorf("RestoreBackup: %d invalid records", len(state.errs))),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: "restore failed, nothing was applied",
+			Details: state.errs,
+		}
+	}
+
+	if err = flush(); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if err = s.repository.ResetEventSequence(ctx, tx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return &state.counts, nil
+}
+
+// check validates the record against the records read before it and counts it, it returns false for invalid ones.
+func (st *restoreState) check(line int, record *models.BackupRecord) bool {
+	errCount := len(st.errs)
+	fail := func(field, rule, message string) {
+		st.errs = append(st.errs, backupLineError(line, field, rule, message))
+	}
+
+	stage := recordStage(record.Type)
+	switch {
+	case stage == 0:
+		fail("type", "enum", fmt.Sprintf("unknown record type %q", record.Type))
+		return false
+	case st.stage == 0 && record.Type != models.BackupHeaderRecord:
+		fail("type", "order", "backup must start with a header")
+		return false
+	case st.stage == recordStage(models.BackupFooterRecord):
+		fail("type", "order", "no records are allowed after the footer")
+		return false
+	case stage < st.stage || (stage == st.stage && record.Type == models.BackupHeaderRecord):
+		fail("type", "order", fmt.Sprintf("%s record is out of order, records go in the order: "+
+			"header, teams, users, pull requests, reviewers, events, footer", record.Type))
+		return false
+	}
+	st.stage = stage
+
+	switch record.Type {
+	case models.BackupHeaderRecord:
+		if record.Header == nil {
+			fail("header", "required", "field is required")
+		} else if record.Header.Version != 1 && record.Header.Version != models.BackupVersion {
+			fail("header.version", "enum", fmt.Sprintf("unsupported backup version %d", record.Header.Version))
+		}
+	case models.BackupTeamRecord:
+		st.checkTeam(record.Team, fail)
+	case models.BackupUserRecord:
+		st.checkUser(record.User, fail)
+	case models.BackupPullRequestRecord:
+		st.checkPullRequest(record.PullRequest, fail)
+	case models.BackupReviewerRecord:
+		st.checkReviewer(record.Reviewer, fail)
+	case models.BackupEventRecord:
+		st.checkEvent(record.Event, fail)
+	case models.BackupFooterRecord:
+		if record.Footer == nil {
+			fail("footer", "required", "field is required")
+		}
+		st.footer = record.Footer
+	}
+
+	return len(st.errs) == errCount
+}
+
+type failFunc func(field, rule, message string)
+
+func (st *restoreState) checkTeam(team *models.BackupTeam, fail failFunc) {
+	if team == nil {
+		fail("team", "required", "field is required")
+		return
+	}
+
+	checkBackupString(fail, "team.team_name", team.Name, 255)
+	if st.teams[team.Name] {
+		fail("team.team_name", "unique", fmt.Sprintf("team %q is duplicated", team.Name))
+	}
+
+	team.Version = checkBackupVersion(fail, "team.version", team.Version)
+
+	st.teams[team.Name] = true
+	st.counts.Teams++
+}
+
+func (st *restoreState) checkUser(user *models.BackupUser, fail failFunc) {
+	if user == nil {
+		fail("user", "required", "field is required")
+		return
+	}
+
+	checkBackupString(fail, "user.user_id", user.ID, 10)
+	if st.users[user.ID] {
+		fail("user.user_id", "unique", fmt.Sprintf("user %q is duplicated", user.ID))
+	}
+
+	if user.Username != nil && utf8.RuneCountInString(*user.Username) > 255 {
+		fail("user.username", "maxLength", "must be at most 255 characters long")
+	}
+
+	if user.Email != nil {
+		if utf8.RuneCountInString(*user.Email) > 255 {
+			fail("user.email", "maxLength", "must be at most 255 characters long")
+		}
+		if st.emails[*user.Email] {
+			fail("user.email", "unique", fmt.Sprintf("email %q is duplicated", *user.Email))
+		}
+		st.emails[*user.Email] = true
+	}
+
+	if !st.teams[user.TeamName] {
+		fail("user.team_name", "reference", fmt.Sprintf("team %q is not in the backup", user.TeamName))
+	}
+
+	st.users[user.ID] = true
+	st.counts.Users++
+}
+
+func (st *restoreState) checkPullRequest(pr *models.BackupPullRequest, fail failFunc) {
+	if pr == nil {
+		fail("pull_request", "required", "field is required")
+		return
+	}
+
+	checkBackupString(fail, "pull_request.pull_request_id", pr.ID, 100)
+	if st.pullRequests[pr.ID] {
+		fail("pull_request.pull_request_id", "unique", fmt.Sprintf("pull request %q is duplicated", pr.ID))
+	}
+
+	checkBackupString(fail, "pull_request.pull_request_name", pr.Name, 255)
+
+	if !st.users[pr.AuthorID] {
+		fail("pull_request.author_id", "reference", fmt.Sprintf("user %q is not in the backup", pr.AuthorID))
+	}
+
+	if pr.Status != "OPEN" && pr.Status != "MERGED" {
+		fail("pull_request.status", "enum", "must be one of [OPEN MERGED]")
+	}
+
+	if pr.CreatedAt.IsZero() {
+		fail("pull_request.created_at", "required", "field is required")
+	}
+
+	pr.Version = checkBackupVersion(fail, "pull_request.version", pr.Version)
+
+	st.pullRequests[pr.ID] = true
+	st.counts.PullRequests++
+}
+
+func (st *restoreState) checkReviewer(reviewer *models.BackupReviewer, fail failFunc) {
+	if reviewer == nil {
+		fail("reviewer", "required", "field is required")
+		return
+	}
+
+	if !st.pullRequests[reviewer.PullRequestID] {
+		fail("reviewer.pull_request_id", "reference",
+			fmt.Sprintf("pull request %q is not in the backup", reviewer.PullRequestID))
+	}
+
+	if !st.users[reviewer.ReviewerID] {
+		fail("reviewer.reviewer_id", "reference", fmt.Sprintf("user %q is not in the backup", reviewer.ReviewerID))
+	}
+
+	key := [2]string{reviewer.PullRequestID, reviewer.ReviewerID}
+	if st.reviewers[key] {
+		fail("reviewer", "unique", "reviewer assignment is duplicated")
+	}
+
+	if reviewer.AssignedAt.IsZero() {
+		fail("reviewer.assigned_at", "required", "field is required")
+	}
+
+	st.reviewers[key] = true
+	st.counts.Reviewers++
+}
+
+func (st *restoreState) checkEvent(event *models.BackupEvent, fail failFunc) {
+	if event == nil {
+		fail("event", "required", "field is required")
+		return
+	}
+
+	if event.ID <= 0 {
+		fail("event.id", "minimum", "must be at least 1")
+	}
+	if st.events[event.ID] {
+		fail("event.id", "unique", fmt.Sprintf("event %d is duplicated", event.ID))
+	}
+
+	if !st.pullRequests[event.PullRequestID] {
+		fail("event.pull_request_id", "reference",
+			fmt.Sprintf("pull request %q is not in the backup", event.PullRequestID))
+	}
+
+	checkBackupString(fail, "event.event_type", event.Type, 32)
+
+	if event.ReviewerID != nil && !st.users[*event.ReviewerID] {
+		fail("event.reviewer_id", "reference", fmt.Sprintf("user %q is not in the backup", *event.ReviewerID))
+	}
+
+	if event.OldReviewerID != nil && !st.users[*event.OldReviewerID] {
+		fail("event.old_reviewer_id", "reference", fmt.Sprintf("user %q is not in the backup", *event.OldReviewerID))
+	}
+
+	if event.CreatedAt.IsZero() {
+		fail("event.created_at", "required", "field is required")
+	}
+
+	st.events[event.ID] = true
+	st.counts.Events++
+}
+
+// finish checks that the backup wasn't truncated: the footer is present and matches the records read.
+func (st *restoreState) finish(lastLine int) {
+	if st.stage == 0 {
+		st.errs = append(st.errs, backupLineError(1, "", "required", "backup is empty"))
+		return
+	}
+
+	if st.footer == nil {
+		st.errs = append(st.errs, backupLineError(lastLine, "", "required", "backup is truncated, footer is missing"))
+		return
+	}
+
+	if *st.footer != st.counts {
+		st.errs = append(st.errs, backupLineError(lastLine, "footer", "count",
+			fmt.Sprintf("footer counts %+v don't match the records %+v", *st.footer, st.counts)))
+	}
+}
+
+func checkBackupString(fail failFunc, field, value string, maxLength int) {
+	switch length := utf8.RuneCountInString(value); {
+	case length == 0:
+		fail(field, "required", "must not be empty")
+	case length > maxLength:
+		fail(field, "maxLength", fmt.Sprintf("must be at most %d characters long", maxLength))
+	}
+}
+
+// checkBackupVersion returns the version to restore, backups of the first format have none and start at 1.
+func checkBackupVersion(fail failFunc, field string, version int64) int64 {
+	switch {
+	case version == 0:
+		return 1
+	case version < 0:
+		fail(field, "minimum", "must be at least 1")
+	}
+
+	return version
+}
+
+func backupLineError(line int, field, rule, message string) models.FieldError {
+	path := fmt.Sprintf("lines[%d]", line)
+	if field != "" {
+		path += "." + field
+	}
+
+	return models.FieldError{Field: path, Rule: rule, Message: message}
+}
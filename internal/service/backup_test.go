@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/backup"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+// exportBackup returns the lines of the NDJSON backup of the service.
+func exportBackup(t *testing.T, s *service.Service) []string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if errDetails := s.ExportBackup(t.Context(), backup.NewEncoder(&buf).Write); errDetails != nil {
+		t.Fatalf("ExportBackup() error = %+v", errDetails)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasPrefix(lines[0], `{"type":"header","header":{"version":2,`) {
+		t.Fatalf("backup starts with %s, want a version 2 header", lines[0])
+	}
+
+	return lines
+}
+
+func restoreBackup(t *testing.T, s *service.Service, data string) (*models.BackupCounts, *models.ErrDetails) {
+	t.Helper()
+
+	return s.RestoreBackup(t.Context(), backup.NewDecoder(strings.NewReader(data)))
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			source := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			team := models.AddTeamRequest{Name: "backend", Members: []models.TeamMember{
+				{ID: "u1", Username: "Alice", Email: "alice@example.com", IsActive: true},
+				{ID: "u2", Username: "Bob", IsActive: true},
+				{ID: "u3", Username: "Carl", IsActive: true},
+				{ID: "u4", Username: "Dana", IsActive: true},
+			}}
+			if _, errDetails := source.AddTeam(t.Context(), team); errDetails != nil {
+				t.Fatalf("AddTeam() error = %+v", errDetails)
+			}
+			addTeam(t, source, "payments", "p1")
+
+			first := createPullRequest(t, source, "pr-1", "u1")
+			createPullRequest(t, source, "pr-2", "u2")
+			_, _, errDetails := source.ReassignPullRequestReviewer(t.Context(), models.ReassignPRReviewerRequest{
+				PullRequestID: "pr-1", OldReviewerID: first.AssignedReviewers[0],
+			})
+			if errDetails != nil {
+				t.Fatalf("ReassignPullRequestReviewer() error = %+v", errDetails)
+			}
+			_, errDetails = source.MergePullRequest(t.Context(), models.MergePRRequest{ID: "pr-2"})
+			if errDetails != nil {
+				t.Fatalf("MergePullRequest() error = %+v", errDetails)
+			}
+			_, errDetails = source.SetUserStatus(t.Context(), models.SetUserStatusRequest{ID: "p1"})
+			if errDetails != nil {
+				t.Fatalf("SetUserStatus() error = %+v", errDetails)
+			}
+
+			exported := exportBackup(t, source)
+
+			target := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			counts, errDetails := restoreBackup(t, target, strings.Join(exported, "\n"))
+			if errDetails != nil {
+				t.Fatalf("RestoreBackup() error = %+v", errDetails)
+			}
+			want := models.BackupCounts{Teams: 2, Users: 5, PullRequests: 2, Reviewers: 4, Events: counts.Events}
+			if *counts != want || counts.Events == 0 {
+				t.Errorf("RestoreBackup() = %+v, want %+v and the events", *counts, want)
+			}
+
+			// The header holds the export time, the rest has to match line by line.
+			if restored := exportBackup(t, target); !slices.Equal(restored[1:], exported[1:]) {
+				t.Errorf("restored backup differs:\n%s\nwant:\n%s",
+					strings.Join(restored, "\n"), strings.Join(exported, "\n"))
+			}
+
+			for _, id := range []string{"pr-1", "pr-2"} {
+				before, _ := source.GetPullRequest(t.Context(), id)
+				after, errDetails := target.GetPullRequest(t.Context(), id)
+				if errDetails != nil {
+					t.Fatalf("GetPullRequest(%s) error = %+v", id, errDetails)
+				}
+				if after.Version != before.Version {
+					t.Errorf("%s version after restore = %d, want %d", id, after.Version, before.Version)
+				}
+			}
+			if pr, _ := target.GetPullRequest(t.Context(), "pr-1"); pr.Version < 2 {
+				t.Errorf("reassigned pr-1 version after restore = %d, want it above 1", pr.Version)
+			}
+
+			teamBefore, _ := source.GetTeam(t.Context(), "backend")
+			teamAfter, errDetails := target.GetTeam(t.Context(), "backend")
+			if errDetails != nil || teamAfter.Version != teamBefore.Version {
+				t.Errorf("GetTeam() after restore = %+v, %+v, want version %d",
+					teamAfter, errDetails, teamBefore.Version)
+			}
+
+			// New events go after the restored ones.
+			createPullRequest(t, target, "pr-3", "u3")
+			if lines := exportBackup(t, target); len(lines) <= len(exported) {
+				t.Errorf("backup after a new pull request has %d lines, want more than %d",
+					len(lines), len(exported))
+			}
+		})
+	}
+}
+
+func TestRestoreBackupRejectsNonEmptyDatabase(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addTeam(t, s, "backend", "u1")
+			exported := exportBackup(t, s)
+
+			_, errDetails := restoreBackup(t, s, strings.Join(exported, "\n"))
+			if errDetails == nil || errDetails.Code != models.InvalidReqErr ||
+				errDetails.Message != "backup can only be restored into an empty database" {
+				t.Errorf("RestoreBackup(non-empty database) error = %+v, want an empty database required", errDetails)
+			}
+		})
+	}
+}
+
+func TestRestoreBackupReportsDanglingReferences(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+
+			data := strings.Join([]string{
+				`{"type":"header","header":{"version":2}}`,
+				`{"type":"team","team":{"team_name":"backend","version":1}}`,
+				`{"type":"user","user":{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true}}`,
+				`{"type":"user","user":{"user_id":"u2","username":"Bob","team_name":"payments","is_active":true}}`,
+				`{"type":"pull_request","pull_request":{"pull_request_id":"pr-1","pull_request_name":"Add search",` +
+					`"author_id":"u3","status":"OPEN","created_at":"2025-10-24T12:00:00Z","version":1}}`,
+				`{"type":"footer","footer":{"teams":1,"users":2,"pull_requests":1,"reviewers":0,"events":0}}`,
+			}, "\n")
+
+			_, errDetails := restoreBackup(t, s, data)
+			if errDetails == nil || errDetails.Code != models.InvalidReqErr {
+				t.Fatalf("RestoreBackup() error = %+v, want %s", errDetails, models.InvalidReqErr)
+			}
+
+			want := []string{"lines[4].user.team_name:reference", "lines[5].pull_request.author_id:reference"}
+			if got := detailFields(errDetails); !slices.Equal(got, want) {
+				t.Errorf("RestoreBackup() details = %v, want %v", got, want)
+			}
+
+			if _, errDetails = s.GetTeam(t.Context(), "backend"); errDetails == nil {
+				t.Error("team backend was restored from an invalid backup")
+			}
+		})
+	}
+}
+
+func TestRestoreBackupOfFirstVersionStartsVersionsAtOne(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+
+			data := strings.Join([]string{
+				`{"type":"header","header":{"version":1}}`,
+				`{"type":"team","team":{"team_name":"backend"}}`,
+				`{"type":"user","user":{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true}}`,
+				`{"type":"pull_request","pull_request":{"pull_request_id":"pr-1","pull_request_name":"Add search",` +
+					`"author_id":"u1","status":"OPEN","created_at":"2025-10-24T12:00:00Z"}}`,
+				`{"type":"footer","footer":{"teams":1,"users":1,"pull_requests":1,"reviewers":0,"events":0}}`,
+			}, "\n")
+
+			if _, errDetails := restoreBackup(t, s, data); errDetails != nil {
+				t.Fatalf("RestoreBackup() error = %+v", errDetails)
+			}
+
+			team, errDetails := s.GetTeam(t.Context(), "backend")
+			if errDetails != nil || team.Version != 1 {
+				t.Errorf("GetTeam() = %+v, %+v, want version 1", team, errDetails)
+			}
+			pr, errDetails := s.GetPullRequest(t.Context(), "pr-1")
+			if errDetails != nil || pr.Version != 1 {
+				t.Errorf("GetPullRequest() = %+v, %+v, want version 1", pr, errDetails)
+			}
+		})
+	}
+}
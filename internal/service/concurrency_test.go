@@ -0,0 +1,227 @@
+package service_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+// stress runs creates, reassignments, merges and status changes within one team from several goroutines.
+type stress struct {
+	t       *testing.T
+	service *service.Service
+	users   []string
+	// prefix keeps the pull request ids of a run apart in a database shared with other runs.
+	prefix string
+
+	mu           sync.Mutex
+	pullRequests []string
+}
+
+// expectedError reports the outcomes of a request that lost a race with another one.
+func expectedError(errDetails *models.ErrDetails) bool {
+	switch errDetails.Code {
+	case models.NotAssignedErr, models.NoCandidateErr, models.PRMergedErr:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *stress) check(operation string, errDetails *models.ErrDetails) {
+	if errDetails != nil && !expectedError(errDetails) {
+		s.t.Errorf("%s error = %+v", operation, errDetails)
+	}
+}
+
+func (s *stress) randomPullRequest(rnd *rand.Rand) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pullRequests) == 0 {
+		return "", false
+	}
+
+	return s.pullRequests[rnd.IntN(len(s.pullRequests))], true
+}
+
+func (s *stress) worker(worker, operations int) {
+	ctx := s.t.Context()
+	rnd := rand.New(rand.NewPCG(uint64(worker), uint64(operations)))
+
+	for i := range operations {
+		user := s.users[rnd.IntN(len(s.users))]
+
+		switch op := rnd.IntN(10); {
+		case op < 4:
+			id := fmt.Sprintf("%spr-%d-%d", s.prefix, worker, i)
+			_, errDetails := s.service.CreatePullRequest(ctx, models.CreatePRRequest{ID: id, Name: id, AuthorID: user})
+			s.check("CreatePullRequest", errDetails)
+			if errDetails == nil {
+				s.mu.Lock()
+				s.pullRequests = append(s.pullRequests, id)
+				s.mu.Unlock()
+			}
+		case op < 7:
+			id, ok := s.randomPullRequest(rnd)
+			if !ok {
+				continue
+			}
+
+			pr, errDetails := s.service.GetPullRequest(ctx, id)
+			s.check("GetPullRequest", errDetails)
+			if errDetails != nil || len(pr.AssignedReviewers) == 0 {
+				continue
+			}
+
+			oldReviewer := pr.AssignedReviewers[rnd.IntN(len(pr.AssignedReviewers))]
+			_, _, errDetails = s.service.ReassignPullRequestReviewer(ctx, models.ReassignPRReviewerRequest{
+				PullRequestID: id, OldReviewerID: oldReviewer,
+			})
+			s.check("ReassignPullRequestReviewer", errDetails)
+		case op < 8:
+			if id, ok := s.randomPullRequest(rnd); ok {
+				_, errDetails := s.service.MergePullRequest(ctx, models.MergePRRequest{ID: id})
+				s.check("MergePullRequest", errDetails)
+			}
+		default:
+			_, errDetails := s.service.SetUserStatus(ctx, models.SetUserStatusRequest{ID: user, IsActive: op == 9})
+			s.check("SetUserStatus", errDetails)
+		}
+	}
+}
+
+// assertInvariants checks every pull request: at most two distinct reviewers, never the author,
+// and only active reviewers on open pull requests, since deactivation takes the user off them.
+func (s *stress) assertInvariants() {
+	for _, id := range s.pullRequests {
+		pr, errDetails := s.service.GetPullRequest(s.t.Context(), id)
+		if errDetails != nil {
+			s.t.Errorf("GetPullRequest(%s) error = %+v", id, errDetails)
+			continue
+		}
+
+		reviewers := slices.Clone(pr.AssignedReviewers)
+		slices.Sort(reviewers)
+		if len(reviewers) > 2 || len(slices.Compact(reviewers)) != len(pr.AssignedReviewers) {
+			s.t.Errorf("%s reviewers = %v, want at most two distinct reviewers", id, pr.AssignedReviewers)
+		}
+
+		for _, reviewer := range pr.Reviewers {
+			if reviewer.UserID == pr.AuthorID {
+				s.t.Errorf("%s is reviewed by its author %s", id, pr.AuthorID)
+			}
+			if pr.Status == "OPEN" && !reviewer.IsActive {
+				s.t.Errorf("open %s is reviewed by inactive %s", id, reviewer.UserID)
+			}
+		}
+	}
+}
+
+// assertLoads checks the review load of every user against the pull requests, as a serialized run would leave it:
+// each open review is counted once, nobody reviews more open pull requests than others authored,
+// and inactive users review nothing.
+func (s *stress) assertLoads() {
+	reviews := make(map[string]int)
+	openByAuthor := make(map[string]int)
+	open := 0
+	for _, id := range s.pullRequests {
+		pr, errDetails := s.service.GetPullRequest(s.t.Context(), id)
+		if errDetails != nil || pr.Status != "OPEN" {
+			continue
+		}
+
+		open++
+		openByAuthor[pr.AuthorID]++
+		for _, reviewer := range pr.AssignedReviewers {
+			reviews[reviewer]++
+		}
+	}
+
+	for _, id := range s.users {
+		user, errDetails := s.service.GetUser(s.t.Context(), id)
+		if errDetails != nil {
+			s.t.Errorf("GetUser(%s) error = %+v", id, errDetails)
+			continue
+		}
+
+		if user.OpenReviews != reviews[id] {
+			s.t.Errorf("%s has %d open reviews, %d open pull requests list it", id, user.OpenReviews, reviews[id])
+		}
+		if limit := open - openByAuthor[id]; reviews[id] > limit {
+			s.t.Errorf("%s reviews %d open pull requests, only %d are authored by others", id, reviews[id], limit)
+		}
+		if !user.IsActive && reviews[id] > 0 {
+			s.t.Errorf("inactive %s still reviews %d open pull requests", id, reviews[id])
+		}
+	}
+}
+
+// run starts the workers and checks the pull requests they leave behind.
+func (s *stress) run(workers, operations int) {
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Go(func() { s.worker(worker, operations) })
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		s.t.Fatal("workers didn't finish in a minute, requests are deadlocked")
+	}
+
+	if len(s.pullRequests) == 0 {
+		s.t.Fatal("no pull request was created")
+	}
+	s.assertInvariants()
+	s.assertLoads()
+}
+
+func TestConcurrentAssignmentKeepsInvariants(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := &stress{
+				t:       t,
+				service: service.NewService(newRepository(t), &recordingNotifier{}, metrics.New()),
+				users:   []string{"u1", "u2", "u3", "u4", "u5", "u6"},
+			}
+			addTeam(t, s.service, "backend", s.users...)
+
+			s.run(8, 40)
+		})
+	}
+}
+
+// TestConcurrentAssignmentKeepsInvariantsOnPostgres runs hundreds of requests against the migrated database
+// configured by the POSTGRES_* variables, where they really run in parallel on separate connections.
+// Users and pull requests get ids of their own, so the database may hold other data.
+func TestConcurrentAssignmentKeepsInvariantsOnPostgres(t *testing.T) {
+	repo := newPostgresRepository(t)
+
+	// user_id holds 10 characters, so the run is told apart by 6 hex digits.
+	run := fmt.Sprintf("%06x", time.Now().UnixNano()&0xffffff)
+	s := &stress{
+		t:       t,
+		service: service.NewService(repo, &recordingNotifier{}, metrics.New()),
+		prefix:  "stress-" + run + "-",
+	}
+	for i := range 8 {
+		s.users = append(s.users, fmt.Sprintf("s%s-u%d", run, i))
+	}
+	addTeam(t, s.service, "stress-"+run, s.users...)
+
+	s.run(32, 25)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+func (s *Service) GetTeamNames(ctx context.Context) ([]string, *models.ErrDetails) {
+	teams, err := s.repository.SelectTeamNames(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return teams, nil
+}
+
+// GetTeamDigest collects open reviews and authored open pull requests of every active team member.
+func (s *Service) GetTeamDigest(ctx context.Context, teamName string) (*models.TeamDigest, *models.ErrDetails) {
+	team, err := s.repository.SelectTeam(ctx, teamName)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if team == nil {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("GetTeamDigest: team not found")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "team not found"}
+	}
+
+	digest := &models.TeamDigest{
+		TeamName:    teamName,
+		GeneratedAt: time.Now(),
+	}
+
+	for _, member := range team.Members {
+		if !member.IsActive {
+			continue
+		}
+
+		reviews, _, err := s.repository.SelectUserReviews(ctx, models.UserReviewsRequest{
+			UserID: member.ID,
+			Status: "OPEN",
+		})
+		if err != nil {
+			return nil, mapRepositoryError(err)
+		}
+
+		authored, err := s.repository.SelectAuthoredPullRequests(ctx, member.ID, "OPEN")
+		if err != nil {
+			return nil, mapRepositoryError(err)
+		}
+
+		digest.Users = append(digest.Users, models.UserDigest{
+			User: models.User{
+				ID:       member.ID,
+				Username: member.Username,
+				Email:    member.Email,
+				TeamName: teamName,
+				IsActive: member.IsActive,
+			},
+			Reviews:         reviews,
+			AwaitingReviews: authored,
+		})
+	}
+
+	return digest, nil
+}
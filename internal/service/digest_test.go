@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func TestGetTeamDigestCarriesAssignmentTime(t *testing.T) {
+	s, _ := newTestService(t)
+	addTeam(t, s, "backend", "u1", "u2", "u3", "u4")
+
+	pr := createPullRequest(t, s, "pr-1", "u1")
+	if len(pr.AssignedReviewers) == 0 {
+		t.Fatal("CreatePullRequest() assigned no reviewers")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	oldReviewer := pr.AssignedReviewers[0]
+	_, newReviewer, errDetails := s.ReassignPullRequestReviewer(t.Context(), models.ReassignPRReviewerRequest{
+		PullRequestID: "pr-1",
+		OldReviewerID: oldReviewer,
+	})
+	if errDetails != nil {
+		t.Fatalf("ReassignPullRequestReviewer() error = %+v", errDetails)
+	}
+
+	digest, errDetails := s.GetTeamDigest(t.Context(), "backend")
+	if errDetails != nil {
+		t.Fatalf("GetTeamDigest() error = %+v", errDetails)
+	}
+
+	for _, user := range digest.Users {
+		switch user.User.ID {
+		case oldReviewer:
+			if len(user.Reviews) != 0 {
+				t.Errorf("old reviewer %s still has reviews %+v", oldReviewer, user.Reviews)
+			}
+		case newReviewer:
+			if len(user.Reviews) != 1 {
+				t.Fatalf("new reviewer %s has %d reviews, want 1", newReviewer, len(user.Reviews))
+			}
+
+			review := user.Reviews[0]
+			if review.AssignedAt == nil || !review.AssignedAt.After(review.CreatedAt) {
+				t.Errorf("review assigned at %v, want after creation at %v", review.AssignedAt, review.CreatedAt)
+			}
+		case "u1":
+			if len(user.AwaitingReviews) != 1 || user.AwaitingReviews[0].ID != "pr-1" {
+				t.Errorf("author awaits %+v, want pr-1", user.AwaitingReviews)
+			}
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+// CreateDirectoryUser provisions a user synced from an identity provider, the team is created if it doesn't exist.
+func (s *Service) CreateDirectoryUser(ctx context.Context, user models.User) (*models.User, *models.ErrDetails) {
+	if errDetails := validationFailed("CreateDirectoryUser", user.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("CreateDirectoryUser: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if _, err = s.repository.UpsertTeam(ctx, tx, user.TeamName); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	member := models.TeamMember{ID: user.ID, Username: user.Username, Email: user.Email, IsActive: user.IsActive}
+	if err = s.repository.InsertTeamMember(ctx, tx, member, user.TeamName); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	created, err := s.repository.SelectUser(ctx, user.ID)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return &created, nil
+}
+
+// UpdateDirectoryUser replaces the username, email, team and is_active of a user synced from an identity provider.
+// Reviews of a deactivated user are reassigned in the same transaction, as SetUserStatus does.
+func (s *Service) UpdateDirectoryUser(ctx context.Context, user models.User) (*models.User, *models.ErrDetails) {
+	if errDetails := validationFailed("UpdateDirectoryUser", user.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+
+	if _, err := s.repository.SelectUser(ctx, user.ID); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("UpdateDirectoryUser: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if _, err = s.repository.UpsertTeam(ctx, tx, user.TeamName); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	member := models.TeamMember{ID: user.ID, Username: user.Username, Email: user.Email, IsActive: user.IsActive}
+	_, wasActive, err := s.repository.UpsertTeamMember(ctx, tx, member, user.TeamName)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	var events []models.PullRequestEvent
+	if wasActive && !user.IsActive {
+		var errDetails *models.ErrDetails
+		events, errDetails = s.deactivateUser(ctx, tx, user.ID)
+		if errDetails != nil {
+			return nil, errDetails
+		}
+	}
+
+	if err = s.repository.InsertPullRequestEvents(ctx, tx, events); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	s.notifier.Notify(events...)
+	s.recordDeactivationReassignments(events)
+
+	updated, err := s.repository.SelectUser(ctx, user.ID)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return &updated, nil
+}
+
+// ListDirectoryUsers returns a page of users matching the filter and the total number of matching users.
+func (s *Service) ListDirectoryUsers(
+	ctx context.Context, filter models.DirectoryUserFilter,
+) ([]models.User, int, *models.ErrDetails) {
+	users, total, err := s.repository.SelectDirectoryUsers(ctx, filter)
+	if err != nil {
+		return nil, 0, mapRepositoryError(err)
+	}
+
+	return users, total, nil
+}
+
+// GetDirectoryTeam returns a team, unlike GetTeam a team without members is returned too.
+func (s *Service) GetDirectoryTeam(ctx context.Context, teamName string) (*models.Team, *models.ErrDetails) {
+	exists, err := s.repository.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if !exists {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("GetDirectoryTeam: team not found")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "team not found"}
+	}
+
+	team, err := s.repository.SelectTeam(ctx, teamName)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if team == nil {
+		team = &models.Team{Name: teamName, Members: make([]models.TeamMember, 0)}
+	}
+
+	return team, nil
+}
+
+// ListDirectoryTeams returns a page of teams ordered by name and the total number of teams.
+// With teamName set only that team is listed.
+func (s *Service) ListDirectoryTeams(
+	ctx context.Context, teamName string, offset, limit int,
+) ([]*models.Team, int, *models.ErrDetails) {
+	names, err := s.repository.SelectTeamNames(ctx)
+	if err != nil {
+		return nil, 0, mapRepositoryError(err)
+	}
+
+	if teamName != "" {
+		names = slices.DeleteFunc(names, func(name string) bool {
+			return name != teamName
+		})
+	}
+
+	total := len(names)
+	names = names[min(offset, total):]
+	if limit > 0 {
+		names = names[:min(limit, len(names))]
+	}
+
+	teams := make([]*models.Team, 0, len(names))
+	for _, name := range names {
+		team, errDetails := s.GetDirectoryTeam(ctx, name)
+		if errDetails != nil {
+			return nil, 0, errDetails
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, total, nil
+}
+
+// CreateDirectoryTeam creates a team and moves the given users into it.
+func (s *Service) CreateDirectoryTeam(
+	ctx context.Context, teamName string, memberIDs []string,
+) (*models.Team, *models.ErrDetails) {
+	errs := models.ValidateText("team_name", teamName, models.MaxNameLength)
+	if errDetails := validationFailed("CreateDirectoryTeam", errs); errDetails != nil {
+		return nil, errDetails
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("CreateDirectoryTeam: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if err = s.repository.InsertTeam(ctx, tx, models.AddTeamRequest{Name: teamName}); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if errDetails := s.addDirectoryTeamMembers(ctx, tx, teamName, memberIDs); errDetails != nil {
+		return nil, errDetails
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return s.GetDirectoryTeam(ctx, teamName)
+}
+
+// UpdateDirectoryTeamMembers applies the changes in order to the members read after the team is locked,
+// so concurrent updates of a team don't overwrite each other. Added users are moved into the team
+// and removed members to the fallback team, since every user has to belong to a team.
+func (s *Service) UpdateDirectoryTeamMembers(
+	ctx context.Context,
+	teamName string,
+	ifMatch models.Versions,
+	changes []models.TeamMembersChange,
+	fallbackTeam string,
+) (*models.Team, *models.ErrDetails) {
+	exists, err := s.repository.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if !exists {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("UpdateDirectoryTeamMembers: team not found")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "team not found"}
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("UpdateDirectoryTeamMembers: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if errDetails := s.lockDirectoryTeam(ctx, tx, "UpdateDirectoryTeamMembers", teamName, ifMatch); errDetails != nil {
+		return nil, errDetails
+	}
+
+	current, err := s.repository.SelectTeamMemberIDs(ctx, tx, teamName)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	members := applyTeamMembersChanges(current, changes)
+
+	var add, remove []string
+	for _, id := range members {
+		if !slices.Contains(current, id) {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(members, id) {
+			remove = append(remove, id)
+		}
+	}
+
+	if len(remove) > 0 && teamName == fallbackTeam {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("UpdateDirectoryTeamMembers: removing members from the fallback team")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: "members can't be removed from the fallback team, add them to another team instead",
+		}
+	}
+
+	if len(remove) > 0 {
+		if _, err = s.repository.UpsertTeam(ctx, tx, fallbackTeam); err != nil {
+			return nil, mapRepositoryError(err)
+		}
+
+		if _, err = s.repository.MoveUsersToTeam(ctx, tx, remove, teamName, fallbackTeam); err != nil {
+			return nil, mapRepositoryError(err)
+		}
+	}
+
+	if errDetails := s.addDirectoryTeamMembers(ctx, tx, teamName, add); errDetails != nil {
+		return nil, errDetails
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return s.GetDirectoryTeam(ctx, teamName)
+}
+
+// applyTeamMembersChanges returns the members after the changes, in the order they were added.
+func applyTeamMembersChanges(members []string, changes []models.TeamMembersChange) []string {
+	members = slices.Clone(members)
+	for _, change := range changes {
+		switch change.Op {
+		case models.MembersAdd:
+			for _, id := range change.MemberIDs {
+				if !slices.Contains(members, id) {
+					members = append(members, id)
+				}
+			}
+		case models.MembersRemove:
+			members = slices.DeleteFunc(members, func(id string) bool {
+				return slices.Contains(change.MemberIDs, id)
+			})
+		case models.MembersReplace:
+			members = slices.Compact(slices.Sorted(slices.Values(change.MemberIDs)))
+		}
+	}
+
+	return members
+}
+
+// SetDirectoryTeamMembers makes memberIDs the only members of the team, see UpdateDirectoryTeamMembers.
+func (s *Service) SetDirectoryTeamMembers(
+	ctx context.Context, teamName string, ifMatch models.Versions, memberIDs []string, fallbackTeam string,
+) (*models.Team, *models.ErrDetails) {
+	changes := []models.TeamMembersChange{{Op: models.MembersReplace, MemberIDs: memberIDs}}
+	return s.UpdateDirectoryTeamMembers(ctx, teamName, ifMatch, changes, fallbackTeam)
+}
+
+// DeleteDirectoryTeam moves the members of the team to the fallback team and deletes the team.
+func (s *Service) DeleteDirectoryTeam(
+	ctx context.Context, teamName string, ifMatch models.Versions, fallbackTeam string,
+) *models.ErrDetails {
+	if teamName == fallbackTeam {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("DeleteDirectoryTeam: deleting the fallback team")),
+			zap.String("type", "business"))
+
+		return &models.ErrDetails{Code: models.InvalidReqErr, Message: "the fallback team can't be deleted"}
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("DeleteDirectoryTeam: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if errDetails := s.lockDirectoryTeam(ctx, tx, "DeleteDirectoryTeam", teamName, ifMatch); errDetails != nil {
+		return errDetails
+	}
+
+	if _, err = s.repository.UpsertTeam(ctx, tx, fallbackTeam); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if err = s.repository.MoveTeamMembers(ctx, tx, teamName, fallbackTeam); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if err = s.repository.DeleteTeam(ctx, tx, teamName); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	return nil
+}
+
+// lockDirectoryTeam locks the team until the transaction ends and checks its version against If-Match.
+func (s *Service) lockDirectoryTeam(
+	ctx context.Context, tx models.Tx, operation, teamName string, ifMatch models.Versions,
+) *models.ErrDetails {
+	current, err := s.repository.LockTeam(ctx, tx, teamName)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+
+	return preconditionFailed(operation, ifMatch, current)
+}
+
+func (s *Service) addDirectoryTeamMembers(
+	ctx context.Context, tx models.Tx, teamName string, memberIDs []string,
+) *models.ErrDetails {
+	memberIDs = slices.Compact(slices.Sorted(slices.Values(memberIDs)))
+
+	moved, err := s.repository.MoveUsersToTeam(ctx, tx, memberIDs, "", teamName)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if moved != int64(len(memberIDs)) {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("addDirectoryTeamMembers: unknown members")),
+			zap.String("type", "business"))
+
+		return &models.ErrDetails{Code: models.NotFoundErr, Message: "some of the members don't exist"}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+func TestMapRepositoryError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want models.ErrDetails
+	}{
+		{"team conflict", &repository.ErrConflict{Entity: repository.EntityTeam},
+			models.ErrDetails{Code: models.TeamExistsErr, Message: "team already exists"}},
+		{"user conflict", &repository.ErrConflict{Entity: repository.EntityUser},
+			models.ErrDetails{Code: models.UserExistsErr, Message: "user already exists"}},
+		{"email conflict", &repository.ErrConflict{Entity: repository.EntityEmail},
+			models.ErrDetails{Code: models.UserExistsErr, Message: "email is already used by another user"}},
+		{"pull request conflict", &repository.ErrConflict{Entity: repository.EntityPullRequest},
+			models.ErrDetails{Code: models.PRExistsErr, Message: "pull request already exists"}},
+		{"wrapped conflict", fmt.Errorf("InsertTeam: %w", &repository.ErrConflict{Entity: repository.EntityTeam}),
+			models.ErrDetails{Code: models.TeamExistsErr, Message: "team already exists"}},
+		{"unknown entity conflict", &repository.ErrConflict{Entity: "token"},
+			models.ErrDetails{Code: models.InternalErr, Message: "service unavailable, try again later"}},
+		{"not found", repository.ErrNotFound,
+			models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}},
+		{"entity not found", repository.NotFound("team"),
+			models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}},
+		{"database", fmt.Errorf("%w: SelectTeam: connection refused", repository.ErrDB),
+			models.ErrDetails{Code: models.InternalErr, Message: "service unavailable, try again later"}},
+		{"invalid cursor", pagination.ErrInvalidCursor,
+			models.ErrDetails{Code: models.InvalidReqErr, Message: "invalid cursor"}},
+		{"unknown", errors.New("unexpected"),
+			models.ErrDetails{Code: models.InternalErr, Message: "service unavailable, try again later"}},
+	}
+
+	for _, tt := range tests {
+		got := service.MapRepositoryError(tt.err)
+		if got == nil || got.Code != tt.want.Code || got.Message != tt.want.Message {
+			t.Errorf("%s: mapRepositoryError(%v) = %+v, want %+v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package service
+
+// MapRepositoryError exposes the mapping of repository errors to the API errors.
+var MapRepositoryError = mapRepositoryError
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// claimAttempts bounds the retries when the key expires or is released between the claim and the lookup.
+	claimAttempts = 3
+
+	// IdempotencyLease is how long a key is held for a request that hasn't responded yet. It only has to outlive
+	// the request, so a key left behind by a crashed instance can be retried soon instead of after the whole TTL.
+	IdempotencyLease = time.Minute
+)
+
+// ClaimIdempotencyKey reserves the key for a new request for IdempotencyLease and returns nil.
+// When the key is held by an earlier request it returns the stored record of that request instead.
+func (s *Service) ClaimIdempotencyKey(ctx context.Context, key string) (*models.IdempotencyRecord, *models.ErrDetails) {
+	for range claimAttempts {
+		claimed, err := s.repository.InsertIdempotencyKey(ctx, key, time.Now().Add(IdempotencyLease))
+		if err != nil {
+			return nil, mapRepositoryError(err)
+		}
+
+		if claimed {
+			return nil, nil
+		}
+
+		record, err := s.repository.SelectIdempotencyKey(ctx, key)
+		if err != nil {
+			return nil, mapRepositoryError(err)
+		}
+
+		if record != nil {
+			return record, nil
+		}
+	}
+
+	zap.L().Info("business logic error",
+		zap.Error(errors.New("ClaimIdempotencyKey: key is contended")),
+		zap.String("type", "business"))
+
+	return nil, &models.ErrDetails{
+		Code:    models.InProgressErr,
+		Message: "a request with this idempotency key is in progress",
+	}
+}
+
+// SaveIdempotentResponse stores the response to replay for the claimed key and keeps the key until ttl passes.
+func (s *Service) SaveIdempotentResponse(
+	ctx context.Context,
+	record models.IdempotencyRecord,
+	ttl time.Duration,
+) *models.ErrDetails {
+	record.ExpiresAt = time.Now().Add(ttl)
+	if err := s.repository.UpdateIdempotencyKey(ctx, record); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey frees the claimed key, so that the request can be retried with it.
+func (s *Service) ReleaseIdempotencyKey(ctx context.Context, key string) *models.ErrDetails {
+	if err := s.repository.DeleteIdempotencyKey(ctx, key); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	return nil
+}
+
+func (s *Service) PurgeIdempotencyKeys(ctx context.Context) *models.ErrDetails {
+	deleted, err := s.repository.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if deleted > 0 {
+		zap.L().Info("expired idempotency keys purged", zap.Int64("count", deleted))
+	}
+
+	return nil
+}
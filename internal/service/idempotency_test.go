@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+func TestIdempotencyKeyIsLeasedUntilTheResponseIsStored(t *testing.T) {
+	const ttl = 24 * time.Hour
+
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepository(t)
+			s := service.NewService(repo, &recordingNotifier{}, metrics.New())
+
+			expiresAt := func() time.Time {
+				t.Helper()
+
+				record, err := repo.SelectIdempotencyKey(t.Context(), "key")
+				if err != nil || record == nil {
+					t.Fatalf("SelectIdempotencyKey() = %v, %v, want the claimed key", record, err)
+				}
+				return record.ExpiresAt
+			}
+
+			claimedAt := time.Now()
+			if record, errDetails := s.ClaimIdempotencyKey(t.Context(), "key"); record != nil || errDetails != nil {
+				t.Fatalf("ClaimIdempotencyKey() = %+v, %+v, want the key claimed", record, errDetails)
+			}
+			if lease := expiresAt().Sub(claimedAt); lease > service.IdempotencyLease+time.Second {
+				t.Errorf("unfinished request holds the key for %s, want at most %s", lease, service.IdempotencyLease)
+			}
+
+			record, errDetails := s.ClaimIdempotencyKey(t.Context(), "key")
+			if errDetails != nil || record == nil || record.Completed() {
+				t.Fatalf("ClaimIdempotencyKey(claimed) = %+v, %+v, want the unfinished record", record, errDetails)
+			}
+
+			savedAt := time.Now()
+			errDetails = s.SaveIdempotentResponse(t.Context(), models.IdempotencyRecord{
+				Key: "key", RequestHash: "hash", StatusCode: http.StatusCreated, Body: []byte("{}"),
+			}, ttl)
+			if errDetails != nil {
+				t.Fatalf("SaveIdempotentResponse() error = %+v", errDetails)
+			}
+			if kept := expiresAt().Sub(savedAt); kept < ttl-time.Second {
+				t.Errorf("stored response is kept for %s, want %s", kept, ttl)
+			}
+
+			record, errDetails = s.ClaimIdempotencyKey(t.Context(), "key")
+			if errDetails != nil || record == nil || record.StatusCode != http.StatusCreated {
+				t.Errorf("ClaimIdempotencyKey(completed) = %+v, %+v, want the stored response", record, errDetails)
+			}
+		})
+	}
+}
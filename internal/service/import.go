@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ImportTeams upserts teams and users of an org chart in a single transaction.
+// Nothing is applied if any row fails, in dry-run mode the transaction is always rolled back.
+func (s *Service) ImportTeams(
+	ctx context.Context, request models.ImportRequest,
+) (*models.ImportResult, *models.ErrDetails) {
+	if errDetails := validationFailed("ImportTeams", request.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("ImportTeams: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	result := &models.ImportResult{
+		DryRun: request.DryRun,
+		Rows:   make([]models.ImportRowResult, 0, len(request.Rows)),
+	}
+
+	teams := make(map[string]bool)
+	for _, row := range request.Rows {
+		if teams[row.TeamName] {
+			continue
+		}
+		teams[row.TeamName] = true
+
+		created, err := s.repository.UpsertTeam(ctx, tx, row.TeamName)
+		if err != nil {
+			return nil, mapRepositoryError(err)
+		}
+
+		if created {
+			result.TeamsCreated++
+		}
+	}
+
+	var events []models.PullRequestEvent
+	var rowErrs []models.FieldError
+	for _, row := range request.Rows {
+		rowEvents, rowErr, errDetails := s.importRow(ctx, tx, row, result)
+		if errDetails != nil {
+			return nil, errDetails
+		}
+
+		if rowErr != nil {
+			rowErrs = append(rowErrs, *rowErr)
+			continue
+		}
+		events = append(events, rowEvents...)
+	}
+
+	if len(rowErrs) > 0 {
+		return nil, importFailed(rowErrs)
+	}
+
+	if err = s.repository.InsertPullRequestEvents(ctx, tx, events); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	if request.DryRun {
+		return result, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	s.notifier.Notify(events...)
+	s.recordDeactivationReassignments(events)
+
+	return result, nil
+}
+
+// importRow upserts a single user inside a savepoint, so that a failed row doesn't abort the transaction.
+func (s *Service) importRow(
+	ctx context.Context, tx models.Tx, row models.ImportRow, result *models.ImportResult,
+) ([]models.PullRequestEvent, *models.FieldError, *models.ErrDetails) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, nil, mapRepositoryError(err)
+	}
+
+	member := models.TeamMember{
+		ID:       row.UserID,
+		Username: row.Username,
+		Email:    row.Email,
+		IsActive: row.IsActive,
+	}
+
+	action, wasActive, err := s.repository.UpsertTeamMember(ctx, savepoint, member, row.TeamName)
+	if err != nil {
+		var conflict *repository.ErrConflict
+		if !errors.As(err, &conflict) || conflict.Entity != repository.EntityEmail {
+			return nil, nil, mapRepositoryError(err)
+		}
+
+		if rollbackErr := savepoint.Rollback(ctx); rollbackErr != nil {
+			return nil, nil, mapRepositoryError(rollbackErr)
+		}
+
+		rowErr := importRowError(row.Row, "email", "unique", "email is already used by another user")
+		return nil, &rowErr, nil
+	}
+
+	var events []models.PullRequestEvent
+	if wasActive && !row.IsActive {
+		var errDetails *models.ErrDetails
+		events, errDetails = s.deactivateUser(ctx, savepoint, row.UserID)
+		if errDetails != nil {
+			return nil, nil, errDetails
+		}
+	}
+
+	if err = savepoint.Commit(ctx); err != nil {
+		return nil, nil, mapRepositoryError(err)
+	}
+
+	switch action {
+	case models.ImportActionCreated:
+		result.UsersCreated++
+	case models.ImportActionUpdated:
+		result.UsersUpdated++
+	default:
+		result.UsersUnchanged++
+	}
+
+	result.Rows = append(result.Rows, models.ImportRowResult{
+		Row:      row.Row,
+		TeamName: row.TeamName,
+		UserID:   row.UserID,
+		Action:   action,
+	})
+
+	return events, nil, nil
+}
+
+func importRowError(row int, field, rule, message string) models.FieldError {
+	return models.FieldError{
+		Field:   fmt.Sprintf("rows[%d].%s", row, field),
+		Rule:    rule,
+		Message: message,
+	}
+}
+
+func importFailed(rowErrs []models.FieldError) *models.ErrDetails {
+	zap.L().Info("business logic error",
+		zap.Error(fmt.Errorf("ImportTeams: %d invalid rows", len(rowErrs))),
+		zap.String("type", "business"))
+
+	return &models.ErrDetails{
+		Code:    models.ValidationErr,
+		Message: "import failed, nothing was applied",
+		Details: rowErrs,
+	}
+}
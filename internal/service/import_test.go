@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+func importRows(rows ...models.ImportRow) []models.ImportRow {
+	for i := range rows {
+		rows[i].Row = i + 1
+	}
+
+	return rows
+}
+
+func detailFields(errDetails *models.ErrDetails) []string {
+	fields := make([]string, 0, len(errDetails.Details))
+	for _, detail := range errDetails.Details {
+		fields = append(fields, detail.Field+":"+detail.Rule)
+	}
+
+	return fields
+}
+
+func TestImportTeamsUpserts(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addTeam(t, s, "backend", "u1")
+
+			rows := importRows(
+				models.ImportRow{TeamName: "backend", UserID: "u1", Username: "user u1", IsActive: true},
+				models.ImportRow{TeamName: "backend", UserID: "u2", Username: "Bob", IsActive: true},
+				models.ImportRow{TeamName: "payments", UserID: "p1", Username: "Anna", Email: "anna@example.com"},
+			)
+
+			dryRun, errDetails := s.ImportTeams(t.Context(), models.ImportRequest{Rows: rows, DryRun: true})
+			if errDetails != nil {
+				t.Fatalf("ImportTeams(dry run) error = %+v", errDetails)
+			}
+			if !dryRun.DryRun || dryRun.TeamsCreated != 1 || dryRun.UsersCreated != 2 || dryRun.UsersUnchanged != 1 {
+				t.Errorf("ImportTeams(dry run) = %+v, want 1 team and 2 users created, 1 unchanged", *dryRun)
+			}
+			if _, errDetails = s.GetTeam(t.Context(), "payments"); errDetails == nil ||
+				errDetails.Code != models.NotFoundErr {
+				t.Errorf("GetTeam(payments) after a dry run error = %+v, want %s", errDetails, models.NotFoundErr)
+			}
+
+			result, errDetails := s.ImportTeams(t.Context(), models.ImportRequest{Rows: rows})
+			if errDetails != nil {
+				t.Fatalf("ImportTeams() error = %+v", errDetails)
+			}
+			if result.DryRun || result.TeamsCreated != 1 || result.UsersCreated != 2 || result.UsersUnchanged != 1 ||
+				len(result.Rows) != 3 {
+				t.Errorf("ImportTeams() = %+v, want the counts of the dry run", *result)
+			}
+
+			team, errDetails := s.GetTeam(t.Context(), "payments")
+			if errDetails != nil {
+				t.Fatalf("GetTeam(payments) error = %+v", errDetails)
+			}
+			want := []models.TeamMember{{ID: "p1", Username: "Anna", Email: "anna@example.com"}}
+			if !slices.Equal(team.Members, want) {
+				t.Errorf("payments members = %+v, want %+v", team.Members, want)
+			}
+
+			rows = importRows(
+				models.ImportRow{TeamName: "payments", UserID: "u1", Username: "user u1", IsActive: true},
+				models.ImportRow{TeamName: "backend", UserID: "u2", Username: "Bob", IsActive: true},
+				models.ImportRow{TeamName: "payments", UserID: "p1", Username: "Anna", Email: "anna@example.com"},
+			)
+			result, errDetails = s.ImportTeams(t.Context(), models.ImportRequest{Rows: rows})
+			if errDetails != nil {
+				t.Fatalf("ImportTeams(again) error = %+v", errDetails)
+			}
+			if result.TeamsCreated != 0 || result.UsersCreated != 0 || result.UsersUpdated != 1 ||
+				result.UsersUnchanged != 2 {
+				t.Errorf("ImportTeams(again) = %+v, want u1 moved and the rest unchanged", *result)
+			}
+			if result.Rows[0].Action != models.ImportActionUpdated {
+				t.Errorf("row of u1 = %+v, want %s", result.Rows[0], models.ImportActionUpdated)
+			}
+		})
+	}
+}
+
+func TestImportTeamsRejectsInvalidRows(t *testing.T) {
+	s, _ := newTestService(t)
+
+	rows := importRows(
+		models.ImportRow{TeamName: "backend", UserID: "u1", Username: "Alice", Email: "alice"},
+		models.ImportRow{TeamName: "", UserID: "u-too-long-id", Username: "Bob"},
+		models.ImportRow{TeamName: "backend", UserID: "u1", Username: ""},
+	)
+
+	_, errDetails := s.ImportTeams(t.Context(), models.ImportRequest{Rows: rows})
+	if errDetails == nil || errDetails.Code != models.ValidationErr {
+		t.Fatalf("ImportTeams() error = %+v, want %s", errDetails, models.ValidationErr)
+	}
+
+	want := []string{
+		"rows[1].email:format",
+		"rows[2].team_name:required",
+		"rows[2].user_id:maxLength",
+		"rows[3].username:required",
+		"rows[3].user_id:unique",
+	}
+	if got := detailFields(errDetails); !slices.Equal(got, want) {
+		t.Errorf("ImportTeams() details = %v, want %v", got, want)
+	}
+
+	_, errDetails = s.ImportTeams(t.Context(), models.ImportRequest{})
+	if errDetails == nil || errDetails.Code != models.ValidationErr {
+		t.Errorf("ImportTeams(no rows) error = %+v, want %s", errDetails, models.ValidationErr)
+	}
+}
+
+func TestImportTeamsReportsEveryEmailConflict(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			team := models.AddTeamRequest{Name: "backend", Members: []models.TeamMember{
+				{ID: "u1", Username: "Alice", Email: "alice@example.com", IsActive: true},
+			}}
+			if _, errDetails := s.AddTeam(t.Context(), team); errDetails != nil {
+				t.Fatalf("AddTeam() error = %+v", errDetails)
+			}
+
+			rows := importRows(
+				models.ImportRow{TeamName: "payments", UserID: "p1", Username: "Anna", IsActive: true},
+				models.ImportRow{TeamName: "backend", UserID: "u2", Username: "Bob", Email: "alice@example.com"},
+				models.ImportRow{TeamName: "backend", UserID: "u3", Username: "Carl", Email: "alice@example.com"},
+			)
+
+			_, errDetails := s.ImportTeams(t.Context(), models.ImportRequest{Rows: rows})
+			if errDetails == nil || errDetails.Code != models.ValidationErr {
+				t.Fatalf("ImportTeams() error = %+v, want %s", errDetails, models.ValidationErr)
+			}
+			want := []string{"rows[2].email:unique", "rows[3].email:unique"}
+			if got := detailFields(errDetails); !slices.Equal(got, want) {
+				t.Errorf("ImportTeams() details = %v, want %v", got, want)
+			}
+
+			for _, userID := range []string{"p1", "u2", "u3"} {
+				if _, errDetails = s.GetUser(t.Context(), userID); errDetails == nil {
+					t.Errorf("user %s was imported from a failed import", userID)
+				}
+			}
+			if _, errDetails = s.GetTeam(t.Context(), "payments"); errDetails == nil {
+				t.Error("team payments was created by a failed import")
+			}
+		})
+	}
+}
+
+func TestImportTeamsReassignsDeactivatedReviewers(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			s := service.NewService(newRepository(t), notifier, metrics.New())
+			addTeam(t, s, "backend", "u1", "u2", "u3", "u4")
+			pr := createPullRequest(t, s, "pr-1", "u1")
+			deactivated := pr.AssignedReviewers[0]
+
+			row := models.ImportRow{TeamName: "backend", UserID: deactivated, Username: "user " + deactivated}
+			rows := importRows(row)
+			result, errDetails := s.ImportTeams(t.Context(), models.ImportRequest{Rows: rows})
+			if errDetails != nil {
+				t.Fatalf("ImportTeams() error = %+v", errDetails)
+			}
+			if result.UsersUpdated != 1 {
+				t.Errorf("ImportTeams() = %+v, want one updated user", *result)
+			}
+
+			details, errDetails := s.GetPullRequest(t.Context(), "pr-1")
+			if errDetails != nil {
+				t.Fatalf("GetPullRequest() error = %+v", errDetails)
+			}
+			if slices.Contains(details.AssignedReviewers, deactivated) || len(details.AssignedReviewers) != 2 {
+				t.Errorf("reviewers after deactivating %s = %v, want two others",
+					deactivated, details.AssignedReviewers)
+			}
+
+			var reassigned bool
+			for _, event := range notifier.events {
+				reassigned = reassigned || event.Type == models.EventReviewerReassigned &&
+					event.PullRequestID == "pr-1" && event.OldReviewerID == deactivated
+			}
+			if !reassigned {
+				t.Errorf("notified events = %+v, want the reassignment of %s", notifier.events, deactivated)
+			}
+		})
+	}
+}
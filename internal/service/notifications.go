@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+// CheckReviewSLA notifies reviewers whose assignments have been open longer than sla.
+// Every assignment is reported at most once.
+func (s *Service) CheckReviewSLA(ctx context.Context, sla time.Duration) *models.ErrDetails {
+	events, err := s.repository.SelectBreachedReviews(ctx, time.Now().Add(-sla))
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("CheckReviewSLA: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	if err = s.repository.InsertPullRequestEvents(ctx, tx, events); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return mapRepositoryError(err)
+	}
+
+	s.notifier.Notify(events...)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (s *Service) ListPullRequests(
+	ctx context.Context, filter models.ListPullRequestsRequest,
+) (*models.ListPullRequestsResponse, *models.ErrDetails) {
+	if errDetails := validationFailed("ListPullRequests", filter.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+	normalizePullRequestFilter(&filter)
+
+	pullRequests, next, err := s.repository.SelectPullRequests(ctx, filter)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return &models.ListPullRequestsResponse{
+		PullRequests: pullRequests,
+		NextCursor:   next,
+	}, nil
+}
+
+// normalizePullRequestFilter fills in the defaults of a validated filter.
+func normalizePullRequestFilter(filter *models.ListPullRequestsRequest) {
+	if filter.SortBy == "" {
+		filter.SortBy = models.SortByCreatedAt
+	}
+
+	if filter.Order == "" {
+		filter.Order = models.OrderDesc
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = models.DefaultPageSize
+	}
+}
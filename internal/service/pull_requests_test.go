@@ -0,0 +1,300 @@
+package service_test
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+// sameIDs compares two lists of ids ignoring their order.
+func sameIDs(got, want []string) bool {
+	got, want = slices.Clone(got), slices.Clone(want)
+	slices.Sort(got)
+	slices.Sort(want)
+
+	return slices.Equal(got, want)
+}
+
+func TestGetPullRequest(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addTeam(t, s, "backend", "u1", "u2", "u3")
+			created := createPullRequest(t, s, "pr-1", "u1")
+
+			pr, errDetails := s.GetPullRequest(t.Context(), "pr-1")
+			if errDetails != nil {
+				t.Fatalf("GetPullRequest() error = %+v", errDetails)
+			}
+			if pr.Name != "PR pr-1" || pr.AuthorID != "u1" || pr.AuthorTeam != "backend" || pr.Status != "OPEN" ||
+				pr.MergedAt != nil || pr.CreatedAt.IsZero() || pr.Version != created.Version {
+				t.Errorf("GetPullRequest() = %+v, want open pr-1 of u1 from backend", *pr)
+			}
+			if !sameIDs(pr.AssignedReviewers, created.AssignedReviewers) || len(pr.Reviewers) != 2 {
+				t.Fatalf("GetPullRequest() reviewers = %v, want %v", pr.AssignedReviewers, created.AssignedReviewers)
+			}
+			for _, reviewer := range pr.Reviewers {
+				if reviewer.Username != "user "+reviewer.UserID || !reviewer.IsActive || reviewer.AssignedAt.IsZero() ||
+					reviewer.ReviewState != models.ReviewStatePending {
+					t.Errorf("reviewer = %+v, want an active pending reviewer", reviewer)
+				}
+			}
+
+			if _, errDetails = s.MergePullRequest(t.Context(), models.MergePRRequest{ID: "pr-1"}); errDetails != nil {
+				t.Fatalf("MergePullRequest() error = %+v", errDetails)
+			}
+
+			pr, errDetails = s.GetPullRequest(t.Context(), "pr-1")
+			if errDetails != nil {
+				t.Fatalf("GetPullRequest(merged) error = %+v", errDetails)
+			}
+			if pr.Status != "MERGED" || pr.MergedAt == nil {
+				t.Errorf("GetPullRequest(merged) = %+v, want a merge time", *pr)
+			}
+			for _, reviewer := range pr.Reviewers {
+				if reviewer.ReviewState != models.ReviewStateCompleted {
+					t.Errorf("reviewer of a merged pull request = %+v, want a completed review", reviewer)
+				}
+			}
+
+			if _, errDetails = s.GetPullRequest(t.Context(), "unknown"); errDetails == nil ||
+				errDetails.Code != models.NotFoundErr {
+				t.Errorf("GetPullRequest(unknown) error = %+v, want %s", errDetails, models.NotFoundErr)
+			}
+			if _, errDetails = s.GetPullRequest(t.Context(), ""); errDetails == nil ||
+				errDetails.Code != models.ValidationErr {
+				t.Errorf("GetPullRequest(\"\") error = %+v, want %s", errDetails, models.ValidationErr)
+			}
+		})
+	}
+}
+
+// listPullRequests pages through the pull requests matching the filter and returns their ids in order.
+func listPullRequests(t *testing.T, s *service.Service, filter models.ListPullRequestsRequest) []string {
+	t.Helper()
+
+	var ids []string
+	for range 100 {
+		resp, errDetails := s.ListPullRequests(t.Context(), filter)
+		if errDetails != nil {
+			t.Fatalf("ListPullRequests(%+v) error = %+v", filter, errDetails)
+		}
+		if filter.Limit > 0 && len(resp.PullRequests) > filter.Limit {
+			t.Fatalf("ListPullRequests() returned %d pull requests, limit is %d", len(resp.PullRequests), filter.Limit)
+		}
+
+		for _, pr := range resp.PullRequests {
+			ids = append(ids, pr.ID)
+		}
+
+		if resp.NextCursor == "" {
+			return ids
+		}
+		filter.Cursor = resp.NextCursor
+	}
+
+	t.Fatal("ListPullRequests() never returned the last page")
+	return nil
+}
+
+// addPullRequests creates pr-1..pr-5 named out of id order and merges pr-3, pr-2 and pr-4 in that order.
+func addPullRequests(t *testing.T, s *service.Service) map[string]*models.PullRequest {
+	t.Helper()
+
+	addTeam(t, s, "backend", "u1", "u2", "u3")
+	addTeam(t, s, "payments", "p1", "p2")
+
+	pullRequests := make(map[string]*models.PullRequest)
+	for _, request := range []models.CreatePRRequest{
+		{ID: "pr-1", Name: "delta", AuthorID: "u1"},
+		{ID: "pr-2", Name: "alpha", AuthorID: "u2"},
+		{ID: "pr-3", Name: "echo", AuthorID: "u1"},
+		{ID: "pr-4", Name: "bravo", AuthorID: "p1"},
+		{ID: "pr-5", Name: "charlie", AuthorID: "u3"},
+	} {
+		pr, errDetails := s.CreatePullRequest(t.Context(), request)
+		if errDetails != nil {
+			t.Fatalf("CreatePullRequest(%s) error = %+v", request.ID, errDetails)
+		}
+		pullRequests[pr.ID] = pr
+	}
+
+	for _, id := range []string{"pr-3", "pr-2", "pr-4"} {
+		// SQLite keeps milliseconds, merges within one of them would be ordered by id.
+		time.Sleep(2 * time.Millisecond)
+		if _, errDetails := s.MergePullRequest(t.Context(), models.MergePRRequest{ID: id}); errDetails != nil {
+			t.Fatalf("MergePullRequest(%s) error = %+v", id, errDetails)
+		}
+	}
+
+	return pullRequests
+}
+
+func TestListPullRequestsFilters(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			pullRequests := addPullRequests(t, s)
+
+			tests := []struct {
+				name   string
+				filter models.ListPullRequestsRequest
+				want   []string
+			}{
+				{"all", models.ListPullRequestsRequest{}, []string{"pr-2", "pr-4", "pr-5", "pr-1", "pr-3"}},
+				{"open", models.ListPullRequestsRequest{Status: "OPEN"}, []string{"pr-5", "pr-1"}},
+				{"merged", models.ListPullRequestsRequest{Status: "MERGED"}, []string{"pr-2", "pr-4", "pr-3"}},
+				{"author", models.ListPullRequestsRequest{AuthorID: "u1"}, []string{"pr-1", "pr-3"}},
+				{"team", models.ListPullRequestsRequest{TeamName: "payments"}, []string{"pr-4"}},
+				{"open by author", models.ListPullRequestsRequest{Status: "OPEN", AuthorID: "u1"}, []string{"pr-1"}},
+				{"unknown team", models.ListPullRequestsRequest{TeamName: "unknown"}, nil},
+			}
+
+			for _, tt := range tests {
+				tt.filter.SortBy, tt.filter.Order = models.SortByName, models.OrderAsc
+				if got := listPullRequests(t, s, tt.filter); !slices.Equal(got, tt.want) {
+					t.Errorf("%s: ListPullRequests() = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+
+			for _, reviewer := range []string{"u1", "u2", "u3", "p1", "p2"} {
+				var want []string
+				for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"} {
+					if slices.Contains(pullRequests[id].AssignedReviewers, reviewer) {
+						want = append(want, id)
+					}
+				}
+
+				filter := models.ListPullRequestsRequest{ReviewerID: reviewer, SortBy: models.SortByCreatedAt,
+					Order: models.OrderAsc}
+				if got := listPullRequests(t, s, filter); !slices.Equal(got, want) {
+					t.Errorf("ListPullRequests(reviewer %s) = %v, want %v", reviewer, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestListPullRequestsSortAndPages(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addPullRequests(t, s)
+
+			tests := []struct {
+				sortBy string
+				order  string
+				want   []string
+			}{
+				{"", "", []string{"pr-5", "pr-4", "pr-3", "pr-2", "pr-1"}},
+				{models.SortByCreatedAt, models.OrderAsc, []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"}},
+				{models.SortByName, models.OrderAsc, []string{"pr-2", "pr-4", "pr-5", "pr-1", "pr-3"}},
+				{models.SortByName, models.OrderDesc, []string{"pr-3", "pr-1", "pr-5", "pr-4", "pr-2"}},
+				{models.SortByMergedAt, models.OrderAsc, []string{"pr-3", "pr-2", "pr-4"}},
+				{models.SortByMergedAt, models.OrderDesc, []string{"pr-4", "pr-2", "pr-3"}},
+			}
+
+			for _, tt := range tests {
+				for _, limit := range []int{0, 1, 2, 5} {
+					filter := models.ListPullRequestsRequest{SortBy: tt.sortBy, Order: tt.order, Limit: limit}
+					if got := listPullRequests(t, s, filter); !slices.Equal(got, tt.want) {
+						t.Errorf("ListPullRequests(%s %s, limit %d) = %v, want %v",
+							tt.sortBy, tt.order, limit, got, tt.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestListPullRequestsRejectsForeignCursor(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addPullRequests(t, s)
+
+			first, errDetails := s.ListPullRequests(t.Context(), models.ListPullRequestsRequest{Limit: 2})
+			if errDetails != nil {
+				t.Fatalf("ListPullRequests() error = %+v", errDetails)
+			}
+			if len(first.PullRequests) != 2 || first.NextCursor == "" {
+				t.Fatalf("ListPullRequests(limit 2) = %d pull requests and cursor %q, want 2 and a cursor",
+					len(first.PullRequests), first.NextCursor)
+			}
+
+			for _, filter := range []models.ListPullRequestsRequest{
+				{SortBy: models.SortByName, Cursor: first.NextCursor},
+				{Order: models.OrderAsc, Cursor: first.NextCursor},
+				{Cursor: "not-a-cursor"},
+			} {
+				_, errDetails = s.ListPullRequests(t.Context(), filter)
+				if errDetails == nil || errDetails.Code != models.InvalidReqErr ||
+					errDetails.Message != "invalid cursor" {
+					t.Errorf("ListPullRequests(%+v) error = %+v, want an invalid cursor", filter, errDetails)
+				}
+			}
+		})
+	}
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(...models.PullRequestEvent) {}
+
+// BenchmarkCreatePullRequest measures the whole create path: reading the author, the reviewer assignment lock
+// of the team, picking reviewers and the insert. The parallel run shows how the lock serializes one team.
+// Postgres runs when POSTGRES_HOST is set, the pull requests created there are kept under a team of their own.
+func BenchmarkCreatePullRequest(b *testing.B) {
+	repositories := backends()
+	repositories["postgres"] = func(tb testing.TB) service.Repository { return newPostgresRepository(tb) }
+
+	for name, newRepository := range repositories {
+		b.Run(name, func(b *testing.B) {
+			s := service.NewService(newRepository(b), nopNotifier{}, metrics.New())
+
+			// user_id holds 10 characters, so the run is told apart by 6 hex digits.
+			run := fmt.Sprintf("%06x", time.Now().UnixNano()&0xffffff)
+			team := models.AddTeamRequest{Name: "bench-" + run}
+			for i := range 10 {
+				id := fmt.Sprintf("b%s-u%d", run, i)
+				team.Members = append(team.Members, models.TeamMember{ID: id, Username: id, IsActive: true})
+			}
+			if _, errDetails := s.AddTeam(b.Context(), team); errDetails != nil {
+				b.Fatalf("AddTeam() error = %+v", errDetails)
+			}
+
+			var created atomic.Int64
+			create := func(b *testing.B) {
+				i := created.Add(1)
+				request := models.CreatePRRequest{
+					ID:       fmt.Sprintf("%s-%d", team.Name, i),
+					Name:     "benchmark",
+					AuthorID: team.Members[i%int64(len(team.Members))].ID,
+				}
+				// Errorf, since Fatalf can't stop the goroutines of RunParallel.
+				if _, errDetails := s.CreatePullRequest(b.Context(), request); errDetails != nil {
+					b.Errorf("CreatePullRequest() error = %+v", errDetails)
+				}
+			}
+
+			b.Run("serial", func(b *testing.B) {
+				for b.Loop() {
+					create(b)
+				}
+			})
+			b.Run("parallel", func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						create(b)
+					}
+				})
+			})
+		})
+	}
+}
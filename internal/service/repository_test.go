@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+// errorKind describes a repository error the way the service tells errors apart.
+func errorKind(err error) string {
+	var conflict *repository.ErrConflict
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, repository.ErrNotFound):
+		return "not found"
+	case errors.As(err, &conflict):
+		return conflict.Entity + " conflict"
+	default:
+		return "error: " + err.Error()
+	}
+}
+
+// absent turns a row returned for an unknown key into an error, lookups that can miss return nil without one.
+func absent[T any](row *T, err error) error {
+	if err == nil && row != nil {
+		return errors.New("unexpected row")
+	}
+
+	return err
+}
+
+// TestRepositoryContract checks that every backend reports missing and duplicate rows with the same errors.
+func TestRepositoryContract(t *testing.T) {
+	member := func(id, email string) models.TeamMember {
+		return models.TeamMember{ID: id, Username: "user " + id, Email: email, IsActive: true}
+	}
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, repo service.Repository, tx models.Tx) error
+		want string
+		// noTx runs the case without a transaction, SQLite would wait for the open one to write.
+		noTx bool
+	}{
+		{"InsertTeam existing", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			return repo.InsertTeam(ctx, tx, models.AddTeamRequest{Name: "backend"})
+		}, "team conflict", false},
+		{"InsertTeamMember existing id", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			return repo.InsertTeamMember(ctx, tx, member("u1", ""), "backend")
+		}, "user conflict", false},
+		// AddTeam reports a taken email as an existing user, only upserts tell the email apart.
+		{"InsertTeamMember existing email", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			return repo.InsertTeamMember(ctx, tx, member("u9", "u4@example.com"), "backend")
+		}, "user conflict", false},
+		{"InsertTeamMember unknown team", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			return repo.InsertTeamMember(ctx, tx, member("u9", ""), "unknown")
+		}, "not found", false},
+		{"UpsertTeamMember existing email", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			_, _, err := repo.UpsertTeamMember(ctx, tx, member("u1", "u4@example.com"), "backend")
+			return err
+		}, "email conflict", false},
+		{"InsertPullRequest existing", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			request := models.CreatePRRequest{ID: "pr-1", Name: "PR pr-1", AuthorID: "u1"}
+			_, err := repo.InsertPullRequest(ctx, tx, request, nil, nil)
+			return err
+		}, "pr conflict", false},
+		{"InsertPullRequest unknown author", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			request := models.CreatePRRequest{ID: "pr-2", Name: "PR pr-2", AuthorID: "unknown"}
+			_, err := repo.InsertPullRequest(ctx, tx, request, nil, nil)
+			return err
+		}, "not found", false},
+		{"InsertPullRequest unknown reviewer", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			request := models.CreatePRRequest{ID: "pr-2", Name: "PR pr-2", AuthorID: "u1"}
+			_, err := repo.InsertPullRequest(ctx, tx, request, []string{"unknown"}, nil)
+			return err
+		}, "not found", false},
+		{"SelectTeam unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			return absent(repo.SelectTeam(ctx, "unknown"))
+		}, "ok", false},
+		{"LockTeam unknown", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			_, err := repo.LockTeam(ctx, tx, "unknown")
+			return err
+		}, "not found", false},
+		{"SelectUser unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			_, err := repo.SelectUser(ctx, "unknown")
+			return err
+		}, "not found", false},
+		{"SelectUserByEmail unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			_, err := repo.SelectUserByEmail(ctx, "unknown@example.com")
+			return err
+		}, "not found", false},
+		{"SelectUserDetails unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			_, err := repo.SelectUserDetails(ctx, "unknown")
+			return err
+		}, "not found", false},
+		{"LockUser unknown", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			_, err := repo.LockUser(ctx, tx, "unknown")
+			return err
+		}, "not found", false},
+		{"SelectPullRequest unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			pr, _, err := repo.SelectPullRequest(ctx, "unknown")
+			return absent(pr, err)
+		}, "ok", false},
+		{"SelectPullRequestDetails unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			return absent(repo.SelectPullRequestDetails(ctx, "unknown"))
+		}, "ok", false},
+		{"LockPullRequest unknown", func(ctx context.Context, repo service.Repository, tx models.Tx) error {
+			return absent(repo.LockPullRequest(ctx, tx, "unknown"))
+		}, "ok", false},
+		{"SelectAPIToken unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			return absent(repo.SelectAPIToken(ctx, "unknown"))
+		}, "ok", false},
+		{"RevokeAPIToken unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			return repo.RevokeAPIToken(ctx, 1000)
+		}, "not found", true},
+		{"SelectIdempotencyKey unknown", func(ctx context.Context, repo service.Repository, _ models.Tx) error {
+			return absent(repo.SelectIdempotencyKey(ctx, "unknown"))
+		}, "ok", false},
+	}
+
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepository(t)
+			s := service.NewService(repo, &recordingNotifier{}, metrics.New())
+			addTeam(t, s, "backend", "u1", "u2", "u3")
+			if _, errDetails := s.ImportTeams(t.Context(), models.ImportRequest{Rows: []models.ImportRow{{
+				TeamName: "backend", UserID: "u4", Username: "user u4", Email: "u4@example.com",
+			}}}); errDetails != nil {
+				t.Fatalf("ImportTeams() error = %+v", errDetails)
+			}
+			createPullRequest(t, s, "pr-1", "u1")
+
+			for _, tt := range tests {
+				if tt.noTx {
+					if got := errorKind(tt.run(t.Context(), repo, nil)); got != tt.want {
+						t.Errorf("%s: error = %s, want %s", tt.name, got, tt.want)
+					}
+					continue
+				}
+
+				tx, err := repo.BeginTx(t.Context())
+				if err != nil {
+					t.Fatalf("BeginTx() error = %v", err)
+				}
+
+				if got := errorKind(tt.run(t.Context(), repo, tx)); got != tt.want {
+					t.Errorf("%s: error = %s, want %s", tt.name, got, tt.want)
+				}
+
+				if err = tx.Rollback(t.Context()); err != nil {
+					t.Fatalf("Rollback() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -4,45 +4,107 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/pagination"
 	"go.uber.org/zap"
 )
 
 type Repository interface {
-	BeginTx(ctx context.Context) (pgx.Tx, error)
-	InsertTeam(ctx context.Context, tx pgx.Tx, team models.AddTeamRequest) error
-	InsertTeamMember(ctx context.Context, tx pgx.Tx, member models.TeamMember, teamName string) error
+	BeginTx(ctx context.Context) (models.Tx, error)
+	InsertTeam(ctx context.Context, tx models.Tx, team models.AddTeamRequest) error
+	InsertTeamMember(ctx context.Context, tx models.Tx, member models.TeamMember, teamName string) error
+	UpsertTeam(ctx context.Context, tx models.Tx, teamName string) (bool, error)
+	UpsertTeamMember(ctx context.Context, tx models.Tx, member models.TeamMember, teamName string) (string, bool, error)
 	SelectTeam(ctx context.Context, teamName string) (*models.Team, error)
-	UpdateUserStatus(ctx context.Context, tx pgx.Tx, user models.SetUserStatusRequest) error
+	UpdateUserStatus(ctx context.Context, tx models.Tx, user models.SetUserStatusRequest) error
 	SelectUser(ctx context.Context, userID string) (models.User, error)
-	FindAvailableReviewers(ctx context.Context, tx pgx.Tx, user models.User) ([]string, error)
-	SelectUserReviews(ctx context.Context, userID string) ([]*models.PullRequestShort, error)
-	DeletePullRequestReviewer(ctx context.Context, tx pgx.Tx, prID, reviewerID string) error
-	InsertPullRequest(ctx context.Context, tx pgx.Tx, pullRequest models.CreatePRRequest) error
+	SelectUserByEmail(ctx context.Context, email string) (models.User, error)
+	SelectUserDetails(ctx context.Context, userID string) (*models.UserDetails, error)
+	SelectUsers(ctx context.Context, filter models.ListUsersRequest) ([]*models.UserDetails, string, error)
+	FindAvailableReviewers(ctx context.Context, tx models.Tx, user models.User) ([]string, error)
+	LockReviewerAssignment(ctx context.Context, tx models.Tx, teamName string) error
+	LockUser(ctx context.Context, tx models.Tx, userID string) (models.User, error)
+	LockOpenReviews(ctx context.Context, tx models.Tx, reviewerID string) ([]*models.PullRequestShort, error)
+	SelectUserReviews(
+		ctx context.Context,
+		filter models.UserReviewsRequest,
+	) ([]*models.PullRequestShort, string, error)
+	DeletePullRequestReviewer(ctx context.Context, tx models.Tx, prID, reviewerID string) error
+	InsertPullRequest(
+		ctx context.Context,
+		tx models.Tx,
+		pullRequest models.CreatePRRequest,
+		reviewers []string,
+		events []models.PullRequestEvent,
+	) (*models.PullRequest, error)
 	SelectPullRequest(ctx context.Context, pullRequestID string) (*models.PullRequest, time.Time, error)
-	UpdatePullRequestStatus(ctx context.Context, pullRequestID string) error
-	AssignPullRequestReviewers(ctx context.Context, tx pgx.Tx, pullRequestID string, reviewers []string) error
+	SelectPullRequestDetails(ctx context.Context, pullRequestID string) (*models.PullRequestDetails, error)
+	LockPullRequest(ctx context.Context, tx models.Tx, pullRequestID string) (*models.PullRequest, error)
+	SelectPullRequests(
+		ctx context.Context,
+		filter models.ListPullRequestsRequest,
+	) ([]*models.PullRequestDetails, string, error)
+	UpdatePullRequestStatus(ctx context.Context, tx models.Tx, pullRequestID string) (bool, error)
 	ReassignPullRequestReviewer(
 		ctx context.Context,
-		tx pgx.Tx,
+		tx models.Tx,
 		prID, reviewerID, authorID, teamName string,
 	) (string, error)
 	SelectUserStats(ctx context.Context) (*models.UserStatsResponse, error)
 	SelectPullRequestStats(ctx context.Context) (*models.PullRequestsStatsResponse, error)
 	SelectReviewerStats(ctx context.Context) (*models.ReviewersStatsResponse, error)
+	SelectOpenReviewsByTeam(ctx context.Context) ([]models.TeamOpenReviews, error)
+	InsertPullRequestEvents(ctx context.Context, tx models.Tx, events []models.PullRequestEvent) error
+	SelectBreachedReviews(ctx context.Context, assignedBefore time.Time) ([]models.PullRequestEvent, error)
+	SelectAuthoredPullRequests(ctx context.Context, authorID, status string) ([]*models.PullRequestShort, error)
+	SelectTeamNames(ctx context.Context) ([]string, error)
+	InsertAPIToken(ctx context.Context, token models.APIToken) (int64, error)
+	SelectAPIToken(ctx context.Context, hash string) (*models.APIToken, error)
+	RevokeAPIToken(ctx context.Context, tokenID int64) error
+	ExportBackup(ctx context.Context, write func(models.BackupRecord) error) (*models.BackupCounts, error)
+	LockBackupTables(ctx context.Context, tx models.Tx) error
+	CountBackupRows(ctx context.Context, tx models.Tx) (*models.BackupCounts, error)
+	CopyBackupRecords(ctx context.Context, tx models.Tx, records []models.BackupRecord) error
+	ResetEventSequence(ctx context.Context, tx models.Tx) error
+	SelectDirectoryUsers(ctx context.Context, filter models.DirectoryUserFilter) ([]models.User, int, error)
+	TeamExists(ctx context.Context, teamName string) (bool, error)
+	LockTeam(ctx context.Context, tx models.Tx, teamName string) (int64, error)
+	SelectTeamMemberIDs(ctx context.Context, tx models.Tx, teamName string) ([]string, error)
+	MoveUsersToTeam(ctx context.Context, tx models.Tx, userIDs []string, fromTeam, teamName string) (int64, error)
+	MoveTeamMembers(ctx context.Context, tx models.Tx, fromTeam, teamName string) error
+	DeleteTeam(ctx context.Context, tx models.Tx, teamName string) error
+	InsertIdempotencyKey(ctx context.Context, key string, expiresAt time.Time) (bool, error)
+	SelectIdempotencyKey(ctx context.Context, key string) (*models.IdempotencyRecord, error)
+	UpdateIdempotencyKey(ctx context.Context, record models.IdempotencyRecord) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+type Notifier interface {
+	Notify(events ...models.PullRequestEvent)
+}
+
+type Metrics interface {
+	PullRequestCreated()
+	PullRequestMerged()
+	ReviewerReassigned(trigger string)
+	NoCandidate()
 }
 
 type Service struct {
 	repository Repository
+	notifier   Notifier
+	metrics    Metrics
 }
 
-func NewService(repo Repository) *Service {
+func NewService(repo Repository, notifier Notifier, metrics Metrics) *Service {
 	return &Service{
 		repository: repo,
+		notifier:   notifier,
+		metrics:    metrics,
 	}
 }
 
@@ -52,23 +114,21 @@ func mapRepositoryError(err error) *models.ErrDetails {
 		Message: "service unavailable, try again later",
 	}
 
-	if isDatabaseError(err) {
+	var conflict *repository.ErrConflict
+	var businessErr *models.ErrDetails
+	switch {
+	case errors.Is(err, repository.ErrDB):
 		zap.L().Error("server error", zap.Error(err), zap.String("type", "technical"))
 		return internal
+	case errors.As(err, &conflict):
+		businessErr = conflictError(conflict)
+	case errors.Is(err, repository.ErrNotFound):
+		businessErr = &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		businessErr = &models.ErrDetails{Code: models.InvalidReqErr, Message: "invalid cursor"}
 	}
 
-	var businessErr *models.ErrDetails
-	errMsg := err.Error()
-	switch {
-	case strings.Contains(errMsg, "team unique violation"):
-		businessErr = &models.ErrDetails{Code: models.TeamExistsErr, Message: "team already exists"}
-	case strings.Contains(errMsg, "user unique violation"):
-		businessErr = &models.ErrDetails{Code: models.UserExistsErr, Message: "user already exists"}
-	case strings.Contains(errMsg, "pr unique violation"):
-		businessErr = &models.ErrDetails{Code: models.PRExistsErr, Message: "pull request already exists"}
-	case strings.Contains(errMsg, "foreign key violation"), strings.Contains(errMsg, "not found"):
-		businessErr = &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
-	default:
+	if businessErr == nil {
 		zap.L().Warn("unknown business error",
 			zap.Error(err),
 			zap.String("type", "business_unknown"),
@@ -81,21 +141,55 @@ func mapRepositoryError(err error) *models.ErrDetails {
 	return businessErr
 }
 
-func isDatabaseError(err error) bool {
-	return strings.HasPrefix(err.Error(), "database:")
+func conflictError(conflict *repository.ErrConflict) *models.ErrDetails {
+	switch conflict.Entity {
+	case repository.EntityTeam:
+		return &models.ErrDetails{Code: models.TeamExistsErr, Message: "team already exists"}
+	case repository.EntityUser:
+		return &models.ErrDetails{Code: models.UserExistsErr, Message: "user already exists"}
+	case repository.EntityEmail:
+		return &models.ErrDetails{Code: models.UserExistsErr, Message: "email is already used by another user"}
+	case repository.EntityPullRequest:
+		return &models.ErrDetails{Code: models.PRExistsErr, Message: "pull request already exists"}
+	default:
+		return nil
+	}
 }
 
-func (s *Service) AddTeam(ctx context.Context, team models.AddTeamRequest) (*models.Team, *models.ErrDetails) {
-	if team.Name == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("AddTeam: empty team_name")),
-			zap.String("type", "business"))
+// validationFailed reports the field errors of a request, it returns nil when there are none.
+func validationFailed(operation string, errs []models.FieldError) *models.ErrDetails {
+	if len(errs) == 0 {
+		return nil
+	}
 
-		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty team_name"}
+	zap.L().Info("business logic error",
+		zap.Error(fmt.Errorf("%s: invalid request", operation)),
+		zap.String("type", "business"))
+
+	return &models.ErrDetails{Code: models.ValidationErr, Message: "request validation failed", Details: errs}
+}
+
+// preconditionFailed reports that the resource changed since the client read the versions sent in If-Match.
+func preconditionFailed(operation string, expected models.Versions, current int64) *models.ErrDetails {
+	if expected.Match(current) {
+		return nil
 	}
 
-	if len(team.Members) == 0 {
-		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty members"}
+	zap.L().Info("business logic error",
+		zap.Error(fmt.Errorf("%s: versions %v don't match current version %d", operation, expected, current)),
+		zap.String("type", "business"))
+
+	return &models.ErrDetails{Code: models.PreconditionErr, Message: "resource was modified, fetch it and try again"}
+}
+
+func (s *Service) AddTeam(ctx context.Context, team models.AddTeamRequest) (*models.Team, *models.ErrDetails) {
+	if errDetails := validationFailed("AddTeam", team.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+
+	// A team lead belongs to an existing team, so only admins create teams.
+	if authErr := authorizeTeamAction(ctx, "AddTeam", team.Name); authErr != nil {
+		return nil, authErr
 	}
 
 	teamExists, err := s.repository.SelectTeam(ctx, team.Name)
@@ -129,14 +223,6 @@ func (s *Service) AddTeam(ctx context.Context, team models.AddTeamRequest) (*mod
 	}
 
 	for _, member := range team.Members {
-		if member.ID == "" {
-			zap.L().Info("business logic error",
-				zap.Error(errors.New("AddTeam: empty user_id")),
-				zap.String("type", "business"))
-
-			return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty user_id"}
-		}
-
 		err = s.repository.InsertTeamMember(ctx, tx, member, team.Name)
 		if err != nil {
 			return nil, mapRepositoryError(err)
@@ -160,12 +246,9 @@ func (s *Service) AddTeam(ctx context.Context, team models.AddTeamRequest) (*mod
 }
 
 func (s *Service) GetTeam(ctx context.Context, teamName string) (*models.Team, *models.ErrDetails) {
-	if teamName == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("empty team_name")),
-			zap.String("type", "business"))
-
-		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty team_name"}
+	errs := models.ValidateText("team_name", teamName, models.MaxNameLength)
+	if errDetails := validationFailed("GetTeam", errs); errDetails != nil {
+		return nil, errDetails
 	}
 
 	team, err := s.repository.SelectTeam(ctx, teamName)
@@ -184,12 +267,17 @@ func (s *Service) GetTeam(ctx context.Context, teamName string) (*models.Team, *
 }
 
 func (s *Service) SetUserStatus(ctx context.Context, userSettings models.SetUserStatusRequest) (models.User, *models.ErrDetails) {
-	if userSettings.ID == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("SetUserStatus: empty user_id")),
-			zap.String("type", "business"))
+	if errDetails := validationFailed("SetUserStatus", userSettings.Validate()); errDetails != nil {
+		return models.User{}, errDetails
+	}
 
-		return models.User{}, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty user_id"}
+	target, err := s.repository.SelectUser(ctx, userSettings.ID)
+	if err != nil {
+		return models.User{}, mapRepositoryError(err)
+	}
+
+	if authErr := authorizeTeamAction(ctx, "SetUserStatus", target.TeamName, models.RoleTeamLead); authErr != nil {
+		return models.User{}, authErr
 	}
 
 	tx, err := s.repository.BeginTx(ctx)
@@ -204,21 +292,40 @@ func (s *Service) SetUserStatus(ctx context.Context, userSettings models.SetUser
 		}
 	}()
 
+	current, err := s.repository.LockTeam(ctx, tx, target.TeamName)
+	if err != nil {
+		return models.User{}, mapRepositoryError(err)
+	}
+
+	if errDetails := preconditionFailed("SetUserStatus", userSettings.IfMatch, current); errDetails != nil {
+		return models.User{}, errDetails
+	}
+
 	err = s.repository.UpdateUserStatus(ctx, tx, userSettings)
 	if err != nil {
 		return models.User{}, mapRepositoryError(err)
 	}
 
+	var events []models.PullRequestEvent
 	if !userSettings.IsActive {
-		if deactivateErr := s.deactivateUser(ctx, tx, userSettings); deactivateErr != nil {
+		var deactivateErr *models.ErrDetails
+		events, deactivateErr = s.deactivateUser(ctx, tx, userSettings.ID)
+		if deactivateErr != nil {
 			return models.User{}, deactivateErr
 		}
 	}
 
+	if err = s.repository.InsertPullRequestEvents(ctx, tx, events); err != nil {
+		return models.User{}, mapRepositoryError(err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return models.User{}, mapRepositoryError(err)
 	}
 
+	s.notifier.Notify(events...)
+	s.recordDeactivationReassignments(events)
+
 	user, err := s.repository.SelectUser(ctx, userSettings.ID)
 	if err != nil {
 		return models.User{}, mapRepositoryError(err)
@@ -227,86 +334,86 @@ func (s *Service) SetUserStatus(ctx context.Context, userSettings models.SetUser
 	return user, nil
 }
 
-func (s *Service) deactivateUser(ctx context.Context, tx pgx.Tx, userSettings models.SetUserStatusRequest) *models.ErrDetails {
-	user, err := s.repository.SelectUser(ctx, userSettings.ID)
+// deactivateUser reassigns the open reviews of the user, the caller has already written the team row.
+// The reviews are read in tx after the reviewer assignment lock, which is taken before the pull request rows
+// as in ReassignPullRequestReviewer.
+func (s *Service) deactivateUser(
+	ctx context.Context, tx models.Tx, userID string,
+) ([]models.PullRequestEvent, *models.ErrDetails) {
+	user, err := s.repository.LockUser(ctx, tx, userID)
 	if err != nil {
-		return mapRepositoryError(err)
+		return nil, mapRepositoryError(err)
 	}
 
-	pullRequests, err := s.repository.SelectUserReviews(ctx, userSettings.ID)
+	if err = s.repository.LockReviewerAssignment(ctx, tx, user.TeamName); err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	pullRequests, err := s.repository.LockOpenReviews(ctx, tx, userID)
 	if err != nil {
-		return mapRepositoryError(err)
+		return nil, mapRepositoryError(err)
 	}
 
+	var events []models.PullRequestEvent
 	for _, pr := range pullRequests {
-		if pr.Status != "MERGED" {
-			newReviewer, serviceErr := s.tryReassignReviewer(ctx, tx, pr.ID,
-				userSettings.ID, pr.AuthorID, user.TeamName)
+		newReviewer, serviceErr := s.tryReassignReviewer(ctx, tx, pr.ID,
+			userID, pr.AuthorID, user.TeamName)
 
-			if serviceErr != nil {
-				return serviceErr
-			}
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
 
-			if newReviewer == "" {
-				err = s.repository.DeletePullRequestReviewer(ctx, tx, pr.ID, userSettings.ID)
-				if err != nil {
-					return mapRepositoryError(err)
-				}
+		if newReviewer == "" {
+			err = s.repository.DeletePullRequestReviewer(ctx, tx, pr.ID, userID)
+			if err != nil {
+				return nil, mapRepositoryError(err)
 			}
+			continue
 		}
+
+		events = append(events, models.PullRequestEvent{
+			Type:            models.EventReviewerReassigned,
+			PullRequestID:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorID:        pr.AuthorID,
+			TeamName:        user.TeamName,
+			ReviewerID:      newReviewer,
+			OldReviewerID:   userID,
+		})
 	}
 
-	return nil
+	return events, nil
 }
 
-func (s *Service) GetUserReviews(ctx context.Context, userID string) ([]*models.PullRequestShort, *models.ErrDetails) {
-	if userID == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("GetUserReviews: user not found")),
-			zap.String("type", "business"))
-
-		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
+func (s *Service) GetUserReviews(
+	ctx context.Context, request models.UserReviewsRequest,
+) (*models.GetUserReviewsResponse, *models.ErrDetails) {
+	if errDetails := validationFailed("GetUserReviews", request.Validate()); errDetails != nil {
+		return nil, errDetails
 	}
 
-	reviews, err := s.repository.SelectUserReviews(ctx, userID)
+	reviews, next, err := s.repository.SelectUserReviews(ctx, request)
 	if err != nil {
 		return nil, mapRepositoryError(err)
 	}
 
-	return reviews, nil
+	if reviews == nil {
+		reviews = make([]*models.PullRequestShort, 0)
+	}
+
+	return &models.GetUserReviewsResponse{
+		UserID:       request.UserID,
+		PullRequests: reviews,
+		NextCursor:   next,
+	}, nil
 }
 
 func (s *Service) CreatePullRequest(
 	ctx context.Context,
 	pullRequest models.CreatePRRequest,
 ) (*models.PullRequest, *models.ErrDetails) {
-	if pullRequest.AuthorID == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("CreatePullRequest: empty author_id")),
-			zap.String("type", "business"))
-
-		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty author_id"}
-	}
-
-	if pullRequest.ID == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("CreatePullRequest: empty pull_request_id")),
-			zap.String("type", "business"))
-
-		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "empty pull_request_id"}
-	}
-
-	exists, _, err := s.repository.SelectPullRequest(ctx, pullRequest.ID)
-	if err != nil {
-		return nil, mapRepositoryError(err)
-	}
-
-	if exists != nil {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("CreatePullRequest: pull request already exists")),
-			zap.String("type", "business"))
-
-		return nil, &models.ErrDetails{Code: models.PRExistsErr, Message: "pull request already exists"}
+	if errDetails := validationFailed("CreatePullRequest", pullRequest.Validate()); errDetails != nil {
+		return nil, errDetails
 	}
 
 	user, err := s.repository.SelectUser(ctx, pullRequest.AuthorID)
@@ -326,52 +433,150 @@ func (s *Service) CreatePullRequest(
 		}
 	}()
 
-	err = s.repository.InsertPullRequest(ctx, tx, pullRequest)
-	if err != nil {
+	// Reviewers of the team are picked one request at a time, otherwise concurrent requests
+	// would choose candidates without seeing the reviewers assigned by each other.
+	if err = s.repository.LockReviewerAssignment(ctx, tx, user.TeamName); err != nil {
 		return nil, mapRepositoryError(err)
 	}
 
-	allReviewers, err := s.repository.FindAvailableReviewers(ctx, tx, user)
+	reviewers, err := s.repository.FindAvailableReviewers(ctx, tx, user)
 	if err != nil {
 		return nil, mapRepositoryError(err)
 	}
 
-	if len(allReviewers) != 0 {
-		var limitedReviewers []string
-		if len(allReviewers) > 2 {
-			limitedReviewers = allReviewers[:2]
-		} else {
-			limitedReviewers = allReviewers
-		}
+	if len(reviewers) > 2 {
+		reviewers = reviewers[:2]
+	}
 
-		err = s.repository.AssignPullRequestReviewers(ctx, tx, pullRequest.ID, limitedReviewers)
-		if err != nil {
-			return nil, mapRepositoryError(err)
-		}
+	events := make([]models.PullRequestEvent, 0, len(reviewers))
+	for _, reviewerID := range reviewers {
+		events = append(events, models.PullRequestEvent{
+			Type:            models.EventReviewerAssigned,
+			PullRequestID:   pullRequest.ID,
+			PullRequestName: pullRequest.Name,
+			AuthorID:        pullRequest.AuthorID,
+			TeamName:        user.TeamName,
+			ReviewerID:      reviewerID,
+		})
+	}
+
+	// The pull request, its reviewers and events are written together, an existing pull request
+	// is reported by the insert.
+	pr, err := s.repository.InsertPullRequest(ctx, tx, pullRequest, reviewers, events)
+	if err != nil {
+		return nil, mapRepositoryError(err)
 	}
 
 	if err = tx.Commit(ctx); err != nil {
 		return nil, mapRepositoryError(err)
 	}
 
-	pr, _, err := s.repository.SelectPullRequest(ctx, pullRequest.ID)
+	s.notifier.Notify(events...)
+	s.metrics.PullRequestCreated()
+
+	return pr, nil
+}
+
+func (s *Service) GetPullRequest(
+	ctx context.Context, pullRequestID string,
+) (*models.PullRequestDetails, *models.ErrDetails) {
+	errs := models.ValidateText("pull_request_id", pullRequestID, models.MaxPullRequestIDLength)
+	if errDetails := validationFailed("GetPullRequest", errs); errDetails != nil {
+		return nil, errDetails
+	}
+
+	pr, err := s.repository.SelectPullRequestDetails(ctx, pullRequestID)
 	if err != nil {
 		return nil, mapRepositoryError(err)
 	}
 
+	if pr == nil {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("GetPullRequest: pull request not found")),
+			zap.String("type", "business"))
+
+		return nil, &models.ErrDetails{Code: models.NotFoundErr, Message: "pull request not found"}
+	}
+
 	return pr, nil
 }
 
-func (s *Service) MergePullRequest(ctx context.Context, pullRequestID string) (models.PullRequest, *models.ErrDetails) {
-	if pullRequestID == "" {
+func (s *Service) MergePullRequest(
+	ctx context.Context,
+	request models.MergePRRequest,
+) (models.PullRequest, *models.ErrDetails) {
+	if errDetails := validationFailed("MergePullRequest", request.Validate()); errDetails != nil {
+		return models.PullRequest{}, errDetails
+	}
+
+	pullRequestID := request.ID
+
+	existing, _, err := s.repository.SelectPullRequest(ctx, pullRequestID)
+	if err != nil {
+		return models.PullRequest{}, mapRepositoryError(err)
+	}
+
+	if existing == nil {
 		zap.L().Info("business logic error",
-			zap.Error(errors.New("CreatePullRequest: pull request already exists")),
+			zap.Error(errors.New("MergePullRequest: pull request not found")),
 			zap.String("type", "business"))
 
 		return models.PullRequest{}, &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
 	}
 
-	if err := s.repository.UpdatePullRequestStatus(ctx, pullRequestID); err != nil {
+	author, err := s.repository.SelectUser(ctx, existing.AuthorID)
+	if err != nil {
+		return models.PullRequest{}, mapRepositoryError(err)
+	}
+
+	authErr := authorizeTeamAction(ctx, "MergePullRequest", author.TeamName, models.RoleTeamLead, models.RoleMember)
+	if authErr != nil {
+		return models.PullRequest{}, authErr
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return models.PullRequest{}, mapRepositoryError(err)
+	}
+	defer func() {
+		if err = tx.Rollback(ctx); err != nil {
+			zap.L().Error("transaction rollbock error",
+				zap.Error(fmt.Errorf("MergePullRequest: failed to rollback tx: %w", err)),
+				zap.String("type", "technical"))
+		}
+	}()
+
+	locked, err := s.repository.LockPullRequest(ctx, tx, pullRequestID)
+	if err != nil {
+		return models.PullRequest{}, mapRepositoryError(err)
+	}
+
+	if locked == nil {
+		return models.PullRequest{}, &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
+	}
+
+	if errDetails := preconditionFailed("MergePullRequest", request.IfMatch, locked.Version); errDetails != nil {
+		return models.PullRequest{}, errDetails
+	}
+
+	merged, err := s.repository.UpdatePullRequestStatus(ctx, tx, pullRequestID)
+	if err != nil {
+		return models.PullRequest{}, mapRepositoryError(err)
+	}
+
+	var events []models.PullRequestEvent
+	if merged {
+		events = append(events, models.PullRequestEvent{
+			Type:          models.EventPullRequestMerged,
+			PullRequestID: pullRequestID,
+		})
+
+		if err = s.repository.InsertPullRequestEvents(ctx, tx, events); err != nil {
+			return models.PullRequest{}, mapRepositoryError(err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
 		return models.PullRequest{}, mapRepositoryError(err)
 	}
 
@@ -380,6 +585,19 @@ func (s *Service) MergePullRequest(ctx context.Context, pullRequestID string) (m
 		return models.PullRequest{}, mapRepositoryError(err)
 	}
 
+	if pr == nil {
+		return models.PullRequest{}, &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
+	}
+
+	if merged {
+		events[0].PullRequestName = pr.Name
+		events[0].AuthorID = pr.AuthorID
+		events[0].TeamName = author.TeamName
+		events[0].Reviewers = pr.AssignedReviewers
+		s.notifier.Notify(events...)
+		s.metrics.PullRequestMerged()
+	}
+
 	pr.MergedAt = mergedAt
 	return *pr, nil
 }
@@ -388,12 +606,8 @@ func (s *Service) ReassignPullRequestReviewer(
 	ctx context.Context,
 	prSettings models.ReassignPRReviewerRequest,
 ) (models.PullRequest, string, *models.ErrDetails) {
-	if prSettings.OldReviewerID == "" || prSettings.PullRequestID == "" {
-		zap.L().Info("business logic error",
-			zap.Error(errors.New("ReassignPullRequestReviewer: reviewer_id or pull_request_id is empty")),
-			zap.String("type", "business"))
-
-		return models.PullRequest{}, "", &models.ErrDetails{Code: models.NotFoundErr, Message: "resource not found"}
+	if errDetails := validationFailed("ReassignPullRequestReviewer", prSettings.Validate()); errDetails != nil {
+		return models.PullRequest{}, "", errDetails
 	}
 
 	assignedPR, _, err := s.repository.SelectPullRequest(ctx, prSettings.PullRequestID)
@@ -419,6 +633,24 @@ func (s *Service) ReassignPullRequestReviewer(
 			&models.ErrDetails{Code: models.PRMergedErr, Message: "can't reassign reviewer on merged pull request"}
 	}
 
+	author, err := s.repository.SelectUser(ctx, assignedPR.AuthorID)
+	if err != nil {
+		return models.PullRequest{}, "", mapRepositoryError(err)
+	}
+
+	authErr := authorizeTeamAction(ctx, "ReassignPullRequestReviewer", author.TeamName,
+		models.RoleTeamLead, models.RoleMember)
+	if authErr != nil {
+		return models.PullRequest{}, "", authErr
+	}
+
+	// The team of the old reviewer is resolved before the transaction, since its assignment lock
+	// has to be taken before the pull request row, as in deactivateUser.
+	user, err := s.repository.SelectUser(ctx, prSettings.OldReviewerID)
+	if err != nil {
+		return models.PullRequest{}, "", mapRepositoryError(err)
+	}
+
 	tx, err := s.repository.BeginTx(ctx)
 	if err != nil {
 		return models.PullRequest{}, "", mapRepositoryError(err)
@@ -432,11 +664,19 @@ func (s *Service) ReassignPullRequestReviewer(
 		}
 	}()
 
-	user, err := s.repository.SelectUser(ctx, prSettings.OldReviewerID)
+	if err = s.repository.LockReviewerAssignment(ctx, tx, user.TeamName); err != nil {
+		return models.PullRequest{}, "", mapRepositoryError(err)
+	}
+
+	assignedPR, err = s.repository.LockPullRequest(ctx, tx, prSettings.PullRequestID)
 	if err != nil {
 		return models.PullRequest{}, "", mapRepositoryError(err)
 	}
 
+	if errDetails := lockedPullRequestReassignable(assignedPR, prSettings); errDetails != nil {
+		return models.PullRequest{}, "", errDetails
+	}
+
 	replacedBy, err := s.repository.ReassignPullRequestReviewer(
 		ctx, tx, prSettings.PullRequestID, prSettings.OldReviewerID, assignedPR.AuthorID, user.TeamName)
 
@@ -449,23 +689,78 @@ func (s *Service) ReassignPullRequestReviewer(
 			zap.Error(errors.New("ReassignPullRequestReviewer: no available reviewers")),
 			zap.String("type", "business"))
 
+		s.metrics.NoCandidate()
 		return models.PullRequest{}, "", &models.ErrDetails{Code: models.NoCandidateErr, Message: "no available reviewers"}
 	}
 
-	if err = tx.Commit(ctx); err != nil {
+	event := models.PullRequestEvent{
+		Type:            models.EventReviewerReassigned,
+		PullRequestID:   assignedPR.ID,
+		PullRequestName: assignedPR.Name,
+		AuthorID:        assignedPR.AuthorID,
+		TeamName:        user.TeamName,
+		ReviewerID:      replacedBy,
+		OldReviewerID:   prSettings.OldReviewerID,
+	}
+
+	if err = s.repository.InsertPullRequestEvents(ctx, tx, []models.PullRequestEvent{event}); err != nil {
 		return models.PullRequest{}, "", mapRepositoryError(err)
 	}
 
-	pr, _, err := s.repository.SelectPullRequest(ctx, prSettings.PullRequestID)
+	pr, err := s.repository.LockPullRequest(ctx, tx, prSettings.PullRequestID)
 	if err != nil {
 		return models.PullRequest{}, "", mapRepositoryError(err)
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return models.PullRequest{}, "", mapRepositoryError(err)
+	}
+
+	s.notifier.Notify(event)
+	s.metrics.ReviewerReassigned(models.ReassignTriggerManual)
+
 	return *pr, replacedBy, nil
 }
 
+// lockedPullRequestReassignable checks the pull request locked in the transaction,
+// it could have been merged or changed since it was read for authorization.
+func lockedPullRequestReassignable(
+	pr *models.PullRequest,
+	request models.ReassignPRReviewerRequest,
+) *models.ErrDetails {
+	if pr == nil {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("ReassignPullRequestReviewer: user not assigned on pull request")),
+			zap.String("type", "business"))
+
+		return &models.ErrDetails{Code: models.NotAssignedErr, Message: "user not assigned on pull request"}
+	}
+
+	if errDetails := preconditionFailed("ReassignPullRequestReviewer", request.IfMatch, pr.Version); errDetails != nil {
+		return errDetails
+	}
+
+	if pr.Status == "MERGED" {
+		zap.L().Info("business logic error",
+			zap.Error(errors.New("ReassignPullRequestReviewer: can't reassign reviewer on merged pull request")),
+			zap.String("type", "business"))
+
+		return &models.ErrDetails{Code: models.PRMergedErr, Message: "can't reassign reviewer on merged pull request"}
+	}
+
+	return nil
+}
+
+func (s *Service) recordDeactivationReassignments(events []models.PullRequestEvent) {
+	for _, event := range events {
+		if event.Type == models.EventReviewerReassigned {
+			s.metrics.ReviewerReassigned(models.ReassignTriggerDeactivation)
+		}
+	}
+}
+
 func (s *Service) tryReassignReviewer(
-	ctx context.Context, tx pgx.Tx, prID, oldReviewerID, authorID, teamName string,
+	ctx context.Context, tx models.Tx, prID, oldReviewerID, authorID, teamName string,
 ) (string, *models.ErrDetails) {
 	replacedBy, err := s.repository.ReassignPullRequestReviewer(
 		ctx, tx, prID, oldReviewerID, authorID, teamName)
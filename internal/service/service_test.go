@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/memory"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/sqlite/sqlitetest"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []models.PullRequestEvent
+}
+
+func (n *recordingNotifier) Notify(events ...models.PullRequestEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, events...)
+}
+
+// newTestService runs the service on the in-memory repository.
+func newTestService(t *testing.T) (*service.Service, *recordingNotifier) {
+	t.Helper()
+
+	notifier := &recordingNotifier{}
+	return service.NewService(memory.NewRepository(), notifier, metrics.New()), notifier
+}
+
+// backends returns the repositories the storage-dependent tests run against.
+func backends() map[string]func(tb testing.TB) service.Repository {
+	return map[string]func(tb testing.TB) service.Repository{
+		"memory": func(testing.TB) service.Repository { return memory.NewRepository() },
+		"sqlite": func(tb testing.TB) service.Repository { return sqlitetest.NewRepository(tb) },
+	}
+}
+
+// newPostgresRepository connects to the migrated database configured by the POSTGRES_* variables,
+// the test is skipped when POSTGRES_HOST is not set.
+func newPostgresRepository(tb testing.TB) *repository.Repository {
+	tb.Helper()
+
+	if os.Getenv("POSTGRES_HOST") == "" {
+		tb.Skip("POSTGRES_HOST is not set")
+	}
+
+	var cfg repository.PostgresCfg
+	if err := cleanenv.ReadEnv(&cfg); err != nil {
+		tb.Fatalf("failed to read postgres config: %v", err)
+	}
+
+	repo, err := repository.NewRepository(cfg)
+	if err != nil {
+		tb.Fatalf("NewRepository() error = %v", err)
+	}
+	tb.Cleanup(repo.CloseConnection)
+
+	return repo
+}
+
+func addTeam(t *testing.T, s *service.Service, teamName string, userIDs ...string) {
+	t.Helper()
+
+	team := models.AddTeamRequest{Name: teamName}
+	for _, id := range userIDs {
+		team.Members = append(team.Members, models.TeamMember{ID: id, Username: "user " + id, IsActive: true})
+	}
+
+	if _, errDetails := s.AddTeam(t.Context(), team); errDetails != nil {
+		t.Fatalf("AddTeam(%s) error = %+v", teamName, errDetails)
+	}
+}
+
+func createPullRequest(t *testing.T, s *service.Service, id, authorID string) *models.PullRequest {
+	t.Helper()
+
+	request := models.CreatePRRequest{ID: id, Name: "PR " + id, AuthorID: authorID}
+	pr, errDetails := s.CreatePullRequest(t.Context(), request)
+	if errDetails != nil {
+		t.Fatalf("CreatePullRequest(%s) error = %+v", id, errDetails)
+	}
+
+	return pr
+}
@@ -32,3 +32,12 @@ func (s *Service) GetReviewersStatistics(ctx context.Context) (*models.Reviewers
 
 	return stats, nil
 }
+
+func (s *Service) OpenReviewsByTeam(ctx context.Context) ([]models.TeamOpenReviews, *models.ErrDetails) {
+	teams, err := s.repository.SelectOpenReviewsByTeam(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return teams, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (s *Service) GetUser(ctx context.Context, userID string) (*models.UserDetails, *models.ErrDetails) {
+	errs := models.ValidateText("user_id", userID, models.MaxUserIDLength)
+	if errDetails := validationFailed("GetUser", errs); errDetails != nil {
+		return nil, errDetails
+	}
+
+	user, err := s.repository.SelectUserDetails(ctx, userID)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) ListUsers(
+	ctx context.Context, filter models.ListUsersRequest,
+) (*models.ListUsersResponse, *models.ErrDetails) {
+	if errDetails := validationFailed("ListUsers", filter.Validate()); errDetails != nil {
+		return nil, errDetails
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = models.DefaultPageSize
+	}
+
+	users, next, err := s.repository.SelectUsers(ctx, filter)
+	if err != nil {
+		return nil, mapRepositoryError(err)
+	}
+
+	return &models.ListUsersResponse{
+		Users:      users,
+		NextCursor: next,
+	}, nil
+}
@@ -0,0 +1,242 @@
+package service_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+// listUsers pages through the users matching the filter and returns their ids in order.
+func listUsers(t *testing.T, s *service.Service, filter models.ListUsersRequest) []string {
+	t.Helper()
+
+	var ids []string
+	for range 100 {
+		resp, errDetails := s.ListUsers(t.Context(), filter)
+		if errDetails != nil {
+			t.Fatalf("ListUsers(%+v) error = %+v", filter, errDetails)
+		}
+		if filter.Limit > 0 && len(resp.Users) > filter.Limit {
+			t.Fatalf("ListUsers() returned %d users, limit is %d", len(resp.Users), filter.Limit)
+		}
+
+		for _, user := range resp.Users {
+			ids = append(ids, user.ID)
+		}
+
+		if resp.NextCursor == "" {
+			return ids
+		}
+		filter.Cursor = resp.NextCursor
+	}
+
+	t.Fatal("ListUsers() never returned the last page")
+	return nil
+}
+
+func addDirectory(t *testing.T, s *service.Service) {
+	t.Helper()
+
+	for _, team := range []models.AddTeamRequest{
+		{Name: "backend", Members: []models.TeamMember{
+			{ID: "u1", Username: "Alice", IsActive: true},
+			{ID: "u2", Username: "alex", IsActive: true},
+			{ID: "u3", Username: "Bob", IsActive: true},
+			{ID: "u4", Username: "Alfred", IsActive: false},
+		}},
+		{Name: "payments", Members: []models.TeamMember{
+			{ID: "p1", Username: "Anna", IsActive: true},
+			{ID: "p2", Username: "Carl", IsActive: true},
+		}},
+	} {
+		if _, errDetails := s.AddTeam(t.Context(), team); errDetails != nil {
+			t.Fatalf("AddTeam(%s) error = %+v", team.Name, errDetails)
+		}
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	active, inactive := true, false
+
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addDirectory(t, s)
+
+			tests := []struct {
+				name   string
+				filter models.ListUsersRequest
+				want   []string
+			}{
+				{"all", models.ListUsersRequest{}, []string{"p1", "p2", "u1", "u2", "u3", "u4"}},
+				{"team", models.ListUsersRequest{TeamName: "backend"}, []string{"u1", "u2", "u3", "u4"}},
+				{"active", models.ListUsersRequest{IsActive: &active}, []string{"p1", "p2", "u1", "u2", "u3"}},
+				{"inactive", models.ListUsersRequest{IsActive: &inactive}, []string{"u4"}},
+				{"prefix ignores case", models.ListUsersRequest{NamePrefix: "AL"}, []string{"u1", "u2", "u4"}},
+				{"active by prefix", models.ListUsersRequest{NamePrefix: "a", IsActive: &active},
+					[]string{"p1", "u1", "u2"}},
+				{"team by prefix", models.ListUsersRequest{TeamName: "payments", NamePrefix: "a"}, []string{"p1"}},
+				{"unknown team", models.ListUsersRequest{TeamName: "unknown"}, nil},
+			}
+
+			for _, tt := range tests {
+				for _, limit := range []int{0, 1, 2, 6} {
+					tt.filter.Limit = limit
+					if got := listUsers(t, s, tt.filter); !slices.Equal(got, tt.want) {
+						t.Errorf("%s: ListUsers(limit %d) = %v, want %v", tt.name, limit, got, tt.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestListUsersRejectsForeignCursor(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addDirectory(t, s)
+			createPullRequest(t, s, "pr-1", "u1")
+			createPullRequest(t, s, "pr-2", "u2")
+
+			pullRequests, errDetails := s.ListPullRequests(t.Context(), models.ListPullRequestsRequest{Limit: 1})
+			if errDetails != nil || pullRequests.NextCursor == "" {
+				t.Fatalf("ListPullRequests(limit 1) = %+v, %+v, want a next page", pullRequests, errDetails)
+			}
+
+			for _, cursor := range []string{pullRequests.NextCursor, "not-a-cursor"} {
+				_, errDetails = s.ListUsers(t.Context(), models.ListUsersRequest{Cursor: cursor})
+				if errDetails == nil || errDetails.Code != models.InvalidReqErr {
+					t.Errorf("ListUsers(cursor %q) error = %+v, want an invalid cursor", cursor, errDetails)
+				}
+			}
+		})
+	}
+}
+
+func TestGetUserCountsOpenWork(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addDirectory(t, s)
+			first := createPullRequest(t, s, "pr-1", "u1")
+			createPullRequest(t, s, "pr-2", "u1")
+			if _, errDetails := s.MergePullRequest(t.Context(), models.MergePRRequest{ID: "pr-2"}); errDetails != nil {
+				t.Fatalf("MergePullRequest() error = %+v", errDetails)
+			}
+
+			user, errDetails := s.GetUser(t.Context(), "u1")
+			if errDetails != nil {
+				t.Fatalf("GetUser(u1) error = %+v", errDetails)
+			}
+			if user.Username != "Alice" || user.TeamName != "backend" || !user.IsActive ||
+				user.OpenPullRequests != 1 || user.OpenReviews != 0 {
+				t.Errorf("GetUser(u1) = %+v, want active Alice of backend with one open pull request", *user)
+			}
+
+			reviewer, errDetails := s.GetUser(t.Context(), first.AssignedReviewers[0])
+			if errDetails != nil {
+				t.Fatalf("GetUser(%s) error = %+v", first.AssignedReviewers[0], errDetails)
+			}
+			if reviewer.OpenReviews != 1 || reviewer.OpenPullRequests != 0 {
+				t.Errorf("GetUser(reviewer) = %+v, want one open review", *reviewer)
+			}
+
+			if _, errDetails = s.GetUser(t.Context(), "unknown"); errDetails == nil ||
+				errDetails.Code != models.NotFoundErr {
+				t.Errorf("GetUser(unknown) error = %+v, want %s", errDetails, models.NotFoundErr)
+			}
+		})
+	}
+}
+
+// listUserReviews pages through the reviews of the user and returns the pull request ids in order.
+func listUserReviews(t *testing.T, s *service.Service, request models.UserReviewsRequest) []string {
+	t.Helper()
+
+	var ids []string
+	for range 100 {
+		resp, errDetails := s.GetUserReviews(t.Context(), request)
+		if errDetails != nil {
+			t.Fatalf("GetUserReviews(%+v) error = %+v", request, errDetails)
+		}
+		if request.Limit > 0 && len(resp.PullRequests) > request.Limit {
+			t.Fatalf("GetUserReviews() returned %d reviews, limit is %d", len(resp.PullRequests), request.Limit)
+		}
+
+		for _, pr := range resp.PullRequests {
+			wantState := models.ReviewStatePending
+			if pr.Status == "MERGED" {
+				wantState = models.ReviewStateCompleted
+			}
+			if pr.ReviewState != wantState || pr.AssignedAt == nil {
+				t.Errorf("review of %s = %+v, want state %s and an assignment time", pr.ID, *pr, wantState)
+			}
+			ids = append(ids, pr.ID)
+		}
+
+		if resp.NextCursor == "" {
+			return ids
+		}
+		if request.Limit == 0 {
+			t.Fatal("GetUserReviews() without a limit returned a next page")
+		}
+		request.Cursor = resp.NextCursor
+	}
+
+	t.Fatal("GetUserReviews() never returned the last page")
+	return nil
+}
+
+func TestGetUserReviews(t *testing.T) {
+	for name, newRepository := range backends() {
+		t.Run(name, func(t *testing.T) {
+			s := service.NewService(newRepository(t), &recordingNotifier{}, metrics.New())
+			addTeam(t, s, "backend", "u1", "u2")
+			for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"} {
+				createPullRequest(t, s, id, "u1")
+			}
+			for _, id := range []string{"pr-2", "pr-4"} {
+				if _, errDetails := s.MergePullRequest(t.Context(), models.MergePRRequest{ID: id}); errDetails != nil {
+					t.Fatalf("MergePullRequest(%s) error = %+v", id, errDetails)
+				}
+			}
+
+			tests := []struct {
+				status string
+				want   []string
+			}{
+				{"", []string{"pr-5", "pr-4", "pr-3", "pr-2", "pr-1"}},
+				{"OPEN", []string{"pr-5", "pr-3", "pr-1"}},
+				{"MERGED", []string{"pr-4", "pr-2"}},
+			}
+
+			for _, tt := range tests {
+				for _, limit := range []int{0, 1, 2, 5} {
+					request := models.UserReviewsRequest{UserID: "u2", Status: tt.status, Limit: limit}
+					if got := listUserReviews(t, s, request); !slices.Equal(got, tt.want) {
+						t.Errorf("GetUserReviews(status %q, limit %d) = %v, want %v", tt.status, limit, got, tt.want)
+					}
+				}
+			}
+
+			if got := listUserReviews(t, s, models.UserReviewsRequest{UserID: "u1"}); len(got) != 0 {
+				t.Errorf("GetUserReviews(author) = %v, want no reviews", got)
+			}
+
+			pullRequests, errDetails := s.ListPullRequests(t.Context(), models.ListPullRequestsRequest{Limit: 1})
+			if errDetails != nil || pullRequests.NextCursor == "" {
+				t.Fatalf("ListPullRequests(limit 1) = %+v, %+v, want a next page", pullRequests, errDetails)
+			}
+
+			request := models.UserReviewsRequest{UserID: "u2", Cursor: pullRequests.NextCursor}
+			if _, errDetails = s.GetUserReviews(t.Context(), request); errDetails == nil ||
+				errDetails.Code != models.InvalidReqErr {
+				t.Errorf("GetUserReviews(foreign cursor) error = %+v, want an invalid cursor", errDetails)
+			}
+		})
+	}
+}
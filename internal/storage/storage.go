@@ -0,0 +1,44 @@
+// Package storage opens the repository backend selected by the configuration.
+package storage
+
+import (
+	"fmt"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/memory"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/sqlite"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
+// Repository is a service repository holding a connection until it is closed.
+type Repository interface {
+	service.Repository
+	CloseConnection()
+}
+
+func NewRepository(driver string, postgres repository.PostgresCfg, sqliteCfg sqlite.SQLiteCfg) (Repository, error) {
+	switch driver {
+	case DriverPostgres:
+		repo, err := repository.NewRepository(postgres)
+		if err != nil {
+			return nil, err
+		}
+		return repo, nil
+	case DriverMemory:
+		return memory.NewRepository(), nil
+	case DriverSQLite:
+		repo, err := sqlite.NewRepository(sqliteCfg)
+		if err != nil {
+			return nil, err
+		}
+		return repo, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (s *server) CreateAPITokenHandler(w http.ResponseWriter, r *http.Request) {
+	body := r.Body
+	defer body.Close()
+
+	var request models.CreateAPITokenRequest
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		err := models.ErrDetails{
+			Code:    models.InvalidJSONErr,
+			Message: fmt.Sprintf("failed to decode json: %v", err),
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	token, serviceErr := s.service.CreateAPIToken(r.Context(), request)
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusCreated, token)
+}
+
+func (s *server) RevokeAPITokenHandler(w http.ResponseWriter, r *http.Request) {
+	body := r.Body
+	defer body.Close()
+
+	var request models.RevokeAPITokenRequest
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		err := models.ErrDetails{
+			Code:    models.InvalidJSONErr,
+			Message: fmt.Sprintf("failed to decode json: %v", err),
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.serveRevokedAPIToken(w, r, request.ID)
+}
+
+func (s *server) serveRevokedAPIToken(w http.ResponseWriter, r *http.Request, tokenID int64) {
+	if serviceErr := s.service.RevokeAPIToken(r.Context(), tokenID); serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
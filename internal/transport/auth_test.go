@@ -0,0 +1,100 @@
+package transport_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func getTeam(token string) request {
+	return request{
+		method:  http.MethodGet,
+		path:    "/team/get?team_name=backend",
+		headers: map[string]string{"Authorization": "Bearer " + token},
+	}
+}
+
+func TestAuthRejectsUnusableTokens(t *testing.T) {
+	s := newTestServer(t, config.Config{AuthCfg: auth.AuthCfg{Enabled: true}})
+	addTeam(t, s, "backend", "u1", "u2")
+
+	issue := func(request models.CreateAPITokenRequest) *models.CreateAPITokenResponse {
+		t.Helper()
+
+		token, errDetails := s.service.CreateAPIToken(t.Context(), request)
+		if errDetails != nil {
+			t.Fatalf("CreateAPIToken() error = %+v", errDetails)
+		}
+		return token
+	}
+
+	expiredAt := time.Now().Add(-time.Minute)
+	expired := issue(models.CreateAPITokenRequest{Name: "expired", Role: models.RoleReadOnly, ExpiresAt: &expiredAt})
+
+	revoked := issue(models.CreateAPITokenRequest{Name: "revoked", Role: models.RoleReadOnly})
+	if errDetails := s.service.RevokeAPIToken(t.Context(), revoked.ID); errDetails != nil {
+		t.Fatalf("RevokeAPIToken() error = %+v", errDetails)
+	}
+
+	lead := issue(models.CreateAPITokenRequest{Name: "lead", Role: models.RoleTeamLead, UserID: "u1"})
+	if resp := s.do(t, getTeam(lead.Token)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("active team lead status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	_, errDetails := s.service.SetUserStatus(t.Context(), models.SetUserStatusRequest{ID: "u1", IsActive: false})
+	if errDetails != nil {
+		t.Fatalf("SetUserStatus() error = %+v", errDetails)
+	}
+
+	for name, token := range map[string]string{
+		"unknown":       "not-a-token",
+		"expired":       expired.Token,
+		"revoked":       revoked.Token,
+		"inactive user": lead.Token,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := s.do(t, getTeam(token))
+			if resp.StatusCode != http.StatusUnauthorized || errorCode(t, resp) != models.UnauthorizedErr {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+			if got := resp.Header.Get("WWW-Authenticate"); got != "Bearer" {
+				t.Errorf("WWW-Authenticate = %q, want Bearer", got)
+			}
+		})
+	}
+}
+
+func TestForbiddenTeamActionAnswers403(t *testing.T) {
+	s := newTestServer(t, config.Config{AuthCfg: auth.AuthCfg{Enabled: true}})
+	addTeam(t, s, "backend", "u1", "u2")
+	addTeam(t, s, "payments", "p1", "p2")
+
+	lead, errDetails := s.service.CreateAPIToken(t.Context(), models.CreateAPITokenRequest{
+		Name: "payments lead", Role: models.RoleTeamLead, UserID: "p1",
+	})
+	if errDetails != nil {
+		t.Fatalf("CreateAPIToken() error = %+v", errDetails)
+	}
+
+	resp := s.do(t, request{
+		method:  http.MethodPost,
+		path:    "/users/setIsActive",
+		body:    models.SetUserStatusRequest{ID: "u2", IsActive: false},
+		headers: map[string]string{"Authorization": "Bearer " + lead.Token},
+	})
+	if resp.StatusCode != http.StatusForbidden || errorCode(t, resp) != models.ForbiddenErr {
+		t.Errorf("setIsActive in another team status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+
+	user, errDetails := s.service.GetUser(t.Context(), "u2")
+	if errDetails != nil {
+		t.Fatalf("GetUser() error = %+v", errDetails)
+	}
+	if !user.IsActive {
+		t.Error("forbidden request deactivated the user")
+	}
+}
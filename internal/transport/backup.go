@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/backup"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+func (s *server) ExportBackupHandler(w http.ResponseWriter, r *http.Request) {
+	encoder := backup.NewEncoder(w)
+	started := false
+
+	serviceErr := s.service.ExportBackup(r.Context(), func(record models.BackupRecord) error {
+		if !started {
+			started = true
+			filename := "backup-" + time.Now().UTC().Format("20060102T150405Z") + ".ndjson"
+			w.Header().Set("Content-Type", backup.ContentType)
+			w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+			w.WriteHeader(http.StatusOK)
+		}
+
+		return encoder.Write(record)
+	})
+
+	if serviceErr != nil {
+		if !started {
+			s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+			return
+		}
+
+		// The status is already sent, breaking the connection is the only way to tell the client
+		// that the backup is incomplete.
+		zap.L().Error("backup export aborted", zap.String("code", serviceErr.Code))
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (s *server) RestoreBackupHandler(w http.ResponseWriter, r *http.Request) {
+	body := r.Body
+	defer body.Close()
+
+	counts, serviceErr := s.service.RestoreBackup(r.Context(), backup.NewDecoder(body))
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, models.RestoreBackupResponse{Restored: *counts})
+}
@@ -0,0 +1,65 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+const ifMatchMessage = "If-Match must list strong ETags returned by the service"
+
+// setETag sends the version of the resource, zero means the resource has no version yet.
+func setETag(w http.ResponseWriter, version int64) {
+	if version > 0 {
+		w.Header().Set("ETag", formatETag(version))
+	}
+}
+
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch returns the versions listed in If-Match, none when the header is absent or "*".
+// Versions are compared with the strong comparison of RFC 9110, so a weak ETag can't match and,
+// like a malformed header, gets the request rejected.
+func parseIfMatch(r *http.Request) (models.Versions, bool) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, true
+	}
+
+	var versions models.Versions
+	for _, etag := range strings.Split(value, ",") {
+		etag = strings.TrimSpace(etag)
+		if strings.HasPrefix(etag, "W/") {
+			return nil, false
+		}
+
+		unquoted, err := strconv.Unquote(etag)
+		if err != nil || !strings.HasPrefix(etag, `"`) {
+			return nil, false
+		}
+
+		version, err := strconv.ParseInt(unquoted, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, false
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, true
+}
+
+func (s *server) ifMatchVersions(w http.ResponseWriter, r *http.Request) (models.Versions, bool) {
+	versions, ok := parseIfMatch(r)
+	if !ok {
+		s.respondWithError(w, http.StatusPreconditionFailed, models.ErrDetails{
+			Code:    models.PreconditionErr,
+			Message: ifMatchMessage,
+		})
+	}
+
+	return versions, ok
+}
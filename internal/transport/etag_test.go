@@ -0,0 +1,101 @@
+package transport_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func TestUserStatusHonoursIfMatch(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2", "u3")
+
+	teamETag := func() string {
+		t.Helper()
+
+		resp := s.do(t, request{method: http.MethodGet, path: "/api/v1/teams/backend"})
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") == "" {
+			t.Fatalf("GET team status = %d, ETag = %q", resp.StatusCode, resp.Header.Get("ETag"))
+		}
+		return resp.Header.Get("ETag")
+	}
+
+	setIsActive := func(userID, ifMatch string) *http.Response {
+		return s.do(t, request{
+			method:  http.MethodPost,
+			path:    "/users/setIsActive",
+			body:    models.SetUserStatusRequest{ID: userID, IsActive: false},
+			headers: map[string]string{"If-Match": ifMatch},
+		})
+	}
+	patchUser := func(userID, ifMatch string) *http.Response {
+		isActive := false
+		return s.do(t, request{
+			method:  http.MethodPatch,
+			path:    "/api/v1/users/" + userID,
+			body:    models.UpdateUserRequest{IsActive: &isActive},
+			headers: map[string]string{"If-Match": ifMatch},
+		})
+	}
+
+	stale := teamETag()
+	if resp := setIsActive("u2", stale); resp.StatusCode != http.StatusOK {
+		t.Fatalf("setIsActive with the current ETag status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	for name, resp := range map[string]*http.Response{
+		"setIsActive with a stale ETag":   setIsActive("u3", stale),
+		"PATCH with a stale ETag":         patchUser("u3", stale),
+		"PATCH with a malformed If-Match": patchUser("u3", "3"),
+	} {
+		if resp.StatusCode != http.StatusPreconditionFailed || errorCode(t, resp) != models.PreconditionErr {
+			t.Errorf("%s: status = %d, want %d", name, resp.StatusCode, http.StatusPreconditionFailed)
+		}
+	}
+
+	if resp := patchUser("u3", teamETag()); resp.StatusCode != http.StatusOK {
+		t.Errorf("PATCH with the current ETag status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+	if resp := patchUser("u1", "*"); resp.StatusCode != http.StatusOK {
+		t.Errorf("PATCH with If-Match * status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+}
+
+func TestMergeComparesIfMatchListsStrongly(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2", "u3")
+
+	created := s.do(t, createPullRequest("pr-1", nil))
+	if created.StatusCode != http.StatusCreated || created.Header.Get("ETag") == "" {
+		t.Fatalf("create status = %d, ETag = %q", created.StatusCode, created.Header.Get("ETag"))
+	}
+	etag := created.Header.Get("ETag")
+
+	merge := func(ifMatch string) *http.Response {
+		return s.do(t, request{
+			method:  http.MethodPost,
+			path:    "/api/v1/pull-requests/pr-1/merge",
+			headers: map[string]string{"If-Match": ifMatch},
+		})
+	}
+
+	for _, ifMatch := range []string{
+		"W/" + etag,
+		`"100", W/` + etag,
+		`"100", "101"`,
+		etag + ", *",
+		etag + ",",
+	} {
+		if resp := merge(ifMatch); resp.StatusCode != http.StatusPreconditionFailed ||
+			errorCode(t, resp) != models.PreconditionErr {
+			t.Errorf("merge with If-Match %s status = %d, want %d", ifMatch, resp.StatusCode,
+				http.StatusPreconditionFailed)
+		}
+	}
+
+	if resp := merge(`"100", ` + etag); resp.StatusCode != http.StatusOK {
+		t.Errorf("merge with the current ETag in a list status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+}
@@ -0,0 +1,12 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+)
+
+// NewHandler builds the routes of StartServer without listening on a port.
+func NewHandler(cfg *config.Config, service PRService, metrics HTTPMetrics) http.Handler {
+	return newServer(cfg, service, metrics).httpServer.Handler
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/vedsatt/pr-review-assignment-service/internal/models"
 	"go.uber.org/zap"
@@ -54,6 +55,7 @@ func (s *server) AddTeamHandler(w http.ResponseWriter, r *http.Request) {
 	resp := models.AddTeamResponse{
 		Team: *team,
 	}
+	setETag(w, team.Version)
 	s.respondWithJSON(w, http.StatusCreated, resp)
 }
 
@@ -68,12 +70,17 @@ func (s *server) GetTeamHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.serveTeam(w, r, teamName)
+}
+
+func (s *server) serveTeam(w http.ResponseWriter, r *http.Request, teamName string) {
 	teamResp, serviceErr := s.service.GetTeam(r.Context(), teamName)
 	if serviceErr != nil {
 		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
 		return
 	}
 
+	setETag(w, teamResp.Version)
 	s.respondWithJSON(w, http.StatusOK, *teamResp)
 }
 
@@ -91,6 +98,15 @@ func (s *server) SetUserStatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.serveUserStatus(w, r, request)
+}
+
+func (s *server) serveUserStatus(w http.ResponseWriter, r *http.Request, request models.SetUserStatusRequest) {
+	var ok bool
+	if request.IfMatch, ok = s.ifMatchVersions(w, r); !ok {
+		return
+	}
+
 	user, serviceErr := s.service.SetUserStatus(r.Context(), request)
 	if serviceErr != nil {
 		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
@@ -114,16 +130,37 @@ func (s *server) GetUserReviewsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pullRequests, serviceErr := s.service.GetUserReviews(r.Context(), userID)
+	s.serveUserReviews(w, r, userID, 0)
+}
+
+// serveUserReviews answers with the reviews of the user, a page of defaultLimit of them unless the query sets a limit.
+func (s *server) serveUserReviews(w http.ResponseWriter, r *http.Request, userID string, defaultLimit int) {
+	query := r.URL.Query()
+	request := models.UserReviewsRequest{
+		UserID: userID,
+		Status: query.Get("status"),
+		Limit:  defaultLimit,
+		Cursor: query.Get("cursor"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: "limit must be an integer",
+			})
+			return
+		}
+		request.Limit = value
+	}
+
+	resp, serviceErr := s.service.GetUserReviews(r.Context(), request)
 	if serviceErr != nil {
 		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
 		return
 	}
 
-	resp := models.GetUserReviewsResponse{
-		UserID:       userID,
-		PullRequests: pullRequests,
-	}
 	s.respondWithJSON(w, http.StatusOK, resp)
 }
 
@@ -150,9 +187,24 @@ func (s *server) CreatePullRequestHandler(w http.ResponseWriter, r *http.Request
 	resp := models.CreatePRResponse{
 		PullRequest: *pullRequest,
 	}
+	setETag(w, pullRequest.Version)
 	s.respondWithJSON(w, http.StatusCreated, resp)
 }
 
+func (s *server) GetPullRequestHandler(w http.ResponseWriter, r *http.Request) {
+	pullRequestID := r.URL.Query().Get("pull_request_id")
+	if pullRequestID == "" {
+		err := models.ErrDetails{
+			Code:    models.NotFoundErr,
+			Message: "resourse not found",
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.servePullRequest(w, r, pullRequestID)
+}
+
 func (s *server) MergePullRequestHandler(w http.ResponseWriter, r *http.Request) {
 	body := r.Body
 	defer body.Close()
@@ -167,7 +219,17 @@ func (s *server) MergePullRequestHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	pullRequest, serviceErr := s.service.MergePullRequest(r.Context(), request.ID)
+	s.serveMergedPullRequest(w, r, request.ID)
+}
+
+func (s *server) serveMergedPullRequest(w http.ResponseWriter, r *http.Request, pullRequestID string) {
+	ifMatch, ok := s.ifMatchVersions(w, r)
+	if !ok {
+		return
+	}
+
+	request := models.MergePRRequest{ID: pullRequestID, IfMatch: ifMatch}
+	pullRequest, serviceErr := s.service.MergePullRequest(r.Context(), request)
 	if serviceErr != nil {
 		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
 		return
@@ -176,6 +238,7 @@ func (s *server) MergePullRequestHandler(w http.ResponseWriter, r *http.Request)
 	resp := models.MergePullRequestResponse{
 		PullRequest: pullRequest,
 	}
+	setETag(w, pullRequest.Version)
 	s.respondWithJSON(w, http.StatusOK, resp)
 }
 
@@ -193,6 +256,17 @@ func (s *server) ReassignPullRequestReviewerHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	s.serveReassignedReviewer(w, r, request)
+}
+
+func (s *server) serveReassignedReviewer(
+	w http.ResponseWriter, r *http.Request, request models.ReassignPRReviewerRequest,
+) {
+	var ok bool
+	if request.IfMatch, ok = s.ifMatchVersions(w, r); !ok {
+		return
+	}
+
 	pullRequest, replacedBy, serviceErr := s.service.ReassignPullRequestReviewer(r.Context(), request)
 	if serviceErr != nil {
 		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
@@ -203,5 +277,6 @@ func (s *server) ReassignPullRequestReviewerHandler(w http.ResponseWriter, r *ht
 		PullRequest: pullRequest,
 		ReplacedBy:  replacedBy,
 	}
+	setETag(w, pullRequest.Version)
 	s.respondWithJSON(w, http.StatusOK, resp)
 }
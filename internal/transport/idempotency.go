@@ -0,0 +1,179 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// responseRecorder buffers the response until the request body is hashed and the response is stored.
+// It has its own header, so that only the headers set by the handler are stored and replayed.
+type responseRecorder struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(p)
+}
+
+// idempotencyMiddleware replays the stored response to a request retried with the same Idempotency-Key.
+// A key reused with another request is rejected, requests without the header are passed through.
+func (s *server) idempotencyMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		if maxLength := models.MaxIdempotencyKeyLength; len(key) > maxLength {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: fmt.Sprintf("%s must be at most %d characters long", idempotencyKeyHeader, maxLength),
+			})
+			return
+		}
+
+		hasher := requestHasher(r)
+		key = scopedIdempotencyKey(r, key)
+
+		record, serviceErr := s.service.ClaimIdempotencyKey(r.Context(), key)
+		if serviceErr != nil {
+			s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+			return
+		}
+
+		if record != nil {
+			s.replay(w, r, hasher, record)
+			return
+		}
+
+		body := r.Body
+		r.Body = io.NopCloser(io.TeeReader(body, hasher))
+
+		recorder := &responseRecorder{header: http.Header{}}
+		next(recorder, r)
+		recorder.WriteHeader(http.StatusOK)
+
+		_, err := io.Copy(hasher, body)
+		_ = body.Close()
+
+		ctx := context.WithoutCancel(r.Context())
+		if err != nil || recorder.statusCode >= http.StatusInternalServerError {
+			s.releaseIdempotencyKey(ctx, key)
+		} else {
+			serviceErr = s.service.SaveIdempotentResponse(ctx, models.IdempotencyRecord{
+				Key:         key,
+				RequestHash: hex.EncodeToString(hasher.Sum(nil)),
+				StatusCode:  recorder.statusCode,
+				Headers:     recorder.header,
+				Body:        recorder.body.Bytes(),
+			}, s.idempotencyTTL)
+			if serviceErr != nil {
+				zap.L().Error("failed to store idempotent response", zap.String("code", serviceErr.Code))
+				s.releaseIdempotencyKey(ctx, key)
+			}
+		}
+
+		copyHeader(w.Header(), recorder.header)
+		w.WriteHeader(recorder.statusCode)
+		if _, err = w.Write(recorder.body.Bytes()); err != nil {
+			zap.L().Error("failed to write response", zap.Error(err))
+		}
+	})
+}
+
+// replay responds to a retried request with the response stored for its key.
+func (s *server) replay(w http.ResponseWriter, r *http.Request, hasher hash.Hash, record *models.IdempotencyRecord) {
+	if !record.Completed() {
+		s.respondWithError(w, http.StatusConflict, models.ErrDetails{
+			Code:    models.InProgressErr,
+			Message: "a request with this idempotency key is in progress",
+		})
+		return
+	}
+
+	if _, err := io.Copy(hasher, r.Body); err != nil {
+		s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: fmt.Sprintf("failed to read body: %v", err),
+		})
+		return
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != record.RequestHash {
+		s.respondWithError(w, http.StatusUnprocessableEntity, models.ErrDetails{
+			Code:    models.KeyReusedErr,
+			Message: "idempotency key was used with a different request",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	copyHeader(w.Header(), record.Headers)
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	if _, err := w.Write(record.Body); err != nil {
+		zap.L().Error("failed to write response", zap.Error(err))
+	}
+}
+
+func (s *server) releaseIdempotencyKey(ctx context.Context, key string) {
+	if serviceErr := s.service.ReleaseIdempotencyKey(ctx, key); serviceErr != nil {
+		zap.L().Error("failed to release idempotency key", zap.String("code", serviceErr.Code))
+	}
+}
+
+// requestHasher starts the hash of the request with its route,
+// so that the key replays a response only to the same request.
+func requestHasher(r *http.Request) hash.Hash {
+	hasher := sha256.New()
+	_, _ = fmt.Fprintf(hasher, "%s %s\n", r.Method, r.URL.RequestURI())
+
+	return hasher
+}
+
+// scopedIdempotencyKey is the key as it is stored. Keys are scoped by the caller,
+// so that callers neither get each other's responses nor block each other by using the same key.
+func scopedIdempotencyKey(r *http.Request, key string) string {
+	hasher := sha256.New()
+	if caller, ok := auth.CallerFromContext(r.Context()); ok {
+		_, _ = fmt.Fprintf(hasher, "%d %s %s\n", caller.TokenID, caller.UserID, caller.Role)
+	}
+	_, _ = io.WriteString(hasher, key)
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func copyHeader(dst http.Header, src map[string][]string) {
+	for name, values := range src {
+		dst[name] = values
+	}
+}
@@ -0,0 +1,86 @@
+package transport_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func createPullRequest(id string, headers map[string]string) request {
+	return request{
+		method:  http.MethodPost,
+		path:    "/api/v1/pull-requests",
+		body:    models.CreatePRRequest{ID: id, Name: "Add " + id, AuthorID: "u1"},
+		headers: headers,
+	}
+}
+
+func TestIdempotentReplayKeepsHeaders(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2", "u3")
+
+	headers := map[string]string{"Idempotency-Key": "create-pr-1"}
+	first := s.do(t, createPullRequest("pr-1", headers))
+	firstBody := readBody(t, first)
+	if first.StatusCode != http.StatusCreated {
+		t.Fatalf("first request status = %d: %s", first.StatusCode, firstBody)
+	}
+	if first.Header.Get("ETag") == "" {
+		t.Fatal("first response has no ETag")
+	}
+
+	replayed := s.do(t, createPullRequest("pr-1", headers))
+	if replayed.StatusCode != http.StatusCreated {
+		t.Fatalf("replayed status = %d, want %d", replayed.StatusCode, http.StatusCreated)
+	}
+	if got := replayed.Header.Get("Idempotent-Replayed"); got != "true" {
+		t.Errorf("Idempotent-Replayed = %q, want true", got)
+	}
+	if got, want := replayed.Header.Get("ETag"), first.Header.Get("ETag"); got != want {
+		t.Errorf("replayed ETag = %q, want %q", got, want)
+	}
+	if got, want := replayed.Header.Get("Content-Type"), first.Header.Get("Content-Type"); got != want {
+		t.Errorf("replayed Content-Type = %q, want %q", got, want)
+	}
+	if body := readBody(t, replayed); !bytes.Equal(body, firstBody) {
+		t.Errorf("replayed body = %s, want %s", body, firstBody)
+	}
+
+	reused := s.do(t, createPullRequest("pr-2", headers))
+	if reused.StatusCode != http.StatusUnprocessableEntity || errorCode(t, reused) != models.KeyReusedErr {
+		t.Errorf("key reused with another body: status = %d, want %d",
+			reused.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestIdempotencyKeysAreScopedByCaller(t *testing.T) {
+	s := newTestServer(t, config.Config{AuthCfg: auth.AuthCfg{Enabled: true}})
+	alice, bob := apiToken(t, s, models.RoleAdmin), apiToken(t, s, models.RoleAdmin)
+	addTeam(t, s, "backend", "u1", "u2", "u3")
+
+	withKey := func(token string) map[string]string {
+		return map[string]string{"Idempotency-Key": "shared-key", "Authorization": "Bearer " + token}
+	}
+
+	if resp := s.do(t, createPullRequest("pr-1", withKey(alice))); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("alice's request status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	resp := s.do(t, createPullRequest("pr-2", withKey(bob)))
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("bob's request with the same key status = %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+	if resp.Header.Get("Idempotent-Replayed") != "" {
+		t.Error("bob got alice's response replayed")
+	}
+
+	resp = s.do(t, createPullRequest("pr-1", withKey(alice)))
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Errorf("alice's retry status = %d, replayed = %q, want the stored response",
+			resp.StatusCode, resp.Header.Get("Idempotent-Replayed"))
+	}
+}
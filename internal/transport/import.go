@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/importer"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+const maxImportSize = 10 << 20
+
+func (s *server) ImportTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	defer body.Close()
+
+	format := importer.FormatJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		var err error
+		format, err = importer.FormatFromContentType(contentType)
+		if err != nil {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: "unsupported content type, use text/csv, application/json or application/yaml",
+			})
+			return
+		}
+	}
+
+	var dryRun bool
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: "dry_run must be a boolean",
+			})
+			return
+		}
+	}
+
+	rows, err := importer.Parse(format, body)
+	if limit, tooLarge := bodyTooLarge(err); tooLarge {
+		s.respondWithError(w, http.StatusRequestEntityTooLarge, models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: fmt.Sprintf("request body must not exceed %d bytes", limit),
+		})
+		return
+	}
+	if err != nil {
+		code := models.InvalidReqErr
+		if format == importer.FormatJSON {
+			code = models.InvalidJSONErr
+		}
+
+		s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+			Code:    code,
+			Message: fmt.Sprintf("failed to parse %s: %v", format, err),
+		})
+		return
+	}
+
+	result, serviceErr := s.service.ImportTeams(r.Context(), models.ImportRequest{Rows: rows, DryRun: dryRun})
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, result)
+}
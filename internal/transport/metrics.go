@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+type HTTPMetrics interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+	Handler() http.Handler
+}
+
+// statusRecorder remembers the status code written by the handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// metricsMiddleware counts the requests by the matched route, so that path values don't blow up the labels.
+func metricsMiddleware(metrics HTTPMetrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		start := time.Now()
+		next.ServeHTTP(recorder, r)
+
+		if recorder.statusCode == 0 {
+			recorder.statusCode = http.StatusOK
+		}
+
+		metrics.ObserveRequest(r.Method, routeLabel(r.Pattern), recorder.statusCode, time.Since(start))
+	})
+}
+
+func routeLabel(pattern string) string {
+	if pattern == "" {
+		return "unmatched"
+	}
+
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return path
+	}
+
+	return pattern
+}
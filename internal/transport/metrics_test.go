@@ -0,0 +1,42 @@
+package transport_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func TestMetricsRequireAuthentication(t *testing.T) {
+	s := newTestServer(t, config.Config{AuthCfg: auth.AuthCfg{Enabled: true}})
+
+	resp := s.do(t, request{method: http.MethodGet, path: "/metrics"})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /metrics without a token status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	token := apiToken(t, s, models.RoleReadOnly)
+	resp = s.do(t, request{
+		method:  http.MethodGet,
+		path:    "/metrics",
+		headers: map[string]string{"Authorization": "Bearer " + token},
+	})
+	body := readBody(t, resp)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /metrics with a read-only token status = %d: %s", resp.StatusCode, body)
+	}
+	if !bytes.Contains(body, []byte("prreview_")) {
+		t.Errorf("GET /metrics body has no service metrics: %s", body)
+	}
+}
+
+func TestMetricsWithoutAuth(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+
+	if resp := s.do(t, request{method: http.MethodGet, path: "/metrics"}); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /metrics status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
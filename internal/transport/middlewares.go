@@ -2,8 +2,11 @@ package transport
 
 import (
 	"net/http"
+	"slices"
 	"time"
 
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
 	"go.uber.org/zap"
 )
 
@@ -25,3 +28,39 @@ func logsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		)
 	})
 }
+
+// authMiddleware authenticates the bearer token of the request and lets through only the given roles.
+func (s *server) authMiddleware(next http.HandlerFunc, roles ...string) http.HandlerFunc {
+	if !s.authEnabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := auth.BearerToken(r.Header.Get("Authorization"))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.respondWithError(w, http.StatusUnauthorized, models.ErrDetails{
+				Code:    models.UnauthorizedErr,
+				Message: "missing bearer token",
+			})
+			return
+		}
+
+		caller, serviceErr := s.authenticator.Authenticate(r.Context(), token)
+		if serviceErr != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+			return
+		}
+
+		if !slices.Contains(roles, caller.Role) {
+			s.respondWithError(w, http.StatusForbidden, models.ErrDetails{
+				Code:    models.ForbiddenErr,
+				Message: "insufficient permissions",
+			})
+			return
+		}
+
+		next(w, r.WithContext(auth.WithCaller(r.Context(), caller)))
+	})
+}
@@ -0,0 +1,451 @@
+package transport
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/mail"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type operation struct {
+	Parameters  []parameter  `json:"parameters"`
+	RequestBody *requestBody `json:"requestBody"`
+}
+
+type openAPIDocument struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// requestValidator checks query, path parameters and JSON bodies against the embedded OpenAPI spec.
+// Only the subset of JSON Schema used by the spec is supported.
+type requestValidator struct {
+	doc openAPIDocument
+}
+
+func newRequestValidator(spec []byte) (*requestValidator, error) {
+	var doc openAPIDocument
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+
+	return &requestValidator{doc: doc}, nil
+}
+
+// operation finds the spec operation for a ServeMux pattern like "POST /team/add".
+func (v *requestValidator) operation(pattern string) (*operation, bool) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		return nil, false
+	}
+
+	op, ok := v.doc.Paths[path][strings.ToLower(method)]
+	return op, ok && op != nil
+}
+
+func (v *requestValidator) validateParameters(op *operation, r *http.Request) []models.FieldError {
+	var errs []models.FieldError
+
+	query := r.URL.Query()
+	known := make(map[string]bool)
+	for _, param := range op.Parameters {
+		var value string
+		var present bool
+
+		switch param.In {
+		case "query":
+			known[param.Name] = true
+			present = query.Has(param.Name)
+			value = query.Get(param.Name)
+		case "path":
+			value = r.PathValue(param.Name)
+			present = value != ""
+		default:
+			continue
+		}
+
+		if !present {
+			if param.Required {
+				errs = append(errs, fieldError(param.Name, "required", "parameter is required"))
+			}
+			continue
+		}
+
+		parsed, err := parseParameter(param.Schema, value)
+		if err != nil {
+			errs = append(errs, fieldError(param.Name, "type", err.Error()))
+			continue
+		}
+
+		v.validateValue(param.Schema, parsed, param.Name, &errs)
+	}
+
+	unknown := make([]string, 0)
+	for name := range query {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		errs = append(errs, fieldError(name, "unknown", "unknown query parameter"))
+	}
+
+	return errs
+}
+
+func parseParameter(s *schema, value string) (any, error) {
+	if s == nil {
+		return value, nil
+	}
+
+	switch s.Type {
+	case "integer", "number":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a %s", s.Type)
+		}
+		return json.Number(strconv.FormatFloat(number, 'f', -1, 64)), nil
+	case "boolean":
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+		return flag, nil
+	default:
+		return value, nil
+	}
+}
+
+// hasJSONBody reports whether the request body has to be validated against the JSON schema of the operation.
+// Bodies of other media types declared by the operation are left to the handler and may be streamed.
+func hasJSONBody(op *operation, contentType string) bool {
+	if op.RequestBody == nil {
+		return false
+	}
+
+	if _, ok := op.RequestBody.Content["application/json"]; !ok {
+		return false
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType != "application/json" {
+		if _, ok := op.RequestBody.Content[mediaType]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateBody returns the decoding error separately, invalid JSON is reported as INVALID_JSON.
+func (v *requestValidator) validateBody(op *operation, body []byte) ([]models.FieldError, error) {
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return nil, nil
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return []models.FieldError{fieldError("body", "required", "request body is required")}, nil
+		}
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the top-level JSON value")
+	}
+
+	var errs []models.FieldError
+	v.validateValue(media.Schema, value, "", &errs)
+
+	return errs, nil
+}
+
+func (v *requestValidator) resolve(s *schema) *schema {
+	for s != nil && s.Ref != "" {
+		s = v.doc.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+
+	return s
+}
+
+func (v *requestValidator) validateValue(s *schema, value any, path string, errs *[]models.FieldError) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			*errs = append(*errs, fieldError(path, "type", "must be an object"))
+			return
+		}
+		v.validateObject(s, object, path, errs)
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			*errs = append(*errs, fieldError(path, "type", "must be an array"))
+			return
+		}
+		v.validateArray(s, array, path, errs)
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			*errs = append(*errs, fieldError(path, "type", "must be a string"))
+			return
+		}
+		validateString(s, str, path, errs)
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			*errs = append(*errs, fieldError(path, "type", "must be a "+s.Type))
+			return
+		}
+		validateNumber(s, number, path, errs)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, fieldError(path, "type", "must be a boolean"))
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, value) {
+		*errs = append(*errs, fieldError(path, "enum", fmt.Sprintf("must be one of %v", s.Enum)))
+	}
+}
+
+func (v *requestValidator) validateObject(s *schema, object map[string]any, path string, errs *[]models.FieldError) {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, fieldError(joinPath(path, name), "required", "field is required"))
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*errs = append(*errs, fieldError(joinPath(path, name), "unknown", "unknown field"))
+			}
+			continue
+		}
+
+		v.validateValue(property, object[name], joinPath(path, name), errs)
+	}
+}
+
+func (v *requestValidator) validateArray(s *schema, array []any, path string, errs *[]models.FieldError) {
+	if s.MinItems != nil && len(array) < *s.MinItems {
+		*errs = append(*errs, fieldError(path, "minItems", fmt.Sprintf("must contain at least %d items", *s.MinItems)))
+	}
+
+	if s.MaxItems != nil && len(array) > *s.MaxItems {
+		*errs = append(*errs, fieldError(path, "maxItems", fmt.Sprintf("must contain at most %d items", *s.MaxItems)))
+	}
+
+	for i, item := range array {
+		v.validateValue(s.Items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+	}
+}
+
+func validateString(s *schema, str, path string, errs *[]models.FieldError) {
+	length := utf8.RuneCountInString(str)
+	if s.MinLength != nil && length < *s.MinLength {
+		if *s.MinLength == 1 {
+			*errs = append(*errs, fieldError(path, "minLength", "must not be empty"))
+		} else {
+			*errs = append(*errs, fieldError(path, "minLength",
+				fmt.Sprintf("must be at least %d characters long", *s.MinLength)))
+		}
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		*errs = append(*errs, fieldError(path, "maxLength",
+			fmt.Sprintf("must be at most %d characters long", *s.MaxLength)))
+	}
+
+	switch s.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			*errs = append(*errs, fieldError(path, "format", "must be an RFC 3339 date-time"))
+		}
+	case "email":
+		if _, err := mail.ParseAddress(str); err != nil {
+			*errs = append(*errs, fieldError(path, "format", "must be an email address"))
+		}
+	}
+}
+
+func validateNumber(s *schema, number json.Number, path string, errs *[]models.FieldError) {
+	value, err := number.Float64()
+	if err != nil {
+		*errs = append(*errs, fieldError(path, "type", "must be a "+s.Type))
+		return
+	}
+
+	if s.Type == "integer" && value != math.Trunc(value) {
+		*errs = append(*errs, fieldError(path, "type", "must be an integer"))
+		return
+	}
+
+	if s.Minimum != nil && value < *s.Minimum {
+		*errs = append(*errs, fieldError(path, "minimum", fmt.Sprintf("must be at least %v", *s.Minimum)))
+	}
+
+	if s.Maximum != nil && value > *s.Maximum {
+		*errs = append(*errs, fieldError(path, "maximum", fmt.Sprintf("must be at most %v", *s.Maximum)))
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func fieldError(field, rule, message string) models.FieldError {
+	return models.FieldError{Field: field, Rule: rule, Message: message}
+}
+
+func (s *server) OpenAPIHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openAPISpec)
+}
+
+// maxJSONBodySize caps JSON request bodies read by the validation, the largest of them is a team import.
+const maxJSONBodySize = maxImportSize
+
+func bodyTooLarge(err error) (int64, bool) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return maxBytesErr.Limit, true
+	}
+
+	return 0, false
+}
+
+// validationMiddleware rejects requests that don't match the OpenAPI spec of their route.
+func (s *server) validationMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, ok := s.validator.operation(r.Pattern)
+		if !ok {
+			next(w, r)
+			return
+		}
+
+		errs := s.validator.validateParameters(op, r)
+
+		if hasJSONBody(op, r.Header.Get("Content-Type")) {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONBodySize))
+			r.Body.Close()
+			if limit, tooLarge := bodyTooLarge(err); tooLarge {
+				s.respondWithError(w, http.StatusRequestEntityTooLarge, models.ErrDetails{
+					Code:    models.InvalidReqErr,
+					Message: fmt.Sprintf("request body must not exceed %d bytes", limit),
+				})
+				return
+			}
+			if err != nil {
+				s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+					Code:    models.InvalidReqErr,
+					Message: fmt.Sprintf("failed to read body: %v", err),
+				})
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			bodyErrs, err := s.validator.validateBody(op, body)
+			if err != nil {
+				s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+					Code:    models.InvalidJSONErr,
+					Message: fmt.Sprintf("failed to decode json: %v", err),
+				})
+				return
+			}
+			errs = append(errs, bodyErrs...)
+		}
+
+		if len(errs) > 0 {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: "request does not match the API specification",
+				Details: errs,
+			})
+			return
+		}
+
+		next(w, r)
+	})
+}
@@ -0,0 +1,50 @@
+package transport_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func TestOversizedJSONBodyIsRejected(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+
+	// A syntactically valid body, so that only its size can fail the request.
+	padding := bytes.Repeat([]byte(" "), 11<<20)
+	body := append(append([]byte(`{"team_name":"t1","members":[]`), padding...), '}')
+
+	for _, path := range []string{"/team/add", "/team/import", "/api/v1/teams"} {
+		resp := s.do(t, request{method: http.MethodPost, path: path, body: body})
+		if resp.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("POST %s status = %d, want 413", path, resp.StatusCode)
+			continue
+		}
+		if code := errorCode(t, resp); code != models.InvalidReqErr {
+			t.Errorf("POST %s error code = %s, want %s", path, code, models.InvalidReqErr)
+		}
+	}
+
+	resp := s.do(t, request{method: http.MethodPost, path: "/scim/v2/Users", body: body})
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST /scim/v2/Users status = %d, want 413", resp.StatusCode)
+	}
+}
+
+func TestInvalidRequestIsRejectedWithDetails(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+
+	resp := s.do(t, request{method: http.MethodPost, path: "/team/add", body: map[string]any{
+		"team_name": "t1",
+		"members":   []map[string]any{{"user_id": "u1", "username": "a", "is_active": "yes"}},
+		"extra":     true,
+	}})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+	if code := errorCode(t, resp); code != models.InvalidReqErr {
+		t.Errorf("error code = %s, want %s", code, models.InvalidReqErr)
+	}
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (s *server) ListPullRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	filter, err := parsePullRequestFilter(r.URL.Query())
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, serviceErr := s.service.ListPullRequests(r.Context(), filter)
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func parsePullRequestFilter(query url.Values) (models.ListPullRequestsRequest, error) {
+	filter := models.ListPullRequestsRequest{
+		Status:     query.Get("status"),
+		AuthorID:   query.Get("author_id"),
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		SortBy:     query.Get("sort_by"),
+		Order:      query.Get("order"),
+		Cursor:     query.Get("cursor"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return filter, fmt.Errorf("limit must be an integer")
+		}
+		filter.Limit = value
+	}
+
+	timeParams := map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"merged_from":  &filter.MergedFrom,
+		"merged_to":    &filter.MergedTo,
+	}
+
+	for name, target := range timeParams {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 date-time", name)
+		}
+		*target = &parsed
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,785 @@
+package transport
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	scimContentType = "application/scim+json"
+	scimPathPrefix  = "/scim/v2"
+)
+
+// registerSCIMHandlers adds SCIM 2.0 provisioning for identity providers. SCIM has its own schema and error format,
+// so the routes aren't described in the OpenAPI spec and aren't validated against it.
+func (s *server) registerSCIMHandlers() {
+	routes := []struct {
+		pattern string
+		handler http.HandlerFunc
+	}{
+		{"GET /scim/v2/ServiceProviderConfig", s.SCIMServiceProviderConfigHandler},
+		{"GET /scim/v2/Users", s.SCIMListUsersHandler},
+		{"POST /scim/v2/Users", s.SCIMCreateUserHandler},
+		{"GET /scim/v2/Users/{id}", s.SCIMGetUserHandler},
+		{"PUT /scim/v2/Users/{id}", s.SCIMReplaceUserHandler},
+		{"PATCH /scim/v2/Users/{id}", s.SCIMPatchUserHandler},
+		{"DELETE /scim/v2/Users/{id}", s.SCIMDeleteUserHandler},
+		{"GET /scim/v2/Groups", s.SCIMListGroupsHandler},
+		{"POST /scim/v2/Groups", s.SCIMCreateGroupHandler},
+		{"GET /scim/v2/Groups/{id}", s.SCIMGetGroupHandler},
+		{"PUT /scim/v2/Groups/{id}", s.SCIMReplaceGroupHandler},
+		{"PATCH /scim/v2/Groups/{id}", s.SCIMPatchGroupHandler},
+		{"DELETE /scim/v2/Groups/{id}", s.SCIMDeleteGroupHandler},
+	}
+
+	for _, route := range routes {
+		s.mux.Handle(route.pattern, logsMiddleware(s.authMiddleware(route.handler, auth.AdminRoles()...)))
+	}
+}
+
+func (s *server) respondWithSCIM(w http.ResponseWriter, code int, resp any) {
+	w.Header().Set("Content-Type", scimContentType)
+
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		zap.L().Error("failed to encode JSON for response", zap.Error(err))
+	}
+}
+
+func (s *server) respondWithSCIMError(w http.ResponseWriter, code int, scimType, detail string) {
+	s.respondWithSCIM(w, code, models.SCIMError{
+		Schemas:  []string{models.SCIMErrorSchema},
+		Status:   strconv.Itoa(code),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+func (s *server) respondWithSCIMServiceError(w http.ResponseWriter, serviceErr *models.ErrDetails) {
+	code := s.mapServiceErrors(serviceErr.Code)
+
+	var scimType string
+	switch serviceErr.Code {
+	case models.UserExistsErr, models.TeamExistsErr:
+		code = http.StatusConflict
+		scimType = "uniqueness"
+	case models.InvalidReqErr, models.ValidationErr:
+		scimType = "invalidValue"
+	}
+
+	detail := serviceErr.Message
+	for _, fieldErr := range serviceErr.Details {
+		detail += fmt.Sprintf("; %s: %s", fieldErr.Field, fieldErr.Message)
+	}
+
+	s.respondWithSCIMError(w, code, scimType, detail)
+}
+
+func (s *server) scimIfMatchVersions(w http.ResponseWriter, r *http.Request) (models.Versions, bool) {
+	versions, ok := parseIfMatch(r)
+	if !ok {
+		s.respondWithSCIMError(w, http.StatusPreconditionFailed, "", ifMatchMessage)
+	}
+
+	return versions, ok
+}
+
+// respondWithSCIMGroup sends the version of the team both as the ETag and in meta.version.
+func (s *server) respondWithSCIMGroup(w http.ResponseWriter, code int, team *models.Team) {
+	setETag(w, team.Version)
+	s.respondWithSCIM(w, code, scimGroup(team))
+}
+
+func (s *server) decodeSCIM(w http.ResponseWriter, r *http.Request, v any) bool {
+	defer r.Body.Close()
+
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(v)
+	if limit, tooLarge := bodyTooLarge(err); tooLarge {
+		s.respondWithSCIMError(w, http.StatusRequestEntityTooLarge, "",
+			fmt.Sprintf("request body must not exceed %d bytes", limit))
+		return false
+	}
+	if err != nil {
+		s.respondWithSCIMError(w, http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("failed to decode json: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func (s *server) SCIMServiceProviderConfigHandler(w http.ResponseWriter, _ *http.Request) {
+	supported := func(value bool) map[string]bool {
+		return map[string]bool{"supported": value}
+	}
+
+	s.respondWithSCIM(w, http.StatusOK, map[string]any{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": models.MaxPageSize},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "API token of the admin role",
+			"primary":     true,
+		}},
+	})
+}
+
+// scimPage parses the 1-based startIndex and count query parameters.
+func scimPage(r *http.Request) (int, int, error) {
+	startIndex, count := 1, models.MaxPageSize
+
+	query := r.URL.Query()
+	if value := query.Get("startIndex"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("startIndex must be an integer")
+		}
+		startIndex = max(parsed, 1)
+	}
+
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("count must be an integer")
+		}
+		count = min(max(parsed, 0), models.MaxPageSize)
+	}
+
+	return startIndex, count, nil
+}
+
+// parseSCIMFilter supports the single `attribute eq "value"` expressions identity providers use to look resources up.
+func parseSCIMFilter(filter string) (string, string, error) {
+	attribute, rest, ok := strings.Cut(strings.TrimSpace(filter), " ")
+	if !ok {
+		return "", "", fmt.Errorf("unsupported filter %q", filter)
+	}
+
+	operator, value, ok := strings.Cut(strings.TrimSpace(rest), " ")
+	if !ok || !strings.EqualFold(operator, "eq") {
+		return "", "", fmt.Errorf("unsupported filter %q, only eq is supported", filter)
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimSpace(value))
+	if err != nil {
+		return "", "", fmt.Errorf("filter value must be a quoted string")
+	}
+
+	return attribute, unquoted, nil
+}
+
+func scimUser(user models.User) models.SCIMUser {
+	active := user.IsActive
+	resource := models.SCIMUser{
+		Schemas:     []string{models.SCIMUserSchema, models.SCIMEnterpriseUserSchema},
+		ID:          user.ID,
+		ExternalID:  user.ID,
+		UserName:    user.Username,
+		DisplayName: user.Username,
+		Active:      &active,
+		Groups: []models.SCIMMember{{
+			Value:   user.TeamName,
+			Display: user.TeamName,
+			Ref:     scimPathPrefix + "/Groups/" + url.PathEscape(user.TeamName),
+		}},
+		Enterprise: &models.SCIMEnterpriseUser{Department: user.TeamName},
+		Meta: &models.SCIMMeta{
+			ResourceType: "User",
+			Location:     scimPathPrefix + "/Users/" + url.PathEscape(user.ID),
+		},
+	}
+
+	if user.Email != "" {
+		resource.Emails = []models.SCIMEmail{{Value: user.Email, Type: "work", Primary: true}}
+	}
+
+	return resource
+}
+
+func scimGroup(team *models.Team) models.SCIMGroup {
+	group := models.SCIMGroup{
+		Schemas:     []string{models.SCIMGroupSchema},
+		ID:          team.Name,
+		DisplayName: team.Name,
+		Members:     make([]models.SCIMMember, 0, len(team.Members)),
+		Meta: &models.SCIMMeta{
+			ResourceType: "Group",
+			Location:     scimPathPrefix + "/Groups/" + url.PathEscape(team.Name),
+		},
+	}
+
+	if team.Version > 0 {
+		group.Meta.Version = formatETag(team.Version)
+	}
+
+	for _, member := range team.Members {
+		group.Members = append(group.Members, models.SCIMMember{
+			Value:   member.ID,
+			Display: member.Username,
+			Ref:     scimPathPrefix + "/Users/" + url.PathEscape(member.ID),
+		})
+	}
+
+	return group
+}
+
+func primaryEmail(emails []models.SCIMEmail) string {
+	for _, email := range emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+
+	return ""
+}
+
+// applySCIMUser copies the attributes of a SCIM user onto the user, missing team and activity are kept.
+func applySCIMUser(user *models.User, resource models.SCIMUser) {
+	user.Username = resource.UserName
+	user.Email = primaryEmail(resource.Emails)
+
+	if resource.Active != nil {
+		user.IsActive = *resource.Active
+	}
+
+	if resource.Enterprise != nil && resource.Enterprise.Department != "" {
+		user.TeamName = resource.Enterprise.Department
+	}
+}
+
+// newUserID generates a user_id for users provisioned without an externalId.
+func newUserID() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate user id: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+func (s *server) SCIMListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, err := scimPage(r)
+	if err != nil {
+		s.respondWithSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+
+	filter := models.DirectoryUserFilter{Offset: startIndex - 1, Limit: count}
+	if value := r.URL.Query().Get("filter"); value != "" {
+		attribute, value, err := parseSCIMFilter(value)
+		if err != nil {
+			s.respondWithSCIMError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+
+		switch strings.ToLower(attribute) {
+		case "id", "externalid":
+			filter.ID = value
+		case "username":
+			filter.Username = value
+		case "emails", "emails.value":
+			filter.Email = value
+		default:
+			s.respondWithSCIMError(w, http.StatusBadRequest, "invalidFilter",
+				fmt.Sprintf("filtering by %s is not supported", attribute))
+			return
+		}
+	}
+
+	// count=0 asks only for totalResults.
+	if count == 0 {
+		filter.Limit = 1
+	}
+
+	users, total, serviceErr := s.service.ListDirectoryUsers(r.Context(), filter)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, user := range users[:min(count, len(users))] {
+		resources = append(resources, scimUser(user))
+	}
+
+	s.respondWithSCIM(w, http.StatusOK, models.SCIMListResponse{
+		Schemas:      []string{models.SCIMListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (s *server) SCIMCreateUserHandler(w http.ResponseWriter, r *http.Request) {
+	var resource models.SCIMUser
+	if !s.decodeSCIM(w, r, &resource) {
+		return
+	}
+
+	user := models.User{ID: resource.ExternalID, TeamName: s.scimDefaultTeam, IsActive: true}
+	if user.ID == "" {
+		var err error
+		user.ID, err = newUserID()
+		if err != nil {
+			zap.L().Error("failed to provision user", zap.Error(err))
+			s.respondWithSCIMError(w, http.StatusInternalServerError, "", "failed to generate user id")
+			return
+		}
+	}
+	applySCIMUser(&user, resource)
+
+	created, serviceErr := s.service.CreateDirectoryUser(r.Context(), user)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	w.Header().Set("Location", scimPathPrefix+"/Users/"+url.PathEscape(created.ID))
+	s.respondWithSCIM(w, http.StatusCreated, scimUser(*created))
+}
+
+func (s *server) SCIMGetUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.scimUserByID(w, r)
+	if !ok {
+		return
+	}
+
+	s.respondWithSCIM(w, http.StatusOK, scimUser(*user))
+}
+
+func (s *server) scimUserByID(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	users, _, serviceErr := s.service.ListDirectoryUsers(r.Context(), models.DirectoryUserFilter{
+		ID:    r.PathValue("id"),
+		Limit: 1,
+	})
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return nil, false
+	}
+
+	if len(users) == 0 {
+		s.respondWithSCIMError(w, http.StatusNotFound, "", "user not found")
+		return nil, false
+	}
+
+	return &users[0], true
+}
+
+func (s *server) SCIMReplaceUserHandler(w http.ResponseWriter, r *http.Request) {
+	var resource models.SCIMUser
+	if !s.decodeSCIM(w, r, &resource) {
+		return
+	}
+
+	user, ok := s.scimUserByID(w, r)
+	if !ok {
+		return
+	}
+	applySCIMUser(user, resource)
+
+	s.serveSCIMUserUpdate(w, r, *user)
+}
+
+func (s *server) serveSCIMUserUpdate(w http.ResponseWriter, r *http.Request, user models.User) {
+	updated, serviceErr := s.service.UpdateDirectoryUser(r.Context(), user)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	s.respondWithSCIM(w, http.StatusOK, scimUser(*updated))
+}
+
+func (s *server) SCIMPatchUserHandler(w http.ResponseWriter, r *http.Request) {
+	var patch models.SCIMPatchRequest
+	if !s.decodeSCIM(w, r, &patch) {
+		return
+	}
+
+	user, ok := s.scimUserByID(w, r)
+	if !ok {
+		return
+	}
+
+	for _, operation := range patch.Operations {
+		if err := applySCIMUserPatch(user, operation); err != nil {
+			s.respondWithSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+	}
+
+	s.serveSCIMUserUpdate(w, r, *user)
+}
+
+// applySCIMUserPatch applies a PATCH operation, either with a path or with an object of attributes as the value.
+func applySCIMUserPatch(user *models.User, operation models.SCIMPatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return fmt.Errorf("unsupported operation %q", operation.Op)
+	}
+
+	if operation.Path == "" {
+		if op == "remove" {
+			return fmt.Errorf("remove requires a path")
+		}
+
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return fmt.Errorf("value must be an object of attributes")
+		}
+
+		for path, value := range attributes {
+			if err := applySCIMUserAttribute(user, op, path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return applySCIMUserAttribute(user, op, operation.Path, operation.Value)
+}
+
+func applySCIMUserAttribute(user *models.User, op, path string, value json.RawMessage) error {
+	attribute := strings.ToLower(path)
+	if op == "remove" {
+		if strings.HasPrefix(attribute, "emails") {
+			user.Email = ""
+			return nil
+		}
+		return fmt.Errorf("attribute %s can't be removed", path)
+	}
+
+	switch {
+	case attribute == "active":
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		user.IsActive = active
+	case attribute == "username":
+		return json.Unmarshal(value, &user.Username)
+	case attribute == "emails":
+		var emails []models.SCIMEmail
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return fmt.Errorf("emails must be a list of emails")
+		}
+		user.Email = primaryEmail(emails)
+	case strings.HasPrefix(attribute, "emails[") && strings.HasSuffix(attribute, "].value"):
+		return json.Unmarshal(value, &user.Email)
+	case attribute == strings.ToLower(models.SCIMEnterpriseUserSchema+":department"):
+		return json.Unmarshal(value, &user.TeamName)
+	case attribute == strings.ToLower(models.SCIMEnterpriseUserSchema):
+		var enterprise models.SCIMEnterpriseUser
+		if err := json.Unmarshal(value, &enterprise); err != nil {
+			return fmt.Errorf("invalid enterprise extension")
+		}
+		if enterprise.Department != "" {
+			user.TeamName = enterprise.Department
+		}
+	case attribute == "displayname", attribute == "externalid", attribute == "name",
+		strings.HasPrefix(attribute, "name."):
+		// The username is taken from userName and user_id can't change, other attributes aren't stored.
+	default:
+		return fmt.Errorf("unsupported attribute %s", path)
+	}
+
+	return nil
+}
+
+// scimBool accepts both JSON booleans and the "True"/"False" strings some identity providers send.
+func scimBool(value json.RawMessage) (bool, error) {
+	var flag bool
+	if err := json.Unmarshal(value, &flag); err == nil {
+		return flag, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(value, &str); err == nil {
+		if parsed, err := strconv.ParseBool(str); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return false, fmt.Errorf("active must be a boolean")
+}
+
+// SCIMDeleteUserHandler deactivates the user, users are never deleted since pull requests reference them.
+func (s *server) SCIMDeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	_, serviceErr := s.service.SetUserStatus(r.Context(), models.SetUserStatusRequest{
+		ID:       r.PathValue("id"),
+		IsActive: false,
+	})
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) SCIMListGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, err := scimPage(r)
+	if err != nil {
+		s.respondWithSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+
+	var teamName string
+	if value := r.URL.Query().Get("filter"); value != "" {
+		attribute, value, err := parseSCIMFilter(value)
+		if err != nil {
+			s.respondWithSCIMError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+
+		if !strings.EqualFold(attribute, "displayName") && !strings.EqualFold(attribute, "id") {
+			s.respondWithSCIMError(w, http.StatusBadRequest, "invalidFilter",
+				fmt.Sprintf("filtering by %s is not supported", attribute))
+			return
+		}
+		teamName = value
+	}
+
+	limit := count
+	if limit == 0 {
+		limit = 1
+	}
+
+	teams, total, serviceErr := s.service.ListDirectoryTeams(r.Context(), teamName, startIndex-1, limit)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	excludeMembers := strings.Contains(r.URL.Query().Get("excludedAttributes"), "members")
+
+	resources := make([]any, 0, len(teams))
+	for _, team := range teams[:min(count, len(teams))] {
+		group := scimGroup(team)
+		if excludeMembers {
+			group.Members = nil
+		}
+		resources = append(resources, group)
+	}
+
+	s.respondWithSCIM(w, http.StatusOK, models.SCIMListResponse{
+		Schemas:      []string{models.SCIMListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func scimMemberIDs(members []models.SCIMMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.Value)
+	}
+
+	return ids
+}
+
+func (s *server) SCIMCreateGroupHandler(w http.ResponseWriter, r *http.Request) {
+	var group models.SCIMGroup
+	if !s.decodeSCIM(w, r, &group) {
+		return
+	}
+
+	team, serviceErr := s.service.CreateDirectoryTeam(r.Context(), group.DisplayName, scimMemberIDs(group.Members))
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	w.Header().Set("Location", scimPathPrefix+"/Groups/"+url.PathEscape(team.Name))
+	s.respondWithSCIMGroup(w, http.StatusCreated, team)
+}
+
+func (s *server) SCIMGetGroupHandler(w http.ResponseWriter, r *http.Request) {
+	team, serviceErr := s.service.GetDirectoryTeam(r.Context(), r.PathValue("id"))
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	s.respondWithSCIMGroup(w, http.StatusOK, team)
+}
+
+func (s *server) SCIMReplaceGroupHandler(w http.ResponseWriter, r *http.Request) {
+	var group models.SCIMGroup
+	if !s.decodeSCIM(w, r, &group) {
+		return
+	}
+
+	teamName := r.PathValue("id")
+	if group.DisplayName != "" && group.DisplayName != teamName {
+		s.respondWithSCIMError(w, http.StatusBadRequest, "mutability", "groups can't be renamed")
+		return
+	}
+
+	ifMatch, ok := s.scimIfMatchVersions(w, r)
+	if !ok {
+		return
+	}
+
+	team, serviceErr := s.service.SetDirectoryTeamMembers(r.Context(), teamName, ifMatch,
+		scimMemberIDs(group.Members), s.scimDefaultTeam)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	s.respondWithSCIMGroup(w, http.StatusOK, team)
+}
+
+// SCIMPatchGroupHandler turns the operations into member changes, the service applies them in order
+// to the members of the locked team, so concurrent PATCH requests don't drop each other's changes.
+func (s *server) SCIMPatchGroupHandler(w http.ResponseWriter, r *http.Request) {
+	var patch models.SCIMPatchRequest
+	if !s.decodeSCIM(w, r, &patch) {
+		return
+	}
+
+	ifMatch, ok := s.scimIfMatchVersions(w, r)
+	if !ok {
+		return
+	}
+
+	teamName := r.PathValue("id")
+	changes := make([]models.TeamMembersChange, 0, len(patch.Operations))
+	for _, operation := range patch.Operations {
+		change, err := scimGroupPatchChange(teamName, operation)
+		if err != nil {
+			s.respondWithSCIMError(w, http.StatusBadRequest, scimTypeOf(err), err.Error())
+			return
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	team, serviceErr := s.service.UpdateDirectoryTeamMembers(r.Context(), teamName, ifMatch, changes, s.scimDefaultTeam)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	s.respondWithSCIMGroup(w, http.StatusOK, team)
+}
+
+// scimPatchError carries the scimType of a rejected PATCH operation.
+type scimPatchError struct {
+	scimType string
+	detail   string
+}
+
+func (e *scimPatchError) Error() string {
+	return e.detail
+}
+
+func scimTypeOf(err error) string {
+	var patchErr *scimPatchError
+	if errors.As(err, &patchErr) {
+		return patchErr.scimType
+	}
+
+	return "invalidValue"
+}
+
+// scimGroupPatchChange converts a PATCH operation into a change of the members,
+// nil is returned for operations that leave the members as they are.
+func scimGroupPatchChange(teamName string, operation models.SCIMPatchOperation) (*models.TeamMembersChange, error) {
+	op := strings.ToLower(operation.Op)
+	path := strings.ToLower(operation.Path)
+
+	var changed []models.SCIMMember
+	switch {
+	case path == "" && op != "remove":
+		var attributes struct {
+			DisplayName string               `json:"displayName"`
+			Members     *[]models.SCIMMember `json:"members"`
+		}
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return nil, &scimPatchError{"invalidValue", "value must be an object of attributes"}
+		}
+		if attributes.DisplayName != "" && attributes.DisplayName != teamName {
+			return nil, &scimPatchError{"mutability", "groups can't be renamed"}
+		}
+		if attributes.Members == nil {
+			return nil, nil
+		}
+		changed = *attributes.Members
+	case path == "members":
+		if len(operation.Value) > 0 {
+			if err := json.Unmarshal(operation.Value, &changed); err != nil {
+				return nil, &scimPatchError{"invalidValue", "members must be a list"}
+			}
+		} else if op == "remove" {
+			return &models.TeamMembersChange{Op: models.MembersReplace}, nil
+		}
+	case op == "remove" && strings.HasPrefix(path, "members[") && strings.HasSuffix(path, "]"):
+		_, value, err := parseSCIMFilter(operation.Path[len("members[") : len(operation.Path)-1])
+		if err != nil {
+			return nil, &scimPatchError{"invalidPath", err.Error()}
+		}
+		changed = []models.SCIMMember{{Value: value}}
+	case path == "displayname":
+		var displayName string
+		if err := json.Unmarshal(operation.Value, &displayName); err != nil || displayName != teamName {
+			return nil, &scimPatchError{"mutability", "groups can't be renamed"}
+		}
+		return nil, nil
+	default:
+		return nil, &scimPatchError{"invalidPath", fmt.Sprintf("unsupported path %q", operation.Path)}
+	}
+
+	ids := scimMemberIDs(changed)
+	switch op {
+	case "add":
+		return &models.TeamMembersChange{Op: models.MembersAdd, MemberIDs: ids}, nil
+	case "remove":
+		return &models.TeamMembersChange{Op: models.MembersRemove, MemberIDs: ids}, nil
+	case "replace":
+		return &models.TeamMembersChange{Op: models.MembersReplace, MemberIDs: ids}, nil
+	default:
+		return nil, &scimPatchError{"invalidValue", fmt.Sprintf("unsupported operation %q", operation.Op)}
+	}
+}
+
+// SCIMDeleteGroupHandler deletes the team, its members are moved to the default SCIM team.
+func (s *server) SCIMDeleteGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ifMatch, ok := s.scimIfMatchVersions(w, r)
+	if !ok {
+		return
+	}
+
+	serviceErr := s.service.DeleteDirectoryTeam(r.Context(), r.PathValue("id"), ifMatch, s.scimDefaultTeam)
+	if serviceErr != nil {
+		s.respondWithSCIMServiceError(w, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,246 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// decodeSCIM reads the response into v after checking its status.
+func decodeSCIM(t *testing.T, resp *http.Response, status int, v any) {
+	t.Helper()
+
+	body := readBody(t, resp)
+	if resp.StatusCode != status {
+		t.Fatalf("status = %d, want %d: %s", resp.StatusCode, status, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("failed to decode SCIM response: %v", err)
+	}
+}
+
+func scimPatch(operations ...models.SCIMPatchOperation) models.SCIMPatchRequest {
+	return models.SCIMPatchRequest{Schemas: []string{models.SCIMPatchOpSchema}, Operations: operations}
+}
+
+func scimMembers(group models.SCIMGroup) []string {
+	ids := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		ids = append(ids, member.Value)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+func TestSCIMUsers(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2", "u3", "u4")
+
+	var user models.SCIMUser
+	resp := s.do(t, request{method: http.MethodPost, path: "/scim/v2/Users", body: models.SCIMUser{
+		Schemas:    []string{models.SCIMUserSchema},
+		ExternalID: "alice",
+		UserName:   "Alice",
+		Emails:     []models.SCIMEmail{{Value: "alice@example.com", Primary: true}},
+	}})
+	decodeSCIM(t, resp, http.StatusCreated, &user)
+	if resp.Header.Get("Location") != "/scim/v2/Users/alice" || user.ID != "alice" || user.UserName != "Alice" ||
+		user.Active == nil || !*user.Active || user.Groups[0].Value != "unassigned" {
+		t.Errorf("POST /Users = %+v, Location %q, want active alice in the default team",
+			user, resp.Header.Get("Location"))
+	}
+
+	var scimErr models.SCIMError
+	resp = s.do(t, request{method: http.MethodPost, path: "/scim/v2/Users", body: models.SCIMUser{
+		Schemas: []string{models.SCIMUserSchema}, ExternalID: "bob", Emails: []models.SCIMEmail{{Value: "bob"}},
+	}})
+	decodeSCIM(t, resp, http.StatusBadRequest, &scimErr)
+	want := "request validation failed; username: field is required; email: must be an email address"
+	if scimErr.SCIMType != "invalidValue" || scimErr.Detail != want {
+		t.Errorf("POST /Users without userName = %+v, want detail %q", scimErr, want)
+	}
+
+	active := false
+	resp = s.do(t, request{method: http.MethodPut, path: "/scim/v2/Users/alice", body: models.SCIMUser{
+		Schemas:    []string{models.SCIMUserSchema, models.SCIMEnterpriseUserSchema},
+		UserName:   "Alice Smith",
+		Active:     &active,
+		Emails:     []models.SCIMEmail{{Value: "smith@example.com"}},
+		Enterprise: &models.SCIMEnterpriseUser{Department: "backend"},
+	}})
+	decodeSCIM(t, resp, http.StatusOK, &user)
+	if user.UserName != "Alice Smith" || *user.Active || user.Emails[0].Value != "smith@example.com" ||
+		user.Groups[0].Value != "backend" {
+		t.Errorf("PUT /Users/alice = %+v, want inactive Alice Smith in backend", user)
+	}
+
+	resp = s.do(t, request{method: http.MethodPatch, path: "/scim/v2/Users/alice", body: scimPatch(
+		models.SCIMPatchOperation{Op: "replace", Path: "active", Value: json.RawMessage(`"True"`)},
+		models.SCIMPatchOperation{Op: "replace", Value: json.RawMessage(`{"userName":"asmith"}`)},
+	)})
+	decodeSCIM(t, resp, http.StatusOK, &user)
+	if user.UserName != "asmith" || !*user.Active || user.Groups[0].Value != "backend" {
+		t.Errorf("PATCH /Users/alice = %+v, want active asmith", user)
+	}
+
+	for _, filter := range []string{`userName eq "asmith"`, `emails.value eq "smith@example.com"`,
+		`externalId eq "alice"`} {
+		var list struct {
+			TotalResults int               `json:"totalResults"`
+			Resources    []models.SCIMUser `json:"Resources"`
+		}
+		resp = s.do(t, request{method: http.MethodGet, path: "/scim/v2/Users?filter=" + url.QueryEscape(filter)})
+		decodeSCIM(t, resp, http.StatusOK, &list)
+		if list.TotalResults != 1 || len(list.Resources) != 1 || list.Resources[0].ID != "alice" {
+			t.Errorf("GET /Users?filter=%s = %+v, want only alice", filter, list)
+		}
+	}
+
+	resp = s.do(t, request{method: http.MethodGet, path: "/scim/v2/Users?filter=" + url.QueryEscape(`name eq "x"`)})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("GET /Users with an unsupported filter status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestSCIMDeactivationReassignsReviews(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2", "u3", "u4")
+
+	pr, errDetails := s.service.CreatePullRequest(t.Context(), models.CreatePRRequest{
+		ID: "pr-1", Name: "Add search", AuthorID: "u1",
+	})
+	if errDetails != nil {
+		t.Fatalf("CreatePullRequest() error = %+v", errDetails)
+	}
+	reviewer := pr.AssignedReviewers[0]
+
+	resp := s.do(t, request{method: http.MethodPatch, path: "/scim/v2/Users/" + reviewer, body: scimPatch(
+		models.SCIMPatchOperation{Op: "replace", Path: "active", Value: json.RawMessage(`false`)},
+	)})
+	var user models.SCIMUser
+	decodeSCIM(t, resp, http.StatusOK, &user)
+	if *user.Active {
+		t.Errorf("PATCH /Users/%s = %+v, want an inactive user", reviewer, user)
+	}
+
+	details, errDetails := s.service.GetPullRequest(t.Context(), "pr-1")
+	if errDetails != nil {
+		t.Fatalf("GetPullRequest() error = %+v", errDetails)
+	}
+	if len(details.AssignedReviewers) != 2 || slices.Contains(details.AssignedReviewers, reviewer) {
+		t.Errorf("reviewers after deactivating %s = %v, want two other reviewers", reviewer, details.AssignedReviewers)
+	}
+}
+
+func TestSCIMGroups(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2", "u3", "u4")
+
+	var group models.SCIMGroup
+	resp := s.do(t, request{method: http.MethodPost, path: "/scim/v2/Groups", body: models.SCIMGroup{
+		Schemas:     []string{models.SCIMGroupSchema},
+		DisplayName: "platform",
+		Members:     []models.SCIMMember{{Value: "u1"}},
+	}})
+	decodeSCIM(t, resp, http.StatusCreated, &group)
+	if group.ID != "platform" || !slices.Equal(scimMembers(group), []string{"u1"}) || resp.Header.Get("ETag") == "" {
+		t.Fatalf("POST /Groups = %+v, ETag %q, want platform with u1", group, resp.Header.Get("ETag"))
+	}
+	created := resp.Header.Get("ETag")
+
+	replace := func(ifMatch string, members ...string) *http.Response {
+		group := models.SCIMGroup{Schemas: []string{models.SCIMGroupSchema}, DisplayName: "platform"}
+		for _, id := range members {
+			group.Members = append(group.Members, models.SCIMMember{Value: id})
+		}
+		return s.do(t, request{method: http.MethodPut, path: "/scim/v2/Groups/platform", body: group,
+			headers: map[string]string{"If-Match": ifMatch}})
+	}
+
+	resp = replace(created, "u1", "u2")
+	decodeSCIM(t, resp, http.StatusOK, &group)
+	if !slices.Equal(scimMembers(group), []string{"u1", "u2"}) {
+		t.Errorf("PUT /Groups/platform members = %v, want u1 and u2", scimMembers(group))
+	}
+	if resp = replace(created, "u3"); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("PUT /Groups/platform with a stale ETag status = %d, want %d",
+			resp.StatusCode, http.StatusPreconditionFailed)
+	}
+
+	resp = s.do(t, request{method: http.MethodPatch, path: "/scim/v2/Groups/platform", body: scimPatch(
+		models.SCIMPatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"u3"}]`)},
+		models.SCIMPatchOperation{Op: "remove", Path: `members[value eq "u1"]`},
+		models.SCIMPatchOperation{Op: "replace", Value: json.RawMessage(`{"displayName":"platform"}`)},
+	)})
+	decodeSCIM(t, resp, http.StatusOK, &group)
+	if !slices.Equal(scimMembers(group), []string{"u2", "u3"}) {
+		t.Errorf("PATCH /Groups/platform members = %v, want u2 and u3", scimMembers(group))
+	}
+
+	resp = s.do(t, request{method: http.MethodGet, path: "/scim/v2/Groups/unassigned"})
+	decodeSCIM(t, resp, http.StatusOK, &group)
+	if !slices.Equal(scimMembers(group), []string{"u1"}) {
+		t.Errorf("members of the default team = %v, want the removed u1", scimMembers(group))
+	}
+
+	resp = s.do(t, request{method: http.MethodPatch, path: "/scim/v2/Groups/platform", body: scimPatch(
+		models.SCIMPatchOperation{Op: "replace", Path: "displayName", Value: json.RawMessage(`"renamed"`)},
+	)})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("PATCH renaming the group status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var list struct {
+		TotalResults int                `json:"totalResults"`
+		Resources    []models.SCIMGroup `json:"Resources"`
+	}
+	filter := url.QueryEscape(`displayName eq "platform"`)
+	resp = s.do(t, request{method: http.MethodGet, path: "/scim/v2/Groups?excludedAttributes=members&filter=" + filter})
+	decodeSCIM(t, resp, http.StatusOK, &list)
+	if list.TotalResults != 1 || len(list.Resources) != 1 || list.Resources[0].ID != "platform" ||
+		len(list.Resources[0].Members) != 0 {
+		t.Errorf("GET /Groups?filter=%s = %+v, want platform without members", filter, list)
+	}
+}
+
+func TestSCIMConcurrentGroupPatchesKeepEveryChange(t *testing.T) {
+	const users = 20
+
+	s := newTestServer(t, config.Config{})
+	ids := make([]string, 0, users)
+	for i := range users {
+		ids = append(ids, fmt.Sprintf("u%02d", i))
+	}
+	addTeam(t, s, "backend", ids...)
+	if _, errDetails := s.service.CreateDirectoryTeam(t.Context(), "platform", nil); errDetails != nil {
+		t.Fatalf("CreateDirectoryTeam() error = %+v", errDetails)
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Go(func() {
+			value := json.RawMessage(fmt.Sprintf(`[{"value":%q}]`, id))
+			resp := s.do(t, request{method: http.MethodPatch, path: "/scim/v2/Groups/platform", body: scimPatch(
+				models.SCIMPatchOperation{Op: "add", Path: "members", Value: value},
+			)})
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("PATCH adding %s status = %d", id, resp.StatusCode)
+			}
+		})
+	}
+	wg.Wait()
+
+	var group models.SCIMGroup
+	decodeSCIM(t, s.do(t, request{method: http.MethodGet, path: "/scim/v2/Groups/platform"}), http.StatusOK, &group)
+	if !slices.Equal(scimMembers(group), ids) {
+		t.Errorf("members after concurrent PATCH requests = %v, want %v", scimMembers(group), ids)
+	}
+}
@@ -2,9 +2,12 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
 	"github.com/vedsatt/pr-review-assignment-service/internal/config"
 	"github.com/vedsatt/pr-review-assignment-service/internal/models"
 	"go.uber.org/zap"
@@ -13,10 +16,21 @@ import (
 type PRService interface {
 	AddTeam(ctx context.Context, team models.AddTeamRequest) (*models.Team, *models.ErrDetails)
 	GetTeam(ctx context.Context, teamName string) (*models.Team, *models.ErrDetails)
+	ImportTeams(ctx context.Context, request models.ImportRequest) (*models.ImportResult, *models.ErrDetails)
 	SetUserStatus(ctx context.Context, userSettings models.SetUserStatusRequest) (models.User, *models.ErrDetails)
-	GetUserReviews(ctx context.Context, userID string) ([]*models.PullRequestShort, *models.ErrDetails)
+	GetUser(ctx context.Context, userID string) (*models.UserDetails, *models.ErrDetails)
+	ListUsers(ctx context.Context, filter models.ListUsersRequest) (*models.ListUsersResponse, *models.ErrDetails)
+	GetUserReviews(
+		ctx context.Context,
+		request models.UserReviewsRequest,
+	) (*models.GetUserReviewsResponse, *models.ErrDetails)
 	CreatePullRequest(ctx context.Context, pullRequest models.CreatePRRequest) (*models.PullRequest, *models.ErrDetails)
-	MergePullRequest(ctx context.Context, pullRequestID string) (models.PullRequest, *models.ErrDetails)
+	GetPullRequest(ctx context.Context, pullRequestID string) (*models.PullRequestDetails, *models.ErrDetails)
+	ListPullRequests(
+		ctx context.Context,
+		filter models.ListPullRequestsRequest,
+	) (*models.ListPullRequestsResponse, *models.ErrDetails)
+	MergePullRequest(ctx context.Context, request models.MergePRRequest) (models.PullRequest, *models.ErrDetails)
 	ReassignPullRequestReviewer(
 		ctx context.Context,
 		prSettings models.ReassignPRReviewerRequest,
@@ -24,65 +38,171 @@ type PRService interface {
 	GetUsersStatistics(ctx context.Context) (*models.UserStatsResponse, *models.ErrDetails)
 	GetPullRequestStatistics(ctx context.Context) (*models.PullRequestsStatsResponse, *models.ErrDetails)
 	GetReviewersStatistics(ctx context.Context) (*models.ReviewersStatsResponse, *models.ErrDetails)
+	Authenticate(ctx context.Context, rawToken string) (*models.Caller, *models.ErrDetails)
+	AuthenticateIdentity(ctx context.Context, identity models.Identity) (*models.Caller, *models.ErrDetails)
+	CreateAPIToken(
+		ctx context.Context,
+		request models.CreateAPITokenRequest,
+	) (*models.CreateAPITokenResponse, *models.ErrDetails)
+	RevokeAPIToken(ctx context.Context, tokenID int64) *models.ErrDetails
+	ExportBackup(ctx context.Context, write func(models.BackupRecord) error) *models.ErrDetails
+	RestoreBackup(ctx context.Context, reader models.BackupReader) (*models.BackupCounts, *models.ErrDetails)
+	CreateDirectoryUser(ctx context.Context, user models.User) (*models.User, *models.ErrDetails)
+	UpdateDirectoryUser(ctx context.Context, user models.User) (*models.User, *models.ErrDetails)
+	ListDirectoryUsers(ctx context.Context, filter models.DirectoryUserFilter) ([]models.User, int, *models.ErrDetails)
+	GetDirectoryTeam(ctx context.Context, teamName string) (*models.Team, *models.ErrDetails)
+	ListDirectoryTeams(
+		ctx context.Context,
+		teamName string,
+		offset, limit int,
+	) ([]*models.Team, int, *models.ErrDetails)
+	CreateDirectoryTeam(ctx context.Context, teamName string, memberIDs []string) (*models.Team, *models.ErrDetails)
+	UpdateDirectoryTeamMembers(
+		ctx context.Context,
+		teamName string,
+		ifMatch models.Versions,
+		changes []models.TeamMembersChange,
+		fallbackTeam string,
+	) (*models.Team, *models.ErrDetails)
+	SetDirectoryTeamMembers(
+		ctx context.Context,
+		teamName string,
+		ifMatch models.Versions,
+		memberIDs []string,
+		fallbackTeam string,
+	) (*models.Team, *models.ErrDetails)
+	DeleteDirectoryTeam(
+		ctx context.Context,
+		teamName string,
+		ifMatch models.Versions,
+		fallbackTeam string,
+	) *models.ErrDetails
+	ClaimIdempotencyKey(ctx context.Context, key string) (*models.IdempotencyRecord, *models.ErrDetails)
+	SaveIdempotentResponse(ctx context.Context, record models.IdempotencyRecord, ttl time.Duration) *models.ErrDetails
+	ReleaseIdempotencyKey(ctx context.Context, key string) *models.ErrDetails
 }
 
 type server struct {
-	httpServer *http.Server
-	mux        *http.ServeMux
-	service    PRService
+	httpServer      *http.Server
+	mux             *http.ServeMux
+	service         PRService
+	authEnabled     bool
+	authenticator   *auth.Authenticator
+	validator       *requestValidator
+	scimDefaultTeam string
+	idempotencyTTL  time.Duration
+	metrics         HTTPMetrics
 }
 
-func StartServer(cfg *config.Config, service PRService) *http.Server {
+func StartServer(cfg *config.Config, service PRService, metrics HTTPMetrics) *http.Server {
+	server := newServer(cfg, service, metrics)
+
+	go func() {
+		// Shutdown closes the server, the process has to go on flushing notifications.
+		err := server.httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Fatal("failed to start server", zap.Error(err))
+		}
+	}()
+
+	return server.httpServer
+}
+
+func newServer(cfg *config.Config, service PRService, metrics HTTPMetrics) *server {
 	mux := http.NewServeMux()
 
 	const defaultTimeout = 5 * time.Second
 	httpServer := &http.Server{
 		Addr:              ":" + cfg.HTTPPort,
-		Handler:           mux,
+		Handler:           metricsMiddleware(metrics, mux),
 		ReadHeaderTimeout: defaultTimeout,
 	}
 
 	server := &server{
-		httpServer: httpServer,
-		mux:        mux,
-		service:    service,
+		httpServer:      httpServer,
+		mux:             mux,
+		service:         service,
+		authEnabled:     cfg.AuthCfg.Enabled,
+		scimDefaultTeam: cfg.SCIMDefaultTeam,
+		idempotencyTTL:  cfg.IdempotencyTTL,
+		metrics:         metrics,
 	}
 
-	server.registerHandlers()
+	server.authenticator = auth.NewAuthenticator(service, cfg.OIDCCfg, &http.Client{Timeout: defaultTimeout})
 
-	go func() {
-		if err := httpServer.ListenAndServe(); err != nil {
-			zap.L().Fatal("failed to star server: %v", zap.Error(err))
-		}
-	}()
+	validator, err := newRequestValidator(openAPISpec)
+	if err != nil {
+		zap.L().Fatal("failed to load openapi spec", zap.Error(err))
+	}
+	server.validator = validator
 
-	return httpServer
+	server.registerHandlers()
+
+	return server
 }
 
 func (s *server) registerHandlers() {
-	s.mux.Handle("POST /team/add", logsMiddleware(s.AddTeamHandler))
-	s.mux.Handle("GET /team/get", logsMiddleware(s.GetTeamHandler))
+	s.mux.Handle("GET /openapi.json", logsMiddleware(s.OpenAPIHandler))
+	// Metrics aren't logged, they are scraped every few seconds.
+	s.mux.Handle("GET /metrics", s.authMiddleware(s.metrics.Handler().ServeHTTP, auth.ReaderRoles()...))
+
+	s.handle("POST /team/add", s.AddTeamHandler, auth.AdminRoles()...)
+	s.handle("GET /team/get", s.GetTeamHandler, auth.ReaderRoles()...)
+	s.handle("POST /team/import", s.ImportTeamsHandler, auth.AdminRoles()...)
 
-	s.mux.Handle("POST /users/setIsActive", logsMiddleware(s.SetUserStatusHandler))
-	s.mux.Handle("GET /users/getReview", logsMiddleware(s.GetUserReviewsHandler))
+	s.handle("POST /users/setIsActive", s.SetUserStatusHandler, auth.ManagerRoles()...)
+	s.handle("GET /users/getReview", s.GetUserReviewsHandler, auth.ReaderRoles()...)
+	s.handle("GET /users/get", s.GetUserHandler, auth.ReaderRoles()...)
+	s.handle("GET /users/list", s.ListUsersHandler, auth.ReaderRoles()...)
 
-	s.mux.Handle("POST /pullRequest/create", logsMiddleware(s.CreatePullRequestHandler))
-	s.mux.Handle("POST /pullRequest/merge", logsMiddleware(s.MergePullRequestHandler))
-	s.mux.Handle("POST /pullRequest/reassign", logsMiddleware(s.ReassignPullRequestReviewerHandler))
+	s.handle("POST /pullRequest/create", s.CreatePullRequestHandler, auth.WriterRoles()...)
+	s.handle("GET /pullRequest/get", s.GetPullRequestHandler, auth.ReaderRoles()...)
+	s.handle("GET /pullRequest/list", s.ListPullRequestsHandler, auth.ReaderRoles()...)
+	s.handle("POST /pullRequest/merge", s.MergePullRequestHandler, auth.WriterRoles()...)
+	s.handle("POST /pullRequest/reassign", s.ReassignPullRequestReviewerHandler, auth.WriterRoles()...)
+
+	s.handle("GET /statistics/users", s.GetUsersStatisticsHandler, auth.ReaderRoles()...)
+	s.handle("GET /statistics/pullRequests", s.GetPullRequestStatisticsHandler, auth.ReaderRoles()...)
+	s.handle("GET /statistics/reviewers", s.GetReviewersStatisticHandler, auth.ReaderRoles()...)
+
+	s.handle("POST /auth/tokens/create", s.CreateAPITokenHandler, auth.AdminRoles()...)
+	s.handle("POST /auth/tokens/revoke", s.RevokeAPITokenHandler, auth.AdminRoles()...)
+
+	s.handle("GET /backup/export", s.ExportBackupHandler, auth.AdminRoles()...)
+	s.handle("POST /backup/restore", s.RestoreBackupHandler, auth.AdminRoles()...)
+
+	s.registerV1Handlers()
+	s.registerSCIMHandlers()
+}
+
+// handle registers an API route behind logging, authentication and request validation.
+// POST routes also honour the Idempotency-Key header.
+func (s *server) handle(pattern string, handler http.HandlerFunc, roles ...string) {
+	next := s.validationMiddleware(handler)
+	if strings.HasPrefix(pattern, http.MethodPost+" ") {
+		next = s.idempotencyMiddleware(next)
+	}
 
-	s.mux.Handle("GET /statistics/users", logsMiddleware(s.GetUsersStatisticsHandler))
-	s.mux.Handle("GET /statistics/pullRequests", logsMiddleware(s.GetPullRequestStatisticsHandler))
-	s.mux.Handle("GET /statistics/reviewers", logsMiddleware(s.GetReviewersStatisticHandler))
+	s.mux.Handle(pattern, logsMiddleware(s.authMiddleware(next, roles...)))
 }
 
 func (s *server) mapServiceErrors(err string) int {
 	switch err {
-	case models.TeamExistsErr:
+	case models.TeamExistsErr, models.InvalidReqErr, models.InvalidJSONErr, models.ValidationErr:
 		return http.StatusBadRequest
-	case models.PRExistsErr, models.PRMergedErr, models.NotAssignedErr, models.NoCandidateErr:
+	case models.UserExistsErr, models.PRExistsErr, models.PRMergedErr, models.NotAssignedErr, models.NoCandidateErr,
+		models.InProgressErr:
 		return http.StatusConflict
+	case models.KeyReusedErr:
+		return http.StatusUnprocessableEntity
+	case models.PreconditionErr:
+		return http.StatusPreconditionFailed
 	case models.NotFoundErr:
 		return http.StatusNotFound
+	case models.UnauthorizedErr:
+		return http.StatusUnauthorized
+	case models.ForbiddenErr:
+		return http.StatusForbidden
 	default:
 		return http.StatusInternalServerError
 	}
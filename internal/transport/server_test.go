@@ -0,0 +1,140 @@
+package transport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/metrics"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/internal/repository/memory"
+	"github.com/vedsatt/pr-review-assignment-service/internal/service"
+	"github.com/vedsatt/pr-review-assignment-service/internal/transport"
+)
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(...models.PullRequestEvent) {}
+
+type testServer struct {
+	*httptest.Server
+
+	service *service.Service
+}
+
+// newTestServer serves the HTTP API on top of the in-memory repository, auth is disabled unless cfg enables it.
+func newTestServer(t *testing.T, cfg config.Config) *testServer {
+	t.Helper()
+
+	if cfg.IdempotencyTTL == 0 {
+		cfg.IdempotencyTTL = time.Hour
+	}
+	if cfg.SCIMDefaultTeam == "" {
+		cfg.SCIMDefaultTeam = "unassigned"
+	}
+
+	m := metrics.New()
+	svc := service.NewService(memory.NewRepository(), nopNotifier{}, m)
+	m.RegisterOpenReviews(svc.OpenReviewsByTeam)
+
+	server := httptest.NewServer(transport.NewHandler(&cfg, svc, m))
+	t.Cleanup(server.Close)
+
+	return &testServer{Server: server, service: svc}
+}
+
+type request struct {
+	method  string
+	path    string
+	body    any
+	headers map[string]string
+}
+
+func (s *testServer) do(t *testing.T, req request) *http.Response {
+	t.Helper()
+
+	var body io.Reader
+	switch b := req.body.(type) {
+	case nil:
+	case []byte:
+		body = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("failed to encode body: %v", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(t.Context(), req.method, s.URL+req.path, body)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range req.headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	resp, err := s.Client().Do(httpReq)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", req.method, req.path, err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+func readBody(t *testing.T, resp *http.Response) []byte {
+	t.Helper()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+
+	return data
+}
+
+func errorCode(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	var body models.ErrorResponse
+	if err := json.Unmarshal(readBody(t, resp), &body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+
+	return body.Error.Code
+}
+
+func addTeam(t *testing.T, s *testServer, teamName string, userIDs ...string) {
+	t.Helper()
+
+	team := models.AddTeamRequest{Name: teamName}
+	for _, id := range userIDs {
+		team.Members = append(team.Members, models.TeamMember{ID: id, Username: "user " + id, IsActive: true})
+	}
+
+	if _, errDetails := s.service.AddTeam(t.Context(), team); errDetails != nil {
+		t.Fatalf("AddTeam(%s) error = %+v", teamName, errDetails)
+	}
+}
+
+// apiToken issues a token with the given role, the test server has to be created with auth enabled.
+func apiToken(t *testing.T, s *testServer, role string) string {
+	t.Helper()
+
+	request := models.CreateAPITokenRequest{Name: "test " + role, Role: role}
+	token, errDetails := s.service.CreateAPIToken(t.Context(), request)
+	if errDetails != nil {
+		t.Fatalf("CreateAPIToken() error = %+v", errDetails)
+	}
+
+	return token.Token
+}
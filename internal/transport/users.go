@@ -0,0 +1,76 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (s *server) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		err := models.ErrDetails{
+			Code:    models.NotFoundErr,
+			Message: "resourse not found",
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.serveUser(w, r, userID)
+}
+
+func (s *server) serveUser(w http.ResponseWriter, r *http.Request, userID string) {
+	user, serviceErr := s.service.GetUser(r.Context(), userID)
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	resp := models.GetUserResponse{
+		User: *user,
+	}
+	s.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (s *server) ListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.ListUsersRequest{
+		TeamName:   query.Get("team_name"),
+		NamePrefix: query.Get("name_prefix"),
+		Cursor:     query.Get("cursor"),
+	}
+
+	if isActive := query.Get("is_active"); isActive != "" {
+		value, err := strconv.ParseBool(isActive)
+		if err != nil {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: "is_active must be a boolean",
+			})
+			return
+		}
+		filter.IsActive = &value
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			s.respondWithError(w, http.StatusBadRequest, models.ErrDetails{
+				Code:    models.InvalidReqErr,
+				Message: "limit must be an integer",
+			})
+			return
+		}
+		filter.Limit = value
+	}
+
+	resp, serviceErr := s.service.ListUsers(r.Context(), filter)
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	s.respondWithJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,52 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/config"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func TestOnlyVersionedUserReviewsArePaginatedByDefault(t *testing.T) {
+	s := newTestServer(t, config.Config{})
+	addTeam(t, s, "backend", "u1", "u2")
+
+	const reviews = models.DefaultPageSize + 1
+	for i := range reviews {
+		id := fmt.Sprintf("pr-%d", i)
+		request := models.CreatePRRequest{ID: id, Name: id, AuthorID: "u1"}
+		if _, errDetails := s.service.CreatePullRequest(t.Context(), request); errDetails != nil {
+			t.Fatalf("CreatePullRequest(%s) error = %+v", id, errDetails)
+		}
+	}
+
+	tests := []struct {
+		path     string
+		want     int
+		wantNext bool
+	}{
+		{"/users/getReview?user_id=u2", reviews, false},
+		{"/users/getReview?user_id=u2&limit=10", 10, true},
+		{"/api/v1/users/u2/reviews", models.DefaultPageSize, true},
+		{"/api/v1/users/u2/reviews?limit=100", reviews, false},
+	}
+
+	for _, tt := range tests {
+		resp := s.do(t, request{method: http.MethodGet, path: tt.path})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want %d", tt.path, resp.StatusCode, http.StatusOK)
+		}
+
+		var body models.GetUserReviewsResponse
+		if err := json.Unmarshal(readBody(t, resp), &body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.PullRequests) != tt.want || (body.NextCursor != "") != tt.wantNext {
+			t.Errorf("GET %s = %d reviews and cursor %q, want %d reviews and a cursor: %t",
+				tt.path, len(body.PullRequests), body.NextCursor, tt.want, tt.wantNext)
+		}
+	}
+}
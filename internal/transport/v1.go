@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/auth"
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func (s *server) registerV1Handlers() {
+	s.handle("POST /api/v1/teams", s.AddTeamHandler, auth.AdminRoles()...)
+	s.handle("POST /api/v1/teams/import", s.ImportTeamsHandler, auth.AdminRoles()...)
+	s.handle("GET /api/v1/teams/{team_name}", s.GetTeamV1Handler, auth.ReaderRoles()...)
+
+	s.handle("GET /api/v1/users", s.ListUsersHandler, auth.ReaderRoles()...)
+	s.handle("GET /api/v1/users/{user_id}", s.GetUserV1Handler, auth.ReaderRoles()...)
+	s.handle("PATCH /api/v1/users/{user_id}", s.UpdateUserV1Handler, auth.ManagerRoles()...)
+	s.handle("GET /api/v1/users/{user_id}/reviews", s.GetUserReviewsV1Handler, auth.ReaderRoles()...)
+
+	s.handle("POST /api/v1/pull-requests", s.CreatePullRequestHandler, auth.WriterRoles()...)
+	s.handle("GET /api/v1/pull-requests", s.ListPullRequestsHandler, auth.ReaderRoles()...)
+	s.handle("GET /api/v1/pull-requests/{pull_request_id}", s.GetPullRequestV1Handler, auth.ReaderRoles()...)
+	s.handle("POST /api/v1/pull-requests/{pull_request_id}/merge", s.MergePullRequestV1Handler, auth.WriterRoles()...)
+	s.handle("POST /api/v1/pull-requests/{pull_request_id}/reassign",
+		s.ReassignPullRequestReviewerV1Handler, auth.WriterRoles()...)
+
+	s.handle("GET /api/v1/statistics/users", s.GetUsersStatisticsHandler, auth.ReaderRoles()...)
+	s.handle("GET /api/v1/statistics/pull-requests", s.GetPullRequestStatisticsHandler, auth.ReaderRoles()...)
+	s.handle("GET /api/v1/statistics/reviewers", s.GetReviewersStatisticHandler, auth.ReaderRoles()...)
+
+	s.handle("POST /api/v1/auth/tokens", s.CreateAPITokenHandler, auth.AdminRoles()...)
+	s.handle("DELETE /api/v1/auth/tokens/{token_id}", s.RevokeAPITokenV1Handler, auth.AdminRoles()...)
+
+	s.handle("GET /api/v1/backup/export", s.ExportBackupHandler, auth.AdminRoles()...)
+	s.handle("POST /api/v1/backup/restore", s.RestoreBackupHandler, auth.AdminRoles()...)
+}
+
+func (s *server) GetTeamV1Handler(w http.ResponseWriter, r *http.Request) {
+	s.serveTeam(w, r, r.PathValue("team_name"))
+}
+
+func (s *server) GetUserV1Handler(w http.ResponseWriter, r *http.Request) {
+	s.serveUser(w, r, r.PathValue("user_id"))
+}
+
+func (s *server) UpdateUserV1Handler(w http.ResponseWriter, r *http.Request) {
+	body := r.Body
+	defer body.Close()
+
+	var request models.UpdateUserRequest
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		err := models.ErrDetails{
+			Code:    models.InvalidJSONErr,
+			Message: fmt.Sprintf("failed to decode json: %v", err),
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if request.IsActive == nil {
+		err := models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: "nothing to update",
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.serveUserStatus(w, r, models.SetUserStatusRequest{
+		ID:       r.PathValue("user_id"),
+		IsActive: *request.IsActive,
+	})
+}
+
+func (s *server) GetUserReviewsV1Handler(w http.ResponseWriter, r *http.Request) {
+	s.serveUserReviews(w, r, r.PathValue("user_id"), models.DefaultPageSize)
+}
+
+func (s *server) GetPullRequestV1Handler(w http.ResponseWriter, r *http.Request) {
+	s.servePullRequest(w, r, r.PathValue("pull_request_id"))
+}
+
+func (s *server) MergePullRequestV1Handler(w http.ResponseWriter, r *http.Request) {
+	s.serveMergedPullRequest(w, r, r.PathValue("pull_request_id"))
+}
+
+func (s *server) ReassignPullRequestReviewerV1Handler(w http.ResponseWriter, r *http.Request) {
+	body := r.Body
+	defer body.Close()
+
+	var request models.ReassignPRReviewerRequest
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		err := models.ErrDetails{
+			Code:    models.InvalidJSONErr,
+			Message: fmt.Sprintf("failed to decode json: %v", err),
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+	request.PullRequestID = r.PathValue("pull_request_id")
+
+	s.serveReassignedReviewer(w, r, request)
+}
+
+func (s *server) RevokeAPITokenV1Handler(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := strconv.ParseInt(r.PathValue("token_id"), 10, 64)
+	if err != nil {
+		err := models.ErrDetails{
+			Code:    models.InvalidReqErr,
+			Message: "token_id must be an integer",
+		}
+		s.respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.serveRevokedAPIToken(w, r, tokenID)
+}
+
+func (s *server) servePullRequest(w http.ResponseWriter, r *http.Request, pullRequestID string) {
+	pullRequest, serviceErr := s.service.GetPullRequest(r.Context(), pullRequestID)
+	if serviceErr != nil {
+		s.respondWithError(w, s.mapServiceErrors(serviceErr.Code), *serviceErr)
+		return
+	}
+
+	resp := models.GetPullRequestResponse{
+		PullRequest: *pullRequest,
+	}
+	setETag(w, pullRequest.Version)
+	s.respondWithJSON(w, http.StatusOK, resp)
+}
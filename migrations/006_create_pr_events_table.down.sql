@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pr_events_pr_reviewer;
+DROP TABLE IF EXISTS pr_events;
+
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS assigned_at;
\ No newline at end of file
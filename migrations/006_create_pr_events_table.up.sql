@@ -0,0 +1,12 @@
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE TABLE IF NOT EXISTS pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(100) NOT NULL REFERENCES pull_requests(id),
+    event_type VARCHAR(32) NOT NULL,
+    reviewer_id VARCHAR(10) REFERENCES users(id),
+    old_reviewer_id VARCHAR(10) REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr_reviewer ON pr_events(pr_id, reviewer_id, event_type);
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pr_created_at;
+DROP INDEX IF EXISTS idx_pr_status_created_at;
+DROP INDEX IF EXISTS idx_pr_merged_at;
+DROP INDEX IF EXISTS idx_pr_name;
+
+ALTER TABLE pull_requests ALTER COLUMN created_at DROP NOT NULL;
\ No newline at end of file
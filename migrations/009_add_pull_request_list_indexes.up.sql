@@ -0,0 +1,11 @@
+UPDATE pull_requests SET created_at = NOW() WHERE created_at IS NULL;
+
+ALTER TABLE pull_requests ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_created_at ON pull_requests(created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_pr_status_created_at ON pull_requests(pr_status, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_pr_merged_at ON pull_requests(merged_at, id) WHERE merged_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_name ON pull_requests(pr_name, id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_user_name_lower;
+DROP INDEX IF EXISTS idx_pr_author_status;
\ No newline at end of file
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_users_user_name_lower ON users(LOWER(user_name) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS idx_pr_author_status ON pull_requests(author_id, pr_status);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
\ No newline at end of file
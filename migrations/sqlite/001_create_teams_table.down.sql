@@ -0,0 +1 @@
+DROP TABLE IF EXISTS teams;
\ No newline at end of file
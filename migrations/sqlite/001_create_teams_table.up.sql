@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS teams (
+    team_name VARCHAR(255) PRIMARY KEY NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_team_active;
+DROP INDEX IF EXISTS idx_users_email;
+DROP TABLE IF EXISTS users;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(10) PRIMARY KEY,
+    user_name VARCHAR(255),
+    email VARCHAR(255),
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name),
+    is_active BOOLEAN DEFAULT true
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email) WHERE email IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON users(team_name, is_active);
\ No newline at end of file
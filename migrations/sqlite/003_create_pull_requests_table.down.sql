@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pr_author_status;
+DROP INDEX IF EXISTS idx_pr_created_at;
+DROP INDEX IF EXISTS idx_pr_status_created_at;
+DROP INDEX IF EXISTS idx_pr_merged_at;
+DROP INDEX IF EXISTS idx_pr_name;
+DROP TABLE IF EXISTS pull_requests;
\ No newline at end of file
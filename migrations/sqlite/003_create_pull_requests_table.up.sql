@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS pull_requests (
+    id VARCHAR(100) PRIMARY KEY,
+    pr_name VARCHAR(255) NOT NULL,
+    author_id VARCHAR(100) NOT NULL REFERENCES users(id),
+    pr_status VARCHAR(6) NOT NULL DEFAULT 'OPEN' CHECK (pr_status IN ('OPEN', 'MERGED')),
+    created_at TIMESTAMP NOT NULL,
+    merged_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_author_status ON pull_requests(author_id, pr_status);
+
+CREATE INDEX IF NOT EXISTS idx_pr_created_at ON pull_requests(created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_pr_status_created_at ON pull_requests(pr_status, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_pr_merged_at ON pull_requests(merged_at, id) WHERE merged_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_name ON pull_requests(pr_name, id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_id;
+DROP TABLE IF EXISTS pr_reviewers;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS pr_reviewers (
+    pr_id VARCHAR(100) NOT NULL REFERENCES pull_requests(id),
+    reviewer_id VARCHAR(10) NOT NULL REFERENCES users(id),
+    assigned_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (pr_id, reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer_id ON pr_reviewers(reviewer_id);
\ No newline at end of file
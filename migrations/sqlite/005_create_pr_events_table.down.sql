@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pr_events_pr_reviewer;
+DROP TABLE IF EXISTS pr_events;
\ No newline at end of file
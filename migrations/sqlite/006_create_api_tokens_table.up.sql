@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INTEGER PRIMARY KEY,
+    token_name VARCHAR(255) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    token_role VARCHAR(16) NOT NULL CHECK (token_role IN ('admin', 'team-lead', 'member', 'read-only')),
+    user_id VARCHAR(10) REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN version;
+
+ALTER TABLE teams DROP COLUMN version;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE teams ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
\ No newline at end of file
@@ -0,0 +1,2741 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: prreview/v1/prreview.proto
+
+package prreviewv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PullRequestStatus int32
+
+const (
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN        PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED      PullRequestStatus = 2
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED": 0,
+		"PULL_REQUEST_STATUS_OPEN":        1,
+		"PULL_REQUEST_STATUS_MERGED":      2,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreview_v1_prreview_proto_enumTypes[0].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_prreview_v1_prreview_proto_enumTypes[0]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{0}
+}
+
+type ReviewState int32
+
+const (
+	ReviewState_REVIEW_STATE_UNSPECIFIED ReviewState = 0
+	ReviewState_REVIEW_STATE_PENDING     ReviewState = 1
+	ReviewState_REVIEW_STATE_COMPLETED   ReviewState = 2
+)
+
+// Enum value maps for ReviewState.
+var (
+	ReviewState_name = map[int32]string{
+		0: "REVIEW_STATE_UNSPECIFIED",
+		1: "REVIEW_STATE_PENDING",
+		2: "REVIEW_STATE_COMPLETED",
+	}
+	ReviewState_value = map[string]int32{
+		"REVIEW_STATE_UNSPECIFIED": 0,
+		"REVIEW_STATE_PENDING":     1,
+		"REVIEW_STATE_COMPLETED":   2,
+	}
+)
+
+func (x ReviewState) Enum() *ReviewState {
+	p := new(ReviewState)
+	*p = x
+	return p
+}
+
+func (x ReviewState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewState) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreview_v1_prreview_proto_enumTypes[1].Descriptor()
+}
+
+func (ReviewState) Type() protoreflect.EnumType {
+	return &file_prreview_v1_prreview_proto_enumTypes[1]
+}
+
+func (x ReviewState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewState.Descriptor instead.
+func (ReviewState) EnumDescriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{1}
+}
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	IsActive      bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type Team struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members  []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// Grows with every change of the members, pass it to SetIsActive to make a conditional change.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Team) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	TeamName      string                 `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type UserDetails struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	User             *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	OpenReviews      int64                  `protobuf:"varint,2,opt,name=open_reviews,json=openReviews,proto3" json:"open_reviews,omitempty"`
+	OpenPullRequests int64                  `protobuf:"varint,3,opt,name=open_pull_requests,json=openPullRequests,proto3" json:"open_pull_requests,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UserDetails) Reset() {
+	*x = UserDetails{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDetails) ProtoMessage() {}
+
+func (x *UserDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDetails.ProtoReflect.Descriptor instead.
+func (*UserDetails) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserDetails) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UserDetails) GetOpenReviews() int64 {
+	if x != nil {
+		return x.OpenReviews
+	}
+	return 0
+}
+
+func (x *UserDetails) GetOpenPullRequests() int64 {
+	if x != nil {
+		return x.OpenPullRequests
+	}
+	return 0
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prreview.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	// Grows with every change, pass it to MergePullRequest or ReassignReviewer to make a conditional change.
+	Version       int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ReviewerAssignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	ReviewState   ReviewState            `protobuf:"varint,5,opt,name=review_state,json=reviewState,proto3,enum=prreview.v1.ReviewState" json:"review_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerAssignment) Reset() {
+	*x = ReviewerAssignment{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerAssignment) ProtoMessage() {}
+
+func (x *ReviewerAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerAssignment.ProtoReflect.Descriptor instead.
+func (*ReviewerAssignment) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReviewerAssignment) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReviewerAssignment) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ReviewerAssignment) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *ReviewerAssignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *ReviewerAssignment) GetReviewState() ReviewState {
+	if x != nil {
+		return x.ReviewState
+	}
+	return ReviewState_REVIEW_STATE_UNSPECIFIED
+}
+
+type PullRequestDetails struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	AuthorTeam        string                 `protobuf:"bytes,4,opt,name=author_team,json=authorTeam,proto3" json:"author_team,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=prreview.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,6,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	Reviewers         []*ReviewerAssignment  `protobuf:"bytes,7,rep,name=reviewers,proto3" json:"reviewers,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	Version           int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequestDetails) Reset() {
+	*x = PullRequestDetails{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestDetails) ProtoMessage() {}
+
+func (x *PullRequestDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestDetails.ProtoReflect.Descriptor instead.
+func (*PullRequestDetails) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PullRequestDetails) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequestDetails) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequestDetails) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequestDetails) GetAuthorTeam() string {
+	if x != nil {
+		return x.AuthorTeam
+	}
+	return ""
+}
+
+func (x *PullRequestDetails) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequestDetails) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequestDetails) GetReviewers() []*ReviewerAssignment {
+	if x != nil {
+		return x.Reviewers
+	}
+	return nil
+}
+
+func (x *PullRequestDetails) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequestDetails) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequestDetails) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type PullRequestShort struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prreview.v1.PullRequestStatus" json:"status,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AssignedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	ReviewState     ReviewState            `protobuf:"varint,7,opt,name=review_state,json=reviewState,proto3,enum=prreview.v1.ReviewState" json:"review_state,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PullRequestShort) Reset() {
+	*x = PullRequestShort{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestShort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestShort) ProtoMessage() {}
+
+func (x *PullRequestShort) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestShort.ProtoReflect.Descriptor instead.
+func (*PullRequestShort) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PullRequestShort) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequestShort) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequestShort) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *PullRequestShort) GetReviewState() ReviewState {
+	if x != nil {
+		return x.ReviewState
+	}
+	return ReviewState_REVIEW_STATE_UNSPECIFIED
+}
+
+type AddTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamRequest) Reset() {
+	*x = AddTeamRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamRequest) ProtoMessage() {}
+
+func (x *AddTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *AddTeamRequest) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type AddTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamResponse) Reset() {
+	*x = AddTeamResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamResponse) ProtoMessage() {}
+
+func (x *AddTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type GetTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamResponse) Reset() {
+	*x = GetTeamResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamResponse) ProtoMessage() {}
+
+func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserDetails           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserResponse) GetUser() *UserDetails {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	NamePrefix    string                 `protobuf:"bytes,3,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListUsersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserDetails         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserDetails {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type SetIsActiveRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Version of the user's team, the same as If-Match of the HTTP API: when it is set and the team
+	// has changed since, the call fails with ABORTED. Zero skips the check.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveRequest) Reset() {
+	*x = SetIsActiveRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveRequest) ProtoMessage() {}
+
+func (x *SetIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *SetIsActiveRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SetIsActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveResponse) Reset() {
+	*x = SetIsActiveResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveResponse) ProtoMessage() {}
+
+func (x *SetIsActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetIsActiveResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetIsActiveResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        PullRequestStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=prreview.v1.PullRequestStatus" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsRequest) Reset() {
+	*x = GetReviewsRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewsRequest) ProtoMessage() {}
+
+func (x *GetReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *GetReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReviewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*PullRequestShort    `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsResponse) Reset() {
+	*x = GetReviewsResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewsResponse) ProtoMessage() {}
+
+func (x *GetReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetReviewsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewsResponse) GetPullRequests() []*PullRequestShort {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *GetReviewsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type CreatePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreatePullRequestResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type GetPullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequestDetails    `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestResponse) Reset() {
+	*x = GetPullRequestResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestResponse) ProtoMessage() {}
+
+func (x *GetPullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetPullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetPullRequestResponse) GetPullRequest() *PullRequestDetails {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+type ListPullRequestsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Status      PullRequestStatus      `protobuf:"varint,1,opt,name=status,proto3,enum=prreview.v1.PullRequestStatus" json:"status,omitempty"`
+	AuthorId    string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	TeamName    string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ReviewerId  string                 `protobuf:"bytes,4,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	MergedFrom  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_from,json=mergedFrom,proto3" json:"merged_from,omitempty"`
+	MergedTo    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_to,json=mergedTo,proto3" json:"merged_to,omitempty"`
+	// created_at (default), merged_at or pull_request_name.
+	SortBy string `protobuf:"bytes,9,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc or desc (default).
+	Order         string `protobuf:"bytes,10,opt,name=order,proto3" json:"order,omitempty"`
+	Limit         int32  `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string `protobuf:"bytes,12,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetMergedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetMergedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPullRequestsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequestDetails  `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequestDetails {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListPullRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Version of the pull request, zero skips the check.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergePullRequestRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MergePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestResponse) Reset() {
+	*x = MergePullRequestResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestResponse) ProtoMessage() {}
+
+func (x *MergePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*MergePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MergePullRequestResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldReviewerId string                 `protobuf:"bytes,2,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	// Version of the pull request, zero skips the check.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReassignReviewerResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type GetUsersStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersStatisticsRequest) Reset() {
+	*x = GetUsersStatisticsRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersStatisticsRequest) ProtoMessage() {}
+
+func (x *GetUsersStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{30}
+}
+
+type TeamUsersCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UsersCount    int64                  `protobuf:"varint,2,opt,name=users_count,json=usersCount,proto3" json:"users_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamUsersCount) Reset() {
+	*x = TeamUsersCount{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamUsersCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamUsersCount) ProtoMessage() {}
+
+func (x *TeamUsersCount) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamUsersCount.ProtoReflect.Descriptor instead.
+func (*TeamUsersCount) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TeamUsersCount) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamUsersCount) GetUsersCount() int64 {
+	if x != nil {
+		return x.UsersCount
+	}
+	return 0
+}
+
+type GetUsersStatisticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalUsers    int64                  `protobuf:"varint,1,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
+	ActiveUsers   int64                  `protobuf:"varint,2,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`
+	InactiveUsers int64                  `protobuf:"varint,3,opt,name=inactive_users,json=inactiveUsers,proto3" json:"inactive_users,omitempty"`
+	UsersByTeam   []*TeamUsersCount      `protobuf:"bytes,4,rep,name=users_by_team,json=usersByTeam,proto3" json:"users_by_team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersStatisticsResponse) Reset() {
+	*x = GetUsersStatisticsResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersStatisticsResponse) ProtoMessage() {}
+
+func (x *GetUsersStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetUsersStatisticsResponse) GetTotalUsers() int64 {
+	if x != nil {
+		return x.TotalUsers
+	}
+	return 0
+}
+
+func (x *GetUsersStatisticsResponse) GetActiveUsers() int64 {
+	if x != nil {
+		return x.ActiveUsers
+	}
+	return 0
+}
+
+func (x *GetUsersStatisticsResponse) GetInactiveUsers() int64 {
+	if x != nil {
+		return x.InactiveUsers
+	}
+	return 0
+}
+
+func (x *GetUsersStatisticsResponse) GetUsersByTeam() []*TeamUsersCount {
+	if x != nil {
+		return x.UsersByTeam
+	}
+	return nil
+}
+
+type GetPullRequestsStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestsStatisticsRequest) Reset() {
+	*x = GetPullRequestsStatisticsRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestsStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestsStatisticsRequest) ProtoMessage() {}
+
+func (x *GetPullRequestsStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestsStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestsStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{33}
+}
+
+type GetPullRequestsStatisticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalPrs      int64                  `protobuf:"varint,1,opt,name=total_prs,json=totalPrs,proto3" json:"total_prs,omitempty"`
+	OpenPrs       int64                  `protobuf:"varint,2,opt,name=open_prs,json=openPrs,proto3" json:"open_prs,omitempty"`
+	MergedPrs     int64                  `protobuf:"varint,3,opt,name=merged_prs,json=mergedPrs,proto3" json:"merged_prs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestsStatisticsResponse) Reset() {
+	*x = GetPullRequestsStatisticsResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestsStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestsStatisticsResponse) ProtoMessage() {}
+
+func (x *GetPullRequestsStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestsStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetPullRequestsStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetPullRequestsStatisticsResponse) GetTotalPrs() int64 {
+	if x != nil {
+		return x.TotalPrs
+	}
+	return 0
+}
+
+func (x *GetPullRequestsStatisticsResponse) GetOpenPrs() int64 {
+	if x != nil {
+		return x.OpenPrs
+	}
+	return 0
+}
+
+func (x *GetPullRequestsStatisticsResponse) GetMergedPrs() int64 {
+	if x != nil {
+		return x.MergedPrs
+	}
+	return 0
+}
+
+type GetReviewersStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewersStatisticsRequest) Reset() {
+	*x = GetReviewersStatisticsRequest{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewersStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewersStatisticsRequest) ProtoMessage() {}
+
+func (x *GetReviewersStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewersStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewersStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{35}
+}
+
+type ReviewerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	ReviewCount   int64                  `protobuf:"varint,3,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerStats) Reset() {
+	*x = ReviewerStats{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerStats) ProtoMessage() {}
+
+func (x *ReviewerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerStats.ProtoReflect.Descriptor instead.
+func (*ReviewerStats) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReviewerStats) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReviewerStats) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ReviewerStats) GetReviewCount() int64 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+type GetReviewersStatisticsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TopReviewers        []*ReviewerStats       `protobuf:"bytes,1,rep,name=top_reviewers,json=topReviewers,proto3" json:"top_reviewers,omitempty"`
+	UsersWithoutReviews []string               `protobuf:"bytes,2,rep,name=users_without_reviews,json=usersWithoutReviews,proto3" json:"users_without_reviews,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetReviewersStatisticsResponse) Reset() {
+	*x = GetReviewersStatisticsResponse{}
+	mi := &file_prreview_v1_prreview_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewersStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewersStatisticsResponse) ProtoMessage() {}
+
+func (x *GetReviewersStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreview_v1_prreview_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewersStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewersStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_prreview_v1_prreview_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetReviewersStatisticsResponse) GetTopReviewers() []*ReviewerStats {
+	if x != nil {
+		return x.TopReviewers
+	}
+	return nil
+}
+
+func (x *GetReviewersStatisticsResponse) GetUsersWithoutReviews() []string {
+	if x != nil {
+		return x.UsersWithoutReviews
+	}
+	return nil
+}
+
+var File_prreview_v1_prreview_proto protoreflect.FileDescriptor
+
+const file_prreview_v1_prreview_proto_rawDesc = "" +
+	"\n" +
+	"\x1aprreview/v1/prreview.proto\x12\vprreview.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"t\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\"p\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x121\n" +
+	"\amembers\x18\x02 \x03(\v2\x17.prreview.v1.TeamMemberR\amembers\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"\x8b\x01\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1b\n" +
+	"\tteam_name\x18\x04 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\"\x85\x01\n" +
+	"\vUserDetails\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.prreview.v1.UserR\x04user\x12!\n" +
+	"\fopen_reviews\x18\x02 \x01(\x03R\vopenReviews\x12,\n" +
+	"\x12open_pull_requests\x18\x03 \x01(\x03R\x10openPullRequests\"\xb8\x02\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x126\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1e.prreview.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\tR\x11assignedReviewers\x127\n" +
+	"\tmerged_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\"\xe0\x01\n" +
+	"\x12ReviewerAssignment\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12;\n" +
+	"\vassigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12;\n" +
+	"\freview_state\x18\x05 \x01(\x0e2\x18.prreview.v1.ReviewStateR\vreviewState\"\xda\x03\n" +
+	"\x12PullRequestDetails\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vauthor_team\x18\x04 \x01(\tR\n" +
+	"authorTeam\x126\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1e.prreview.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x06 \x03(\tR\x11assignedReviewers\x12=\n" +
+	"\treviewers\x18\a \x03(\v2\x1f.prreview.v1.ReviewerAssignmentR\treviewers\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\"\xf0\x02\n" +
+	"\x10PullRequestShort\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x126\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1e.prreview.v1.PullRequestStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vassigned_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12;\n" +
+	"\freview_state\x18\a \x01(\x0e2\x18.prreview.v1.ReviewStateR\vreviewState\"`\n" +
+	"\x0eAddTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x121\n" +
+	"\amembers\x18\x02 \x03(\v2\x17.prreview.v1.TeamMemberR\amembers\"8\n" +
+	"\x0fAddTeamResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.prreview.v1.TeamR\x04team\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"8\n" +
+	"\x0fGetTeamResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.prreview.v1.TeamR\x04team\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"?\n" +
+	"\x0fGetUserResponse\x12,\n" +
+	"\x04user\x18\x01 \x01(\v2\x18.prreview.v1.UserDetailsR\x04user\"\xae\x01\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12 \n" +
+	"\tis_active\x18\x02 \x01(\bH\x00R\bisActive\x88\x01\x01\x12\x1f\n" +
+	"\vname_prefix\x18\x03 \x01(\tR\n" +
+	"namePrefix\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursorB\f\n" +
+	"\n" +
+	"_is_active\"d\n" +
+	"\x11ListUsersResponse\x12.\n" +
+	"\x05users\x18\x01 \x03(\v2\x18.prreview.v1.UserDetailsR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"d\n" +
+	"\x12SetIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"<\n" +
+	"\x13SetIsActiveResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.prreview.v1.UserR\x04user\"\x92\x01\n" +
+	"\x11GetReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.prreview.v1.PullRequestStatusR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\x92\x01\n" +
+	"\x12GetReviewsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12B\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x1d.prreview.v1.PullRequestShortR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x8b\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\"X\n" +
+	"\x19CreatePullRequestResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.prreview.v1.PullRequestR\vpullRequest\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"\\\n" +
+	"\x16GetPullRequestResponse\x12B\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x1f.prreview.v1.PullRequestDetailsR\vpullRequest\"\xf9\x03\n" +
+	"\x17ListPullRequestsRequest\x126\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1e.prreview.v1.PullRequestStatusR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vreviewer_id\x18\x04 \x01(\tR\n" +
+	"reviewerId\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12;\n" +
+	"\vmerged_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mergedFrom\x127\n" +
+	"\tmerged_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bmergedTo\x12\x17\n" +
+	"\asort_by\x18\t \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05order\x18\n" +
+	" \x01(\tR\x05order\x12\x14\n" +
+	"\x05limit\x18\v \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\f \x01(\tR\x06cursor\"\x81\x01\n" +
+	"\x18ListPullRequestsResponse\x12D\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x1f.prreview.v1.PullRequestDetailsR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"[\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"W\n" +
+	"\x18MergePullRequestResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.prreview.v1.PullRequestR\vpullRequest\"\x83\x01\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12&\n" +
+	"\x0fold_reviewer_id\x18\x02 \x01(\tR\roldReviewerId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"x\n" +
+	"\x18ReassignReviewerResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.prreview.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x1b\n" +
+	"\x19GetUsersStatisticsRequest\"N\n" +
+	"\x0eTeamUsersCount\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vusers_count\x18\x02 \x01(\x03R\n" +
+	"usersCount\"\xc8\x01\n" +
+	"\x1aGetUsersStatisticsResponse\x12\x1f\n" +
+	"\vtotal_users\x18\x01 \x01(\x03R\n" +
+	"totalUsers\x12!\n" +
+	"\factive_users\x18\x02 \x01(\x03R\vactiveUsers\x12%\n" +
+	"\x0einactive_users\x18\x03 \x01(\x03R\rinactiveUsers\x12?\n" +
+	"\rusers_by_team\x18\x04 \x03(\v2\x1b.prreview.v1.TeamUsersCountR\vusersByTeam\"\"\n" +
+	" GetPullRequestsStatisticsRequest\"z\n" +
+	"!GetPullRequestsStatisticsResponse\x12\x1b\n" +
+	"\ttotal_prs\x18\x01 \x01(\x03R\btotalPrs\x12\x19\n" +
+	"\bopen_prs\x18\x02 \x01(\x03R\aopenPrs\x12\x1d\n" +
+	"\n" +
+	"merged_prs\x18\x03 \x01(\x03R\tmergedPrs\"\x1f\n" +
+	"\x1dGetReviewersStatisticsRequest\"g\n" +
+	"\rReviewerStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12!\n" +
+	"\freview_count\x18\x03 \x01(\x03R\vreviewCount\"\x95\x01\n" +
+	"\x1eGetReviewersStatisticsResponse\x12?\n" +
+	"\rtop_reviewers\x18\x01 \x03(\v2\x1a.prreview.v1.ReviewerStatsR\ftopReviewers\x122\n" +
+	"\x15users_without_reviews\x18\x02 \x03(\tR\x13usersWithoutReviews*v\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x02*a\n" +
+	"\vReviewState\x12\x1c\n" +
+	"\x18REVIEW_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14REVIEW_STATE_PENDING\x10\x01\x12\x1a\n" +
+	"\x16REVIEW_STATE_COMPLETED\x10\x022\x99\x01\n" +
+	"\vTeamService\x12D\n" +
+	"\aAddTeam\x12\x1b.prreview.v1.AddTeamRequest\x1a\x1c.prreview.v1.AddTeamResponse\x12D\n" +
+	"\aGetTeam\x12\x1b.prreview.v1.GetTeamRequest\x1a\x1c.prreview.v1.GetTeamResponse2\xc0\x02\n" +
+	"\vUserService\x12D\n" +
+	"\aGetUser\x12\x1b.prreview.v1.GetUserRequest\x1a\x1c.prreview.v1.GetUserResponse\x12J\n" +
+	"\tListUsers\x12\x1d.prreview.v1.ListUsersRequest\x1a\x1e.prreview.v1.ListUsersResponse\x12P\n" +
+	"\vSetIsActive\x12\x1f.prreview.v1.SetIsActiveRequest\x1a .prreview.v1.SetIsActiveResponse\x12M\n" +
+	"\n" +
+	"GetReviews\x12\x1e.prreview.v1.GetReviewsRequest\x1a\x1f.prreview.v1.GetReviewsResponse2\xf6\x03\n" +
+	"\x12PullRequestService\x12b\n" +
+	"\x11CreatePullRequest\x12%.prreview.v1.CreatePullRequestRequest\x1a&.prreview.v1.CreatePullRequestResponse\x12Y\n" +
+	"\x0eGetPullRequest\x12\".prreview.v1.GetPullRequestRequest\x1a#.prreview.v1.GetPullRequestResponse\x12_\n" +
+	"\x10ListPullRequests\x12$.prreview.v1.ListPullRequestsRequest\x1a%.prreview.v1.ListPullRequestsResponse\x12_\n" +
+	"\x10MergePullRequest\x12$.prreview.v1.MergePullRequestRequest\x1a%.prreview.v1.MergePullRequestResponse\x12_\n" +
+	"\x10ReassignReviewer\x12$.prreview.v1.ReassignReviewerRequest\x1a%.prreview.v1.ReassignReviewerResponse2\xe9\x02\n" +
+	"\x11StatisticsService\x12e\n" +
+	"\x12GetUsersStatistics\x12&.prreview.v1.GetUsersStatisticsRequest\x1a'.prreview.v1.GetUsersStatisticsResponse\x12z\n" +
+	"\x19GetPullRequestsStatistics\x12-.prreview.v1.GetPullRequestsStatisticsRequest\x1a..prreview.v1.GetPullRequestsStatisticsResponse\x12q\n" +
+	"\x16GetReviewersStatistics\x12*.prreview.v1.GetReviewersStatisticsRequest\x1a+.prreview.v1.GetReviewersStatisticsResponseBPZNgithub.com/vedsatt/pr-review-assignment-service/pkg/api/prreview/v1;prreviewv1b\x06proto3"
+
+var (
+	file_prreview_v1_prreview_proto_rawDescOnce sync.Once
+	file_prreview_v1_prreview_proto_rawDescData []byte
+)
+
+func file_prreview_v1_prreview_proto_rawDescGZIP() []byte {
+	file_prreview_v1_prreview_proto_rawDescOnce.Do(func() {
+		file_prreview_v1_prreview_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prreview_v1_prreview_proto_rawDesc), len(file_prreview_v1_prreview_proto_rawDesc)))
+	})
+	return file_prreview_v1_prreview_proto_rawDescData
+}
+
+var file_prreview_v1_prreview_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_prreview_v1_prreview_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_prreview_v1_prreview_proto_goTypes = []any{
+	(PullRequestStatus)(0),                    // 0: prreview.v1.PullRequestStatus
+	(ReviewState)(0),                          // 1: prreview.v1.ReviewState
+	(*TeamMember)(nil),                        // 2: prreview.v1.TeamMember
+	(*Team)(nil),                              // 3: prreview.v1.Team
+	(*User)(nil),                              // 4: prreview.v1.User
+	(*UserDetails)(nil),                       // 5: prreview.v1.UserDetails
+	(*PullRequest)(nil),                       // 6: prreview.v1.PullRequest
+	(*ReviewerAssignment)(nil),                // 7: prreview.v1.ReviewerAssignment
+	(*PullRequestDetails)(nil),                // 8: prreview.v1.PullRequestDetails
+	(*PullRequestShort)(nil),                  // 9: prreview.v1.PullRequestShort
+	(*AddTeamRequest)(nil),                    // 10: prreview.v1.AddTeamRequest
+	(*AddTeamResponse)(nil),                   // 11: prreview.v1.AddTeamResponse
+	(*GetTeamRequest)(nil),                    // 12: prreview.v1.GetTeamRequest
+	(*GetTeamResponse)(nil),                   // 13: prreview.v1.GetTeamResponse
+	(*GetUserRequest)(nil),                    // 14: prreview.v1.GetUserRequest
+	(*GetUserResponse)(nil),                   // 15: prreview.v1.GetUserResponse
+	(*ListUsersRequest)(nil),                  // 16: prreview.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),                 // 17: prreview.v1.ListUsersResponse
+	(*SetIsActiveRequest)(nil),                // 18: prreview.v1.SetIsActiveRequest
+	(*SetIsActiveResponse)(nil),               // 19: prreview.v1.SetIsActiveResponse
+	(*GetReviewsRequest)(nil),                 // 20: prreview.v1.GetReviewsRequest
+	(*GetReviewsResponse)(nil),                // 21: prreview.v1.GetReviewsResponse
+	(*CreatePullRequestRequest)(nil),          // 22: prreview.v1.CreatePullRequestRequest
+	(*CreatePullRequestResponse)(nil),         // 23: prreview.v1.CreatePullRequestResponse
+	(*GetPullRequestRequest)(nil),             // 24: prreview.v1.GetPullRequestRequest
+	(*GetPullRequestResponse)(nil),            // 25: prreview.v1.GetPullRequestResponse
+	(*ListPullRequestsRequest)(nil),           // 26: prreview.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil),          // 27: prreview.v1.ListPullRequestsResponse
+	(*MergePullRequestRequest)(nil),           // 28: prreview.v1.MergePullRequestRequest
+	(*MergePullRequestResponse)(nil),          // 29: prreview.v1.MergePullRequestResponse
+	(*ReassignReviewerRequest)(nil),           // 30: prreview.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),          // 31: prreview.v1.ReassignReviewerResponse
+	(*GetUsersStatisticsRequest)(nil),         // 32: prreview.v1.GetUsersStatisticsRequest
+	(*TeamUsersCount)(nil),                    // 33: prreview.v1.TeamUsersCount
+	(*GetUsersStatisticsResponse)(nil),        // 34: prreview.v1.GetUsersStatisticsResponse
+	(*GetPullRequestsStatisticsRequest)(nil),  // 35: prreview.v1.GetPullRequestsStatisticsRequest
+	(*GetPullRequestsStatisticsResponse)(nil), // 36: prreview.v1.GetPullRequestsStatisticsResponse
+	(*GetReviewersStatisticsRequest)(nil),     // 37: prreview.v1.GetReviewersStatisticsRequest
+	(*ReviewerStats)(nil),                     // 38: prreview.v1.ReviewerStats
+	(*GetReviewersStatisticsResponse)(nil),    // 39: prreview.v1.GetReviewersStatisticsResponse
+	(*timestamppb.Timestamp)(nil),             // 40: google.protobuf.Timestamp
+}
+var file_prreview_v1_prreview_proto_depIdxs = []int32{
+	2,  // 0: prreview.v1.Team.members:type_name -> prreview.v1.TeamMember
+	4,  // 1: prreview.v1.UserDetails.user:type_name -> prreview.v1.User
+	0,  // 2: prreview.v1.PullRequest.status:type_name -> prreview.v1.PullRequestStatus
+	40, // 3: prreview.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	40, // 4: prreview.v1.ReviewerAssignment.assigned_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: prreview.v1.ReviewerAssignment.review_state:type_name -> prreview.v1.ReviewState
+	0,  // 6: prreview.v1.PullRequestDetails.status:type_name -> prreview.v1.PullRequestStatus
+	7,  // 7: prreview.v1.PullRequestDetails.reviewers:type_name -> prreview.v1.ReviewerAssignment
+	40, // 8: prreview.v1.PullRequestDetails.created_at:type_name -> google.protobuf.Timestamp
+	40, // 9: prreview.v1.PullRequestDetails.merged_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: prreview.v1.PullRequestShort.status:type_name -> prreview.v1.PullRequestStatus
+	40, // 11: prreview.v1.PullRequestShort.created_at:type_name -> google.protobuf.Timestamp
+	40, // 12: prreview.v1.PullRequestShort.assigned_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: prreview.v1.PullRequestShort.review_state:type_name -> prreview.v1.ReviewState
+	2,  // 14: prreview.v1.AddTeamRequest.members:type_name -> prreview.v1.TeamMember
+	3,  // 15: prreview.v1.AddTeamResponse.team:type_name -> prreview.v1.Team
+	3,  // 16: prreview.v1.GetTeamResponse.team:type_name -> prreview.v1.Team
+	5,  // 17: prreview.v1.GetUserResponse.user:type_name -> prreview.v1.UserDetails
+	5,  // 18: prreview.v1.ListUsersResponse.users:type_name -> prreview.v1.UserDetails
+	4,  // 19: prreview.v1.SetIsActiveResponse.user:type_name -> prreview.v1.User
+	0,  // 20: prreview.v1.GetReviewsRequest.status:type_name -> prreview.v1.PullRequestStatus
+	9,  // 21: prreview.v1.GetReviewsResponse.pull_requests:type_name -> prreview.v1.PullRequestShort
+	6,  // 22: prreview.v1.CreatePullRequestResponse.pull_request:type_name -> prreview.v1.PullRequest
+	8,  // 23: prreview.v1.GetPullRequestResponse.pull_request:type_name -> prreview.v1.PullRequestDetails
+	0,  // 24: prreview.v1.ListPullRequestsRequest.status:type_name -> prreview.v1.PullRequestStatus
+	40, // 25: prreview.v1.ListPullRequestsRequest.created_from:type_name -> google.protobuf.Timestamp
+	40, // 26: prreview.v1.ListPullRequestsRequest.created_to:type_name -> google.protobuf.Timestamp
+	40, // 27: prreview.v1.ListPullRequestsRequest.merged_from:type_name -> google.protobuf.Timestamp
+	40, // 28: prreview.v1.ListPullRequestsRequest.merged_to:type_name -> google.protobuf.Timestamp
+	8,  // 29: prreview.v1.ListPullRequestsResponse.pull_requests:type_name -> prreview.v1.PullRequestDetails
+	6,  // 30: prreview.v1.MergePullRequestResponse.pull_request:type_name -> prreview.v1.PullRequest
+	6,  // 31: prreview.v1.ReassignReviewerResponse.pull_request:type_name -> prreview.v1.PullRequest
+	33, // 32: prreview.v1.GetUsersStatisticsResponse.users_by_team:type_name -> prreview.v1.TeamUsersCount
+	38, // 33: prreview.v1.GetReviewersStatisticsResponse.top_reviewers:type_name -> prreview.v1.ReviewerStats
+	10, // 34: prreview.v1.TeamService.AddTeam:input_type -> prreview.v1.AddTeamRequest
+	12, // 35: prreview.v1.TeamService.GetTeam:input_type -> prreview.v1.GetTeamRequest
+	14, // 36: prreview.v1.UserService.GetUser:input_type -> prreview.v1.GetUserRequest
+	16, // 37: prreview.v1.UserService.ListUsers:input_type -> prreview.v1.ListUsersRequest
+	18, // 38: prreview.v1.UserService.SetIsActive:input_type -> prreview.v1.SetIsActiveRequest
+	20, // 39: prreview.v1.UserService.GetReviews:input_type -> prreview.v1.GetReviewsRequest
+	22, // 40: prreview.v1.PullRequestService.CreatePullRequest:input_type -> prreview.v1.CreatePullRequestRequest
+	24, // 41: prreview.v1.PullRequestService.GetPullRequest:input_type -> prreview.v1.GetPullRequestRequest
+	26, // 42: prreview.v1.PullRequestService.ListPullRequests:input_type -> prreview.v1.ListPullRequestsRequest
+	28, // 43: prreview.v1.PullRequestService.MergePullRequest:input_type -> prreview.v1.MergePullRequestRequest
+	30, // 44: prreview.v1.PullRequestService.ReassignReviewer:input_type -> prreview.v1.ReassignReviewerRequest
+	32, // 45: prreview.v1.StatisticsService.GetUsersStatistics:input_type -> prreview.v1.GetUsersStatisticsRequest
+	35, // 46: prreview.v1.StatisticsService.GetPullRequestsStatistics:input_type -> prreview.v1.GetPullRequestsStatisticsRequest
+	37, // 47: prreview.v1.StatisticsService.GetReviewersStatistics:input_type -> prreview.v1.GetReviewersStatisticsRequest
+	11, // 48: prreview.v1.TeamService.AddTeam:output_type -> prreview.v1.AddTeamResponse
+	13, // 49: prreview.v1.TeamService.GetTeam:output_type -> prreview.v1.GetTeamResponse
+	15, // 50: prreview.v1.UserService.GetUser:output_type -> prreview.v1.GetUserResponse
+	17, // 51: prreview.v1.UserService.ListUsers:output_type -> prreview.v1.ListUsersResponse
+	19, // 52: prreview.v1.UserService.SetIsActive:output_type -> prreview.v1.SetIsActiveResponse
+	21, // 53: prreview.v1.UserService.GetReviews:output_type -> prreview.v1.GetReviewsResponse
+	23, // 54: prreview.v1.PullRequestService.CreatePullRequest:output_type -> prreview.v1.CreatePullRequestResponse
+	25, // 55: prreview.v1.PullRequestService.GetPullRequest:output_type -> prreview.v1.GetPullRequestResponse
+	27, // 56: prreview.v1.PullRequestService.ListPullRequests:output_type -> prreview.v1.ListPullRequestsResponse
+	29, // 57: prreview.v1.PullRequestService.MergePullRequest:output_type -> prreview.v1.MergePullRequestResponse
+	31, // 58: prreview.v1.PullRequestService.ReassignReviewer:output_type -> prreview.v1.ReassignReviewerResponse
+	34, // 59: prreview.v1.StatisticsService.GetUsersStatistics:output_type -> prreview.v1.GetUsersStatisticsResponse
+	36, // 60: prreview.v1.StatisticsService.GetPullRequestsStatistics:output_type -> prreview.v1.GetPullRequestsStatisticsResponse
+	39, // 61: prreview.v1.StatisticsService.GetReviewersStatistics:output_type -> prreview.v1.GetReviewersStatisticsResponse
+	48, // [48:62] is the sub-list for method output_type
+	34, // [34:48] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_prreview_v1_prreview_proto_init() }
+func file_prreview_v1_prreview_proto_init() {
+	if File_prreview_v1_prreview_proto != nil {
+		return
+	}
+	file_prreview_v1_prreview_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prreview_v1_prreview_proto_rawDesc), len(file_prreview_v1_prreview_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   38,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_prreview_v1_prreview_proto_goTypes,
+		DependencyIndexes: file_prreview_v1_prreview_proto_depIdxs,
+		EnumInfos:         file_prreview_v1_prreview_proto_enumTypes,
+		MessageInfos:      file_prreview_v1_prreview_proto_msgTypes,
+	}.Build()
+	File_prreview_v1_prreview_proto = out.File
+	file_prreview_v1_prreview_proto_goTypes = nil
+	file_prreview_v1_prreview_proto_depIdxs = nil
+}
@@ -0,0 +1,207 @@
+// Package client is a Go SDK for the PR review assignment service HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout    = 10 * time.Second
+	defaultMaxRetries = 3
+	defaultBackoff    = 200 * time.Millisecond
+
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken sets the bearer token sent with every request.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries sets how many times a request is retried after a 5xx response or a transport error.
+// The delay between attempts starts at backoff and doubles after every attempt.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New creates a client for the service available at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// do sends the request and decodes the JSON response into out, error responses are returned as *APIError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	_, err := c.doVersioned(ctx, method, path, query, 0, in, out)
+	return err
+}
+
+// doVersioned sends a non-zero version as If-Match and returns the version from the ETag of the response.
+func (c *Client) doVersioned(
+	ctx context.Context, method, path string, query url.Values, version int64, in, out any,
+) (int64, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	header := http.Header{}
+	if version > 0 {
+		header.Set("If-Match", strconv.Quote(strconv.FormatInt(version, 10)))
+	}
+
+	return c.doRaw(ctx, method, path, query, header, "application/json", body, out)
+}
+
+// doRaw sends an already encoded body of the given content type. A POST gets an Idempotency-Key that is kept
+// across retries, so the service applies a request only once even if the response to it is lost.
+func (c *Client) doRaw(
+	ctx context.Context, method, path string, query url.Values, header http.Header, contentType string, body []byte,
+	out any,
+) (int64, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	if header == nil {
+		header = http.Header{}
+	}
+	if method == http.MethodPost {
+		header.Set(idempotencyKeyHeader, rand.Text())
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		resp, err := c.send(ctx, method, endpoint, header, contentType, reader)
+		retryable := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= c.maxRetries {
+			if err != nil {
+				return 0, err
+			}
+			return parseETag(resp.Header.Get("ETag")), c.decode(resp, out)
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(
+	ctx context.Context, method, endpoint string, header http.Header, contentType string, body io.Reader,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// parseETag returns the version sent by the service, zero when the response has no ETag.
+func parseETag(value string) int64 {
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(value, "W/"))
+	if err != nil {
+		return 0
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+func (c *Client) decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
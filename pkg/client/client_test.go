@@ -0,0 +1,328 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+	"github.com/vedsatt/pr-review-assignment-service/pkg/client"
+)
+
+// api stands in for the service, it answers every request with the next of the queued handlers
+// and records what it has received.
+type api struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers []http.HandlerFunc
+	requests []*http.Request
+	bodies   []string
+}
+
+func newAPI(t *testing.T, handlers ...http.HandlerFunc) *api {
+	t.Helper()
+
+	a := &api{handlers: handlers}
+	a.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		_, _ = body.ReadFrom(r.Body)
+
+		a.mu.Lock()
+		a.requests = append(a.requests, r)
+		a.bodies = append(a.bodies, body.String())
+		if len(a.handlers) == 0 {
+			a.mu.Unlock()
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		handler := a.handlers[0]
+		a.handlers = a.handlers[1:]
+		a.mu.Unlock()
+
+		handler(w, r)
+	}))
+	t.Cleanup(a.Close)
+
+	return a
+}
+
+func (a *api) received() []*http.Request {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]*http.Request(nil), a.requests...)
+}
+
+func respond(statusCode int, body any, headers ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		for i := 0; i+1 < len(headers); i += 2 {
+			w.Header().Set(headers[i], headers[i+1])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func respondError(statusCode int, code, message string) http.HandlerFunc {
+	return respond(statusCode, models.ErrorResponse{Error: models.ErrDetails{Code: code, Message: message}})
+}
+
+func newClient(a *api, opts ...client.Option) *client.Client {
+	opts = append([]client.Option{client.WithRetries(3, time.Millisecond)}, opts...)
+	return client.New(a.URL+"/", opts...)
+}
+
+func TestRetriedPostKeepsIdempotencyKey(t *testing.T) {
+	pullRequest := models.PullRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: "OPEN"}
+	a := newAPI(t,
+		respondError(http.StatusInternalServerError, models.InternalErr, "boom"),
+		respondError(http.StatusServiceUnavailable, models.InternalErr, "boom"),
+		respond(http.StatusCreated, models.CreatePRResponse{PullRequest: pullRequest}, "ETag", `"1"`),
+		respond(http.StatusCreated, models.CreatePRResponse{PullRequest: pullRequest}),
+	)
+	c := newClient(a)
+
+	request := client.CreatePRRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	created, err := c.CreatePullRequest(t.Context(), request)
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if created.ID != "pr-1" || created.Version != 1 {
+		t.Errorf("CreatePullRequest() = %+v, want pr-1 with version 1", created)
+	}
+
+	if _, err = c.CreatePullRequest(t.Context(), request); err != nil {
+		t.Fatalf("second CreatePullRequest() error = %v", err)
+	}
+
+	requests := a.received()
+	if len(requests) != 4 {
+		t.Fatalf("service got %d requests, want 4", len(requests))
+	}
+
+	key := requests[0].Header.Get("Idempotency-Key")
+	if key == "" {
+		t.Fatal("POST was sent without Idempotency-Key")
+	}
+	for i, r := range requests[1:3] {
+		if got := r.Header.Get("Idempotency-Key"); got != key {
+			t.Errorf("retry %d Idempotency-Key = %q, want %q", i+1, got, key)
+		}
+	}
+	if got := requests[3].Header.Get("Idempotency-Key"); got == "" || got == key {
+		t.Errorf("next call Idempotency-Key = %q, want a new key", got)
+	}
+
+	for i, body := range a.bodies {
+		var got models.CreatePRRequest
+		if err = json.Unmarshal([]byte(body), &got); err != nil || got != request {
+			t.Errorf("request %d body = %s, want %+v", i, body, request)
+		}
+	}
+}
+
+func TestGetIsRetriedWithoutIdempotencyKey(t *testing.T) {
+	a := newAPI(t,
+		respondError(http.StatusBadGateway, models.InternalErr, "boom"),
+		respond(http.StatusOK, models.Team{Name: "backend"}, "ETag", `"7"`),
+	)
+
+	team, err := newClient(a, client.WithToken("secret")).GetTeam(t.Context(), "back end")
+	if err != nil {
+		t.Fatalf("GetTeam() error = %v", err)
+	}
+	if team.Name != "backend" || team.Version != 7 {
+		t.Errorf("GetTeam() = %+v, want backend with version 7", team)
+	}
+
+	for _, r := range a.received() {
+		if r.URL.EscapedPath() != "/api/v1/teams/back%20end" {
+			t.Errorf("path = %s, want /api/v1/teams/back%%20end", r.URL.EscapedPath())
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want Bearer secret", got)
+		}
+		if got := r.Header.Get("Idempotency-Key"); got != "" {
+			t.Errorf("GET was sent with Idempotency-Key %q", got)
+		}
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	a := newAPI(t,
+		respondError(http.StatusInternalServerError, models.InternalErr, "boom"),
+		respondError(http.StatusInternalServerError, models.InternalErr, "boom"),
+	)
+
+	_, err := newClient(a, client.WithRetries(1, time.Millisecond)).GetUsersStatistics(t.Context())
+	if !errors.Is(err, client.ErrInternal) {
+		t.Fatalf("GetUsersStatistics() error = %v, want ErrInternal", err)
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || apiErr.Message != "boom" {
+		t.Errorf("GetUsersStatistics() error = %+v, want the last 500 response", apiErr)
+	}
+	if got := len(a.received()); got != 2 {
+		t.Errorf("service got %d requests, want 2", got)
+	}
+}
+
+func TestClientErrorsAreNotRetried(t *testing.T) {
+	a := newAPI(t, respond(http.StatusBadRequest, models.ErrorResponse{
+		Error: models.ErrDetails{
+			Code:    models.ValidationErr,
+			Message: "request validation failed",
+			Details: []models.FieldError{{Field: "team_name", Rule: "required", Message: "is required"}},
+		},
+	}))
+
+	_, err := newClient(a).AddTeam(t.Context(), client.AddTeamRequest{})
+	if !errors.Is(err, client.ErrValidation) {
+		t.Fatalf("AddTeam() error = %v, want ErrValidation", err)
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Details) != 1 || apiErr.Details[0].Field != "team_name" {
+		t.Errorf("AddTeam() error = %+v, want team_name details", apiErr)
+	}
+	if got := len(a.received()); got != 1 {
+		t.Errorf("service got %d requests, want 1", got)
+	}
+}
+
+func TestMergeSendsVersionAsIfMatch(t *testing.T) {
+	merged := models.PullRequest{ID: "pr-1", Status: "MERGED"}
+	a := newAPI(t,
+		respond(http.StatusOK, models.MergePullRequestResponse{PullRequest: merged}, "ETag", `"4"`),
+		respond(http.StatusOK, models.MergePullRequestResponse{PullRequest: merged}, "ETag", `"4"`),
+		respondError(http.StatusPreconditionFailed, models.PreconditionErr, "version mismatch"),
+	)
+	c := newClient(a)
+
+	pullRequest, err := c.MergePullRequest(t.Context(), "pr-1", 3)
+	if err != nil {
+		t.Fatalf("MergePullRequest() error = %v", err)
+	}
+	if pullRequest.Status != "MERGED" || pullRequest.Version != 4 {
+		t.Errorf("MergePullRequest() = %+v, want merged with version 4", pullRequest)
+	}
+
+	if _, err = c.MergePullRequest(t.Context(), "pr-1", 0); err != nil {
+		t.Fatalf("MergePullRequest() without version error = %v", err)
+	}
+
+	_, err = c.MergePullRequest(t.Context(), "pr-1", 2)
+	if !errors.Is(err, client.ErrPrecondition) {
+		t.Errorf("MergePullRequest() with a stale version error = %v, want ErrPrecondition", err)
+	}
+
+	requests := a.received()
+	wantIfMatch := []string{`"3"`, "", `"2"`}
+	for i, r := range requests {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/pull-requests/pr-1/merge" {
+			t.Errorf("request %d = %s %s, want POST /api/v1/pull-requests/pr-1/merge", i, r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("If-Match"); got != wantIfMatch[i] {
+			t.Errorf("request %d If-Match = %q, want %q", i, got, wantIfMatch[i])
+		}
+	}
+}
+
+func TestReassignReviewer(t *testing.T) {
+	a := newAPI(t, respond(http.StatusOK, models.ReassignPullRequestReviewerResponse{
+		PullRequest: models.PullRequest{ID: "pr-1", AssignedReviewers: []string{"u3"}},
+		ReplacedBy:  "u3",
+	}, "ETag", `"6"`))
+
+	pullRequest, replacedBy, err := newClient(a).ReassignReviewer(t.Context(), "pr-1", "u2", 5)
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if replacedBy != "u3" || pullRequest.Version != 6 {
+		t.Errorf("ReassignReviewer() = %+v, %q, want u3 with version 6", pullRequest, replacedBy)
+	}
+
+	r := a.received()[0]
+	if got := r.Header.Get("If-Match"); got != `"5"` {
+		t.Errorf("If-Match = %q, want \"5\"", got)
+	}
+	if got := a.bodies[0]; got != `{"old_reviewer_id":"u2"}` {
+		t.Errorf("body = %s, want old_reviewer_id u2", got)
+	}
+}
+
+func TestListPullRequestsQuery(t *testing.T) {
+	a := newAPI(t, respond(http.StatusOK, models.ListPullRequestsResponse{NextCursor: "next"}))
+
+	from := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	resp, err := newClient(a).ListPullRequests(t.Context(), client.ListPullRequestsRequest{
+		Status:      "OPEN",
+		TeamName:    "backend",
+		CreatedFrom: &from,
+		Limit:       10,
+	})
+	if err != nil {
+		t.Fatalf("ListPullRequests() error = %v", err)
+	}
+	if resp.NextCursor != "next" {
+		t.Errorf("NextCursor = %q, want next", resp.NextCursor)
+	}
+
+	want := "created_from=2025-01-02T03%3A04%3A05Z&limit=10&status=OPEN&team_name=backend"
+	if got := a.received()[0].URL.RawQuery; got != want {
+		t.Errorf("query = %s, want %s", got, want)
+	}
+}
+
+func TestContextCancelsBackoff(t *testing.T) {
+	a := newAPI(t, respondError(http.StatusInternalServerError, models.InternalErr, "boom"))
+	c := client.New(a.URL, client.WithRetries(3, time.Hour))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.GetPullRequestStatistics(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetPullRequestStatistics() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestExportBackupIsNotRetried(t *testing.T) {
+	a := newAPI(t, respondError(http.StatusInternalServerError, models.InternalErr, "boom"))
+
+	var backup bytes.Buffer
+	if err := newClient(a).ExportBackup(t.Context(), &backup); !errors.Is(err, client.ErrInternal) {
+		t.Errorf("ExportBackup() error = %v, want ErrInternal", err)
+	}
+	if got := len(a.received()); got != 1 {
+		t.Errorf("service got %d requests, want 1", got)
+	}
+}
+
+func TestSetUserStatusSendsTeamVersion(t *testing.T) {
+	a := newAPI(t, respond(http.StatusOK, models.SetUserStatusResponse{User: models.User{ID: "u2"}}))
+
+	if _, err := newClient(a).SetUserStatus(t.Context(), "u2", false, 8); err != nil {
+		t.Fatalf("SetUserStatus() error = %v", err)
+	}
+
+	r := a.received()[0]
+	if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/users/u2" {
+		t.Errorf("request = %s %s, want PATCH /api/v1/users/u2", r.Method, r.URL.Path)
+	}
+	if got := r.Header.Get("If-Match"); got != `"8"` {
+		t.Errorf("If-Match = %q, want \"8\"", got)
+	}
+	if got := a.bodies[0]; got != `{"is_active":false}` {
+		t.Errorf("body = %s, want is_active false", got)
+	}
+}
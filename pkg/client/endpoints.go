@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+func setQuery(query url.Values, name, value string) {
+	if value != "" {
+		query.Set(name, value)
+	}
+}
+
+func setTimeQuery(query url.Values, name string, value *time.Time) {
+	if value != nil {
+		query.Set(name, value.Format(time.RFC3339Nano))
+	}
+}
+
+func (c *Client) AddTeam(ctx context.Context, request AddTeamRequest) (*Team, error) {
+	var resp models.AddTeamResponse
+	version, err := c.doVersioned(ctx, http.MethodPost, "/api/v1/teams", nil, 0, request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Team.Version = version
+	return &resp.Team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	var team models.Team
+	path := "/api/v1/teams/" + url.PathEscape(teamName)
+	version, err := c.doVersioned(ctx, http.MethodGet, path, nil, 0, nil, &team)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Version = version
+	return &team, nil
+}
+
+// ImportTeams uploads an org chart, contentType is one of "text/csv", "application/json" or "application/yaml".
+// With dryRun the service reports the changes without applying them.
+func (c *Client) ImportTeams(ctx context.Context, contentType string, data []byte, dryRun bool) (*ImportResult, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	var resp models.ImportResult
+	_, err := c.doRaw(ctx, http.MethodPost, "/api/v1/teams/import", query, nil, contentType, data, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetUser(ctx context.Context, userID string) (*UserDetails, error) {
+	var resp models.GetUserResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(userID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
+
+// ListUsers returns a page of users ordered by id, pass NextCursor of the response as Cursor to get the next one.
+func (c *Client) ListUsers(ctx context.Context, filter ListUsersRequest) (*ListUsersResponse, error) {
+	query := url.Values{}
+	setQuery(query, "team_name", filter.TeamName)
+	setQuery(query, "name_prefix", filter.NamePrefix)
+	setQuery(query, "cursor", filter.Cursor)
+	if filter.IsActive != nil {
+		query.Set("is_active", strconv.FormatBool(*filter.IsActive))
+	}
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var resp models.ListUsersResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users", query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetUserStatus activates or deactivates the user. A non-zero version of the user's team, e.g. Team.Version,
+// is sent as If-Match, since deactivation reassigns reviews and changes the team.
+func (c *Client) SetUserStatus(ctx context.Context, userID string, isActive bool, version int64) (*User, error) {
+	var resp models.SetUserStatusResponse
+	request := models.UpdateUserRequest{IsActive: &isActive}
+	path := "/api/v1/users/" + url.PathEscape(userID)
+	if _, err := c.doVersioned(ctx, http.MethodPatch, path, nil, version, request, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
+
+// GetUserReviews returns a page of the user's reviews, most recently assigned first.
+func (c *Client) GetUserReviews(ctx context.Context, request UserReviewsRequest) (*GetUserReviewsResponse, error) {
+	query := url.Values{}
+	setQuery(query, "status", request.Status)
+	setQuery(query, "cursor", request.Cursor)
+	if request.Limit > 0 {
+		query.Set("limit", strconv.Itoa(request.Limit))
+	}
+
+	var resp models.GetUserReviewsResponse
+	path := "/api/v1/users/" + url.PathEscape(request.UserID) + "/reviews"
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, request CreatePRRequest) (*PullRequest, error) {
+	var resp models.CreatePRResponse
+	version, err := c.doVersioned(ctx, http.MethodPost, "/api/v1/pull-requests", nil, 0, request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PullRequest.Version = version
+	return &resp.PullRequest, nil
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, pullRequestID string) (*PullRequestDetails, error) {
+	var resp models.GetPullRequestResponse
+	path := "/api/v1/pull-requests/" + url.PathEscape(pullRequestID)
+	version, err := c.doVersioned(ctx, http.MethodGet, path, nil, 0, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PullRequest.Version = version
+	return &resp.PullRequest, nil
+}
+
+// ListPullRequests returns a page of pull requests, pass NextCursor of the response as Cursor to get the next one.
+func (c *Client) ListPullRequests(
+	ctx context.Context, filter ListPullRequestsRequest,
+) (*ListPullRequestsResponse, error) {
+	query := url.Values{}
+	setQuery(query, "status", filter.Status)
+	setQuery(query, "author_id", filter.AuthorID)
+	setQuery(query, "team_name", filter.TeamName)
+	setQuery(query, "reviewer_id", filter.ReviewerID)
+	setTimeQuery(query, "created_from", filter.CreatedFrom)
+	setTimeQuery(query, "created_to", filter.CreatedTo)
+	setTimeQuery(query, "merged_from", filter.MergedFrom)
+	setTimeQuery(query, "merged_to", filter.MergedTo)
+	setQuery(query, "sort_by", filter.SortBy)
+	setQuery(query, "order", filter.Order)
+	setQuery(query, "cursor", filter.Cursor)
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var resp models.ListPullRequestsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/pull-requests", query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// MergePullRequest merges the pull request. A non-zero version, e.g. PullRequestDetails.Version, is sent as If-Match
+// and the call fails with ErrPrecondition if the pull request has changed since it was read.
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestID string, version int64) (*PullRequest, error) {
+	var resp models.MergePullRequestResponse
+	path := "/api/v1/pull-requests/" + url.PathEscape(pullRequestID) + "/merge"
+	newVersion, err := c.doVersioned(ctx, http.MethodPost, path, nil, version, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PullRequest.Version = newVersion
+	return &resp.PullRequest, nil
+}
+
+// ReassignReviewer replaces the reviewer and returns the updated pull request and the id of the new reviewer.
+// A non-zero version is sent as If-Match, the same way as in MergePullRequest.
+func (c *Client) ReassignReviewer(
+	ctx context.Context, pullRequestID, oldReviewerID string, version int64,
+) (*PullRequest, string, error) {
+	var resp models.ReassignPullRequestReviewerResponse
+	request := struct {
+		OldReviewerID string `json:"old_reviewer_id"`
+	}{OldReviewerID: oldReviewerID}
+
+	path := "/api/v1/pull-requests/" + url.PathEscape(pullRequestID) + "/reassign"
+	newVersion, err := c.doVersioned(ctx, http.MethodPost, path, nil, version, request, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp.PullRequest.Version = newVersion
+	return &resp.PullRequest, resp.ReplacedBy, nil
+}
+
+func (c *Client) GetUsersStatistics(ctx context.Context) (*UserStatsResponse, error) {
+	var resp models.UserStatsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/statistics/users", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetPullRequestStatistics(ctx context.Context) (*PullRequestsStatsResponse, error) {
+	var resp models.PullRequestsStatsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/statistics/pull-requests", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetReviewersStatistics(ctx context.Context) (*ReviewersStatsResponse, error) {
+	var resp models.ReviewersStatsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/statistics/reviewers", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) CreateAPIToken(ctx context.Context, request CreateAPITokenRequest) (*CreateAPITokenResponse, error) {
+	var resp models.CreateAPITokenResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/tokens", nil, request, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) RevokeAPIToken(ctx context.Context, tokenID int64) error {
+	path := "/api/v1/auth/tokens/" + strconv.FormatInt(tokenID, 10)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// ExportBackup streams an NDJSON backup of all data into w. The request is not retried, since a part of the backup
+// may already be written. Large backups may need a longer timeout than the default one, see WithHTTPClient.
+func (c *Client) ExportBackup(ctx context.Context, w io.Writer) error {
+	resp, err := c.send(ctx, http.MethodGet, c.baseURL+"/api/v1/backup/export", nil, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
+
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreBackup streams an NDJSON backup made by ExportBackup into an empty service. The request is not retried.
+func (c *Client) RestoreBackup(ctx context.Context, r io.Reader) (*BackupCounts, error) {
+	resp, err := c.send(ctx, http.MethodPost, c.baseURL+"/api/v1/backup/restore", nil, "application/x-ndjson", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored models.RestoreBackupResponse
+	if err = c.decode(resp, &restored); err != nil {
+		return nil, err
+	}
+
+	return &restored.Restored, nil
+}
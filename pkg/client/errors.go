@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/vedsatt/pr-review-assignment-service/internal/models"
+)
+
+// Sentinel errors for every error code of the API, match them with errors.Is.
+var (
+	ErrTeamExists   = errors.New("team already exists")
+	ErrUserExists   = errors.New("user already exists")
+	ErrPRExists     = errors.New("pull request already exists")
+	ErrPRMerged     = errors.New("pull request is merged")
+	ErrNotAssigned  = errors.New("reviewer is not assigned")
+	ErrNoCandidate  = errors.New("no candidate for review")
+	ErrNotFound     = errors.New("resource not found")
+	ErrInvalidJSON  = errors.New("invalid json")
+	ErrInvalidReq   = errors.New("invalid request")
+	ErrValidation   = errors.New("request validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrKeyReused    = errors.New("idempotency key reused")
+	ErrInProgress   = errors.New("request in progress")
+	ErrPrecondition = errors.New("precondition failed")
+	ErrInternal     = errors.New("internal error")
+)
+
+// APIError is an error response of the API, use errors.As to get the code, message and field details.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    []FieldError
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (%d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	switch e.Code {
+	case models.TeamExistsErr:
+		return ErrTeamExists
+	case models.UserExistsErr:
+		return ErrUserExists
+	case models.PRExistsErr:
+		return ErrPRExists
+	case models.PRMergedErr:
+		return ErrPRMerged
+	case models.NotAssignedErr:
+		return ErrNotAssigned
+	case models.NoCandidateErr:
+		return ErrNoCandidate
+	case models.NotFoundErr:
+		return ErrNotFound
+	case models.InvalidJSONErr:
+		return ErrInvalidJSON
+	case models.InvalidReqErr:
+		return ErrInvalidReq
+	case models.ValidationErr:
+		return ErrValidation
+	case models.UnauthorizedErr:
+		return ErrUnauthorized
+	case models.ForbiddenErr:
+		return ErrForbidden
+	case models.KeyReusedErr:
+		return ErrKeyReused
+	case models.InProgressErr:
+		return ErrInProgress
+	case models.PreconditionErr:
+		return ErrPrecondition
+	default:
+		return ErrInternal
+	}
+}
+
+func newAPIError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read error response: %w", err)
+	}
+
+	var errResp models.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code == "" {
+		apiErr.Code = models.InternalErr
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	apiErr.Code = errResp.Error.Code
+	apiErr.Message = errResp.Error.Message
+	apiErr.Details = errResp.Error.Details
+
+	return apiErr
+}
@@ -0,0 +1,34 @@
+package client
+
+import "github.com/vedsatt/pr-review-assignment-service/internal/models"
+
+// Aliases of the service models, so that code outside of this module can use them.
+type (
+	Team                      = models.Team
+	TeamMember                = models.TeamMember
+	User                      = models.User
+	UserDetails               = models.UserDetails
+	ListUsersRequest          = models.ListUsersRequest
+	ListUsersResponse         = models.ListUsersResponse
+	PullRequest               = models.PullRequest
+	PullRequestShort          = models.PullRequestShort
+	PullRequestDetails        = models.PullRequestDetails
+	ReviewerAssignment        = models.ReviewerAssignment
+	FieldError                = models.FieldError
+	AddTeamRequest            = models.AddTeamRequest
+	CreatePRRequest           = models.CreatePRRequest
+	UserReviewsRequest        = models.UserReviewsRequest
+	GetUserReviewsResponse    = models.GetUserReviewsResponse
+	ListPullRequestsRequest   = models.ListPullRequestsRequest
+	ListPullRequestsResponse  = models.ListPullRequestsResponse
+	CreateAPITokenRequest     = models.CreateAPITokenRequest
+	CreateAPITokenResponse    = models.CreateAPITokenResponse
+	UserStatsResponse         = models.UserStatsResponse
+	TeamUsersCount            = models.TeamUsersCount
+	PullRequestsStatsResponse = models.PullRequestsStatsResponse
+	ReviewersStatsResponse    = models.ReviewersStatsResponse
+	Reviewers                 = models.Reviewers
+	ImportResult              = models.ImportResult
+	ImportRowResult           = models.ImportRowResult
+	BackupCounts              = models.BackupCounts
+)